@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jsonPathSegments splits a JSON Path (e.g. `properties.ipConfigurations[0].name`) into its segments, where
+// keys containing a `.` can be quoted using brackets (e.g. `tags["hidden.link"]`)
+func jsonPathSegments(path string) ([]string, error) {
+	segments := make([]string, 0)
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+
+	current := ""
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '.':
+			if current != "" {
+				segments = append(segments, current)
+				current = ""
+			}
+
+		case '[':
+			if current != "" {
+				segments = append(segments, current)
+				current = ""
+			}
+
+			end := strings.IndexByte(path[i:], ']')
+			if end == -1 {
+				return nil, fmt.Errorf("parsing %q: missing closing `]`", path)
+			}
+			segment := path[i+1 : i+end]
+			if unquoted, err := strconv.Unquote(segment); err == nil {
+				segment = unquoted
+			}
+			if segment == "" {
+				return nil, fmt.Errorf("parsing %q: empty `[]`", path)
+			}
+			segments = append(segments, segment)
+			i += end
+
+		default:
+			current += string(path[i])
+		}
+	}
+
+	if current != "" {
+		segments = append(segments, current)
+	}
+
+	return segments, nil
+}
+
+// evaluateJsonPath returns the value at the specified JSON Path within the input, and whether it exists
+func evaluateJsonPath(input interface{}, path string) (interface{}, bool, error) {
+	segments, err := jsonPathSegments(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	current := input
+	for i, segment := range segments {
+		switch v := current.(type) {
+		case map[string]interface{}:
+			value, ok := v[segment]
+			if !ok {
+				return nil, false, nil
+			}
+			current = value
+
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil {
+				return nil, false, fmt.Errorf("evaluating %q: expected an index for the list at %q but got %q", path, strings.Join(segments[:i], "."), segment)
+			}
+			if index < 0 || index >= len(v) {
+				return nil, false, nil
+			}
+			current = v[index]
+
+		default:
+			return nil, false, nil
+		}
+	}
+
+	return current, true, nil
+}
+
+// normalizeJsonValue round-trips the input through JSON, so that Go values (e.g. an `int` or a struct) can be
+// compared against the deserialized JSON body
+func normalizeJsonValue(input interface{}) (interface{}, error) {
+	if v, ok := input.(json.RawMessage); ok {
+		var out interface{}
+		if err := json.Unmarshal(v, &out); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+
+	raw, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var out interface{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const testArmResourceBody = `{
+  "id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/networkInterfaces/example",
+  "tags": {
+    "hidden.link": "value"
+  },
+  "properties": {
+    "enableAcceleratedNetworking": true,
+    "ipConfigurations": [
+      {
+        "name": "internal",
+        "properties": {
+          "privateIPAddressVersion": "IPv4",
+          "primary": true
+        }
+      }
+    ]
+  }
+}`
+
+func TestJsonPathSegments(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []string
+		error    bool
+	}{
+		{
+			input:    "properties.sku.name",
+			expected: []string{"properties", "sku", "name"},
+		},
+		{
+			input:    "$.properties.ipConfigurations[0].name",
+			expected: []string{"properties", "ipConfigurations", "0", "name"},
+		},
+		{
+			input:    "properties.ipConfigurations.0.name",
+			expected: []string{"properties", "ipConfigurations", "0", "name"},
+		},
+		{
+			input:    `tags["hidden.link"]`,
+			expected: []string{"tags", "hidden.link"},
+		},
+		{
+			input: "properties.ipConfigurations[0",
+			error: true,
+		},
+		{
+			input: "properties[]",
+			error: true,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Logf("[DEBUG] Testing %q", v.input)
+
+		actual, err := jsonPathSegments(v.input)
+		if err != nil {
+			if v.error {
+				continue
+			}
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestEvaluateJsonPath(t *testing.T) {
+	var body interface{}
+	if err := json.Unmarshal([]byte(testArmResourceBody), &body); err != nil {
+		t.Fatalf("deserializing body: %+v", err)
+	}
+
+	testCases := []struct {
+		path     string
+		expected interface{}
+		exists   bool
+		error    bool
+	}{
+		{
+			path:     "properties.enableAcceleratedNetworking",
+			expected: true,
+			exists:   true,
+		},
+		{
+			path:     "properties.ipConfigurations[0].properties.privateIPAddressVersion",
+			expected: "IPv4",
+			exists:   true,
+		},
+		{
+			path:     `tags["hidden.link"]`,
+			expected: "value",
+			exists:   true,
+		},
+		{
+			path:   "properties.ipConfigurations[1].name",
+			exists: false,
+		},
+		{
+			path:   "properties.dnsSettings",
+			exists: false,
+		},
+		{
+			path:   "properties.enableAcceleratedNetworking.nested",
+			exists: false,
+		},
+		{
+			path:  "properties.ipConfigurations.first",
+			error: true,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Logf("[DEBUG] Testing %q", v.path)
+
+		actual, exists, err := evaluateJsonPath(body, v.path)
+		if err != nil {
+			if v.error {
+				continue
+			}
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if exists != v.exists {
+			t.Fatalf("expected exists to be %t but got %t", v.exists, exists)
+		}
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestNormalizeJsonValue(t *testing.T) {
+	testCases := []struct {
+		input    interface{}
+		expected interface{}
+	}{
+		{
+			input:    3,
+			expected: float64(3),
+		},
+		{
+			input:    map[string]string{"hello": "world"},
+			expected: map[string]interface{}{"hello": "world"},
+		},
+		{
+			input:    json.RawMessage(`["a", 1]`),
+			expected: []interface{}{"a", float64(1)},
+		},
+		{
+			input: struct {
+				Name string `json:"name"`
+			}{Name: "example"},
+			expected: map[string]interface{}{"name": "example"},
+		},
+	}
+
+	for _, v := range testCases {
+		actual, err := normalizeJsonValue(v.input)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
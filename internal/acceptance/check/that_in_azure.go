@@ -0,0 +1,227 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/helpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/testclient"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// RemoteResourceFunc retrieves the Resource from Azure, returning either the deserialized JSON body or
+// a model which can be serialized to JSON (e.g. the Model from a go-azure-sdk response)
+type RemoteResourceFunc func(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (interface{}, error)
+
+type thatInAzureType struct {
+	// resourceName being the full resource name e.g. azurerm_foo.bar
+	resourceName string
+
+	// apiVersion is the API Version used for the ARM GET, defaulting to the latest stable API version
+	apiVersion string
+
+	// remoteResourceFunc retrieves the Resource, when nil a generic ARM GET is used for the `id` in the State
+	remoteResourceFunc RemoteResourceFunc
+}
+
+// InAzure returns a type which can be used for assertions against the Resource as it exists in Azure,
+// which is retrieved using a generic ARM GET for the Resource ID in the State
+func (t thatType) InAzure() thatInAzureType {
+	return thatInAzureType{
+		resourceName: t.resourceName,
+	}
+}
+
+// InAzureUsing returns a type which can be used for assertions against the Resource as it exists in Azure,
+// which is retrieved using the specified function - for example using the same SDK Client as the Exists function
+func (t thatType) InAzureUsing(f RemoteResourceFunc) thatInAzureType {
+	return thatInAzureType{
+		resourceName:       t.resourceName,
+		remoteResourceFunc: f,
+	}
+}
+
+// WithApiVersion specifies the API Version to use when retrieving the Resource using a generic ARM GET
+func (t thatInAzureType) WithApiVersion(apiVersion string) thatInAzureType {
+	t.apiVersion = apiVersion
+	return t
+}
+
+// Key returns a type which can be used for assertions on the value at the specified JSON Path
+// (e.g. `properties.sku.name` or `properties.ipConfigurations[0].name`) within the Resource
+func (t thatInAzureType) Key(path string) thatInAzureWithKeyType {
+	return thatInAzureWithKeyType{
+		that: t,
+		path: path,
+	}
+}
+
+// retrieve returns the deserialized JSON body for the Resource
+func (t thatInAzureType) retrieve(s *terraform.State) (interface{}, error) {
+	rs, ok := s.RootModule().Resources[t.resourceName]
+	if !ok {
+		return nil, fmt.Errorf("%q was not found in the state", t.resourceName)
+	}
+
+	client, err := testclient.Build()
+	if err != nil {
+		return nil, fmt.Errorf("building client: %+v", err)
+	}
+
+	ctx, cancel := context.WithDeadline(client.StopContext, time.Now().Add(5*time.Minute))
+	defer cancel()
+
+	var out interface{}
+	if t.remoteResourceFunc != nil {
+		out, err = t.remoteResourceFunc(ctx, client, rs.Primary)
+	} else {
+		out, err = helpers.GetArmResource(ctx, client, rs.Primary.ID, t.apiVersion)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %q from Azure: %+v", t.resourceName, err)
+	}
+
+	out, err = normalizeJsonValue(out)
+	if err != nil {
+		return nil, fmt.Errorf("serializing %q to JSON: %+v", t.resourceName, err)
+	}
+
+	return out, nil
+}
+
+type thatInAzureWithKeyType struct {
+	that thatInAzureType
+
+	// path being the JSON Path to the value we're querying e.g. properties.sku.name
+	path string
+}
+
+// checkValue returns a TestCheckFunc which retrieves the value at the JSON Path and passes it to the assertion
+func (t thatInAzureWithKeyType) checkValue(assertion func(value interface{}, exists bool) error) pluginsdk.TestCheckFunc {
+	return func(s *terraform.State) error {
+		body, err := t.that.retrieve(s)
+		if err != nil {
+			return err
+		}
+
+		value, exists, err := evaluateJsonPath(body, t.path)
+		if err != nil {
+			return err
+		}
+
+		if err := assertion(value, exists); err != nil {
+			return fmt.Errorf("%q in Azure: %+v", t.that.resourceName, err)
+		}
+
+		return nil
+	}
+}
+
+// Exists returns a TestCheckFunc which validates that the JSON Path exists on the Resource in Azure
+func (t thatInAzureWithKeyType) Exists() pluginsdk.TestCheckFunc {
+	return t.checkValue(func(_ interface{}, exists bool) error {
+		if !exists {
+			return fmt.Errorf("expected %q to exist but it didn't", t.path)
+		}
+		return nil
+	})
+}
+
+// DoesNotExist returns a TestCheckFunc which validates that the JSON Path does not exist (or is null) on the
+// Resource in Azure
+func (t thatInAzureWithKeyType) DoesNotExist() pluginsdk.TestCheckFunc {
+	return t.checkValue(func(value interface{}, exists bool) error {
+		if exists && value != nil {
+			return fmt.Errorf("expected %q not to exist but it had the value %s", t.path, formatJsonValue(value))
+		}
+		return nil
+	})
+}
+
+// HasValue returns a TestCheckFunc which validates that the value at the JSON Path matches the expected value,
+// which can be any value which can be serialized to JSON (e.g. a string, a number or a map) - or a json.RawMessage.
+// When the values differ, the diff between the expected and actual values is returned.
+func (t thatInAzureWithKeyType) HasValue(expected interface{}) pluginsdk.TestCheckFunc {
+	return t.checkValue(func(value interface{}, exists bool) error {
+		if !exists {
+			return fmt.Errorf("expected %q to have the value %s but it didn't exist", t.path, formatJsonValue(expected))
+		}
+
+		normalized, err := normalizeJsonValue(expected)
+		if err != nil {
+			return fmt.Errorf("serializing the expected value for %q to JSON: %+v", t.path, err)
+		}
+
+		if diff := cmp.Diff(normalized, value); diff != "" {
+			return fmt.Errorf("unexpected value for %q (-expected +actual):\n%s", t.path, diff)
+		}
+		return nil
+	})
+}
+
+// MatchesRegex returns a TestCheckFunc which validates that the value at the JSON Path is a string matching
+// the given regular expression
+func (t thatInAzureWithKeyType) MatchesRegex(r *regexp.Regexp) pluginsdk.TestCheckFunc {
+	return t.checkValue(func(value interface{}, exists bool) error {
+		if !exists {
+			return fmt.Errorf("expected %q to match %q but it didn't exist", t.path, r.String())
+		}
+
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected %q to be a string but got %s", t.path, formatJsonValue(value))
+		}
+		if !r.MatchString(v) {
+			return fmt.Errorf("expected %q to match %q but got %q", t.path, r.String(), v)
+		}
+		return nil
+	})
+}
+
+// MatchesStateKey returns a TestCheckFunc which validates that the value at the JSON Path matches the value
+// for the specified key in the State for this Resource
+func (t thatInAzureWithKeyType) MatchesStateKey(key string) pluginsdk.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[t.that.resourceName]
+		if !ok {
+			return fmt.Errorf("%q was not found in the state", t.that.resourceName)
+		}
+
+		expected, ok := rs.Primary.Attributes[key]
+		if !ok {
+			return fmt.Errorf("the value %q does not exist within %q", key, t.that.resourceName)
+		}
+
+		return t.checkValue(func(value interface{}, exists bool) error {
+			if !exists {
+				return fmt.Errorf("expected %q to match the state key %q (%q) but it didn't exist", t.path, key, expected)
+			}
+
+			actual := fmt.Sprintf("%v", value)
+			if v, ok := value.(string); ok {
+				actual = v
+			}
+			if actual != expected {
+				return fmt.Errorf("expected %q to match the state key %q (%q) but got %q", t.path, key, expected, actual)
+			}
+			return nil
+		})(s)
+	}
+}
+
+func formatJsonValue(input interface{}) string {
+	v, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Sprintf("%+v", input)
+	}
+	return string(v)
+}
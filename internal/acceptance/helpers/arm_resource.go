@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2022-09-01/providers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// GetArmResource retrieves the raw JSON body for the specified Resource ID from Azure Resource Manager, using the
+// latest stable API version for the Resource Type when apiVersion isn't specified.
+func GetArmResource(ctx context.Context, client *clients.Client, resourceId, apiVersion string) (interface{}, error) {
+	if apiVersion == "" {
+		v, err := latestApiVersionForResourceId(ctx, client, resourceId)
+		if err != nil {
+			return nil, err
+		}
+		apiVersion = *v
+	}
+
+	resourcesClient := client.Resource.ResourcesClient
+	req, err := resourcesClient.GetByIDPreparer(ctx, strings.TrimPrefix(resourceId, "/"), apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("preparing request for %q: %+v", resourceId, err)
+	}
+
+	resp, err := resourcesClient.GetByIDSender(req)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %q: %+v", resourceId, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body for %q: %+v", resourceId, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("retrieving %q (API Version %q): unexpected status %d: %s", resourceId, apiVersion, resp.StatusCode, string(body))
+	}
+
+	var out interface{}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, fmt.Errorf("deserializing response body for %q: %+v", resourceId, err)
+	}
+
+	return out, nil
+}
+
+func latestApiVersionForResourceId(ctx context.Context, client *clients.Client, resourceId string) (*string, error) {
+	subscriptionId, namespace, resourceType, err := resourceProviderAndTypeFromResourceId(resourceId)
+	if err != nil {
+		return nil, err
+	}
+	if subscriptionId == "" {
+		subscriptionId = client.Account.SubscriptionId
+	}
+
+	providerId := providers.NewSubscriptionProviderID(subscriptionId, namespace)
+	resp, err := client.Resource.ResourceProvidersClient.Get(ctx, providerId, providers.DefaultGetOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", providerId, err)
+	}
+	if resp.Model == nil || resp.Model.ResourceTypes == nil {
+		return nil, fmt.Errorf("retrieving %s: `model.resourceTypes` was nil", providerId)
+	}
+
+	for _, v := range *resp.Model.ResourceTypes {
+		if v.ResourceType == nil || !strings.EqualFold(*v.ResourceType, resourceType) || v.ApiVersions == nil {
+			continue
+		}
+
+		if apiVersion := latestApiVersion(*v.ApiVersions); apiVersion != "" {
+			return &apiVersion, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no API Versions were found for the Resource Type %q within %s", resourceType, providerId)
+}
+
+// resourceProviderAndTypeFromResourceId returns the Subscription ID, Resource Provider Namespace and Resource Type
+// (e.g. `storageAccounts/blobServices`) for the specified Resource ID. For extension resources the Resource Type
+// is determined from the last `providers` segment.
+func resourceProviderAndTypeFromResourceId(resourceId string) (string, string, string, error) {
+	segments := strings.Split(strings.Trim(resourceId, "/"), "/")
+	if len(segments) < 2 || len(segments)%2 != 0 {
+		return "", "", "", fmt.Errorf("parsing %q: expected an even number of segments", resourceId)
+	}
+
+	subscriptionId := ""
+	if strings.EqualFold(segments[0], "subscriptions") {
+		subscriptionId = segments[1]
+	}
+
+	providerIndex := -1
+	for i := 0; i < len(segments); i += 2 {
+		if strings.EqualFold(segments[i], "providers") {
+			providerIndex = i
+		}
+	}
+
+	if providerIndex == -1 {
+		// Resource Groups and Subscriptions are the only Resources without a `providers` segment
+		if len(segments) == 4 && strings.EqualFold(segments[2], "resourceGroups") {
+			return subscriptionId, "Microsoft.Resources", "resourceGroups", nil
+		}
+		return "", "", "", fmt.Errorf("parsing %q: the Resource Provider could not be determined", resourceId)
+	}
+
+	namespace := segments[providerIndex+1]
+	typeSegments := segments[providerIndex+2:]
+	if len(typeSegments) == 0 {
+		return "", "", "", fmt.Errorf("parsing %q: the Resource Type could not be determined", resourceId)
+	}
+
+	resourceTypes := make([]string, 0)
+	for i := 0; i < len(typeSegments); i += 2 {
+		resourceTypes = append(resourceTypes, typeSegments[i])
+	}
+
+	return subscriptionId, namespace, strings.Join(resourceTypes, "/"), nil
+}
+
+// latestApiVersion returns the latest stable API version, falling back to the latest preview API version when no
+// stable API versions are available
+func latestApiVersion(input []string) string {
+	stable := make([]string, 0)
+	preview := make([]string, 0)
+	for _, v := range input {
+		if strings.Contains(strings.ToLower(v), "preview") {
+			preview = append(preview, v)
+			continue
+		}
+		stable = append(stable, v)
+	}
+
+	for _, versions := range [][]string{stable, preview} {
+		if len(versions) > 0 {
+			sort.Strings(versions)
+			return versions[len(versions)-1]
+		}
+	}
+
+	return ""
+}
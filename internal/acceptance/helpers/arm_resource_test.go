@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helpers
+
+import (
+	"testing"
+)
+
+func TestResourceProviderAndTypeFromResourceId(t *testing.T) {
+	testCases := []struct {
+		input          string
+		subscriptionId string
+		namespace      string
+		resourceType   string
+		error          bool
+	}{
+		{
+			input:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+			subscriptionId: "00000000-0000-0000-0000-000000000000",
+			namespace:      "Microsoft.Resources",
+			resourceType:   "resourceGroups",
+		},
+		{
+			input:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example/blobServices/default",
+			subscriptionId: "00000000-0000-0000-0000-000000000000",
+			namespace:      "Microsoft.Storage",
+			resourceType:   "storageAccounts/blobServices",
+		},
+		{
+			// extension resources use the last providers segment
+			input:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Compute/virtualMachines/example/providers/Microsoft.Insights/diagnosticSettings/example",
+			subscriptionId: "00000000-0000-0000-0000-000000000000",
+			namespace:      "Microsoft.Insights",
+			resourceType:   "diagnosticSettings",
+		},
+		{
+			input:        "/providers/Microsoft.Management/managementGroups/example",
+			namespace:    "Microsoft.Management",
+			resourceType: "managementGroups",
+		},
+		{
+			input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups",
+			error: true,
+		},
+		{
+			input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage",
+			error: true,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Logf("[DEBUG] Testing %q", v.input)
+
+		subscriptionId, namespace, resourceType, err := resourceProviderAndTypeFromResourceId(v.input)
+		if err != nil {
+			if v.error {
+				continue
+			}
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if subscriptionId != v.subscriptionId || namespace != v.namespace || resourceType != v.resourceType {
+			t.Fatalf("expected %q / %q / %q but got %q / %q / %q", v.subscriptionId, v.namespace, v.resourceType, subscriptionId, namespace, resourceType)
+		}
+	}
+}
+
+func TestLatestApiVersion(t *testing.T) {
+	testCases := []struct {
+		input    []string
+		expected string
+	}{
+		{
+			input:    []string{},
+			expected: "",
+		},
+		{
+			input:    []string{"2021-01-01", "2023-05-01", "2022-09-01"},
+			expected: "2023-05-01",
+		},
+		{
+			input:    []string{"2023-01-01", "2024-01-01-preview"},
+			expected: "2023-01-01",
+		},
+		{
+			input:    []string{"2023-01-01-preview", "2024-01-01-preview"},
+			expected: "2024-01-01-preview",
+		},
+	}
+
+	for _, v := range testCases {
+		if actual := latestApiVersion(v.input); actual != v.expected {
+			t.Fatalf("expected %q but got %q for %+v", v.expected, actual, v.input)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ssh
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachines"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	hostKeysBeginMarker = "-----BEGIN SSH HOST KEY KEYS-----"
+	hostKeysEndMarker   = "-----END SSH HOST KEY KEYS-----"
+)
+
+// KeyPair is an SSH Key Pair generated for an acceptance test, where the PublicKey can be used in the
+// `public_key` field of an `azurerm_ssh_public_key` or the `admin_ssh_key` block of a Virtual Machine
+type KeyPair struct {
+	// PrivateKey is the Signer used to authenticate using this Key Pair
+	PrivateKey ssh.Signer
+
+	// PrivateKeyPEM is the PEM encoded Private Key
+	PrivateKeyPEM string
+
+	// PublicKey is the Public Key in the OpenSSH `authorized_keys` format
+	PublicKey string
+}
+
+// GenerateKeyPair generates a new RSA Key Pair, since RSA keys are supported by all Azure Virtual Machine images
+func GenerateKeyPair() (*KeyPair, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("generating RSA key: %+v", err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("building signer: %+v", err)
+	}
+
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+
+	return &KeyPair{
+		PrivateKey:    signer,
+		PrivateKeyPEM: string(privateKeyPEM),
+		PublicKey:     strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey()))),
+	}, nil
+}
+
+// ParseHostKeys parses the Host Keys from the input, which contains one Public Key per line in either the
+// `authorized_keys` or `known_hosts` format - blank lines and comments are ignored
+func ParseHostKeys(input string) ([]ssh.PublicKey, error) {
+	keys := make([]ssh.PublicKey, 0)
+
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(line)); err == nil {
+			keys = append(keys, key)
+			continue
+		}
+
+		_, _, key, _, _, err := ssh.ParseKnownHosts([]byte(line))
+		if err != nil {
+			return nil, fmt.Errorf("parsing host key %q: %+v", line, err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// HostKeysFromSerialConsoleLog parses the Host Keys which cloud-init writes to the Serial Console between the
+// `BEGIN SSH HOST KEY KEYS` and `END SSH HOST KEY KEYS` markers
+func HostKeysFromSerialConsoleLog(input string) ([]ssh.PublicKey, error) {
+	keys := make([]ssh.PublicKey, 0)
+
+	inBlock := false
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.Contains(line, hostKeysBeginMarker) {
+			inBlock = true
+			keys = keys[:0]
+			continue
+		}
+		if strings.Contains(line, hostKeysEndMarker) {
+			inBlock = false
+			continue
+		}
+		if !inBlock {
+			continue
+		}
+
+		// each line can be prefixed by the kernel timestamp / cloud-init process, so find the start of the key
+		for _, field := range strings.Fields(line) {
+			if strings.HasPrefix(field, "ssh-") || strings.HasPrefix(field, "ecdsa-") {
+				key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(line[strings.Index(line, field):]))
+				if err != nil {
+					return nil, fmt.Errorf("parsing host key from %q: %+v", line, err)
+				}
+				keys = append(keys, key)
+				break
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no host keys were found in the serial console log")
+	}
+
+	return keys, nil
+}
+
+// HostKeysFromBootDiagnostics retrieves the Host Keys for a Linux Virtual Machine from the Serial Console Log
+// available via Boot Diagnostics, which requires Boot Diagnostics to be enabled on the Virtual Machine
+func HostKeysFromBootDiagnostics(ctx context.Context, client *clients.Client, id virtualmachines.VirtualMachineId) ([]ssh.PublicKey, error) {
+	resp, err := client.Compute.VirtualMachinesClient.RetrieveBootDiagnosticsData(ctx, id, virtualmachines.DefaultRetrieveBootDiagnosticsDataOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("retrieving boot diagnostics data for %s: %+v", id, err)
+	}
+	if resp.Model == nil || resp.Model.SerialConsoleLogBlobUri == nil {
+		return nil, fmt.Errorf("retrieving boot diagnostics data for %s: `serialConsoleLogBlobUri` was nil", id)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, *resp.Model.SerialConsoleLogBlobUri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building request for the serial console log for %s: %+v", id, err)
+	}
+
+	logResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving the serial console log for %s: %+v", id, err)
+	}
+	defer logResp.Body.Close()
+
+	if logResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("retrieving the serial console log for %s: unexpected status %d", id, logResp.StatusCode)
+	}
+
+	serialLog, err := io.ReadAll(logResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading the serial console log for %s: %+v", id, err)
+	}
+
+	return HostKeysFromSerialConsoleLog(string(serialLog))
+}
+
+type hostKeyMismatchError struct {
+	hostname string
+	key      ssh.PublicKey
+}
+
+func (e *hostKeyMismatchError) Error() string {
+	return fmt.Sprintf("the host key presented by %q (%s %s) did not match any of the known host keys", e.hostname, e.key.Type(), ssh.FingerprintSHA256(e.key))
+}
+
+// hostKeyCallback returns a HostKeyCallback which requires the host to present one of the specified Host Keys
+func hostKeyCallback(hostKeys []ssh.PublicKey) ssh.HostKeyCallback {
+	return func(hostname string, _ net.Addr, key ssh.PublicKey) error {
+		for _, v := range hostKeys {
+			if v.Type() == key.Type() && bytes.Equal(v.Marshal(), key.Marshal()) {
+				return nil
+			}
+		}
+
+		return &hostKeyMismatchError{
+			hostname: hostname,
+			key:      key,
+		}
+	}
+}
@@ -6,8 +6,11 @@ package ssh
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net"
+	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -16,51 +19,182 @@ import (
 )
 
 type Runner struct {
-	Hostname      string
-	Port          int
-	Username      string
-	Password      string
+	Hostname string
+	Port     int
+	Username string
+
+	// Password is used to authenticate when PrivateKey isn't specified
+	Password string
+
+	// PrivateKey is used to authenticate using a Key Pair, see GenerateKeyPair
+	PrivateKey ssh.Signer
+
+	// HostKeys are the known Host Keys, one of which must be presented by the host
+	HostKeys []ssh.PublicKey
+
+	// InsecureIgnoreHostKey skips verifying the Host Key when HostKeys isn't specified
+	InsecureIgnoreHostKey bool
+
+	// FilesToUpload are uploaded (using SCP) prior to running any commands
+	FilesToUpload []File
+
 	CommandsToRun []string
+
+	// Timeout is how long to retry connecting to the host for, defaulting to 5 minutes
+	Timeout time.Duration
+}
+
+// CommandResult is the output from running a command on the host
+type CommandResult struct {
+	Command    string
+	Stdout     string
+	Stderr     string
+	ExitStatus int
 }
 
 func (r Runner) Run(ctx context.Context) error {
-	if err := retry.RetryContext(ctx, 5*time.Minute, r.tryRun); err != nil {
-		return err
+	_, err := r.RunWithOutput(ctx)
+	return err
+}
+
+// RunWithOutput uploads any files and then runs each of the commands, returning the output from each command
+func (r Runner) RunWithOutput(ctx context.Context) ([]CommandResult, error) {
+	config, err := r.clientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := r.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Minute
+	}
+
+	var results []CommandResult
+	err = retry.RetryContext(ctx, timeout, func() *pluginsdk.RetryError {
+		var retryErr *pluginsdk.RetryError
+		results, retryErr = r.tryRun(ctx, config)
+		return retryErr
+	})
+	if err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+func (r Runner) clientConfig() (*ssh.ClientConfig, error) {
+	auth := make([]ssh.AuthMethod, 0)
+	if r.PrivateKey != nil {
+		auth = append(auth, ssh.PublicKeys(r.PrivateKey))
+	} else if r.Password != "" {
+		auth = append(auth, ssh.Password(r.Password))
+	} else {
+		return nil, fmt.Errorf("either `PrivateKey` or `Password` must be specified")
+	}
+
+	var callback ssh.HostKeyCallback
+	var hostKeyAlgorithms []string
+	switch {
+	case len(r.HostKeys) > 0:
+		callback = hostKeyCallback(r.HostKeys)
+		hostKeyAlgorithms = hostKeyAlgorithmsForKeys(r.HostKeys)
+	case r.InsecureIgnoreHostKey:
+		log.Printf("[WARN] Host Key verification is disabled for %q", r.Hostname)
+		callback = ssh.InsecureIgnoreHostKey()
+	default:
+		return nil, fmt.Errorf("either `HostKeys` must be specified or `InsecureIgnoreHostKey` must be enabled")
 	}
 
-	return nil
+	return &ssh.ClientConfig{
+		User:            r.Username,
+		Auth:            auth,
+		HostKeyCallback: callback,
+		// only negotiate the algorithms for the known Host Keys, otherwise the host could present a different key
+		HostKeyAlgorithms: hostKeyAlgorithms,
+		Timeout:           30 * time.Second,
+	}, nil
 }
 
-func (r Runner) tryRun() *pluginsdk.RetryError {
-	config := &ssh.ClientConfig{
-		User: r.Username,
-		Auth: []ssh.AuthMethod{
-			ssh.Password(r.Password),
-		},
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+func hostKeyAlgorithmsForKeys(hostKeys []ssh.PublicKey) []string {
+	algorithms := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, key := range hostKeys {
+		keyAlgorithms := []string{key.Type()}
+		if key.Type() == ssh.KeyAlgoRSA {
+			keyAlgorithms = []string{ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSA}
+		}
+
+		for _, v := range keyAlgorithms {
+			if _, ok := seen[v]; !ok {
+				seen[v] = struct{}{}
+				algorithms = append(algorithms, v)
+			}
+		}
 	}
+	return algorithms
+}
 
-	hostAddress := fmt.Sprintf("%s:%d", r.Hostname, r.Port)
+func (r Runner) tryRun(ctx context.Context, config *ssh.ClientConfig) ([]CommandResult, *pluginsdk.RetryError) {
+	hostAddress := net.JoinHostPort(r.Hostname, strconv.Itoa(r.Port))
 	log.Printf("[INFO] SSHing to %q...", hostAddress)
 	client, err := ssh.Dial("tcp", hostAddress, config)
 	if err != nil {
-		return pluginsdk.RetryableError(fmt.Errorf("connecting to host: %+v", err))
+		// a mismatched host key won't resolve itself, so there's no point retrying
+		var keyErr *hostKeyMismatchError
+		if errors.As(err, &keyErr) {
+			return nil, pluginsdk.NonRetryableError(fmt.Errorf("connecting to host: %+v", err))
+		}
+		return nil, pluginsdk.RetryableError(fmt.Errorf("connecting to host: %+v", err))
+	}
+	defer client.Close()
+
+	for _, file := range r.FilesToUpload {
+		log.Printf("[DEBUG] Uploading %q..", file.Path)
+		if err := uploadFile(ctx, client, file); err != nil {
+			return nil, pluginsdk.NonRetryableError(fmt.Errorf("uploading %q: %+v", file.Path, err))
+		}
+	}
+
+	results := make([]CommandResult, 0)
+	for _, cmd := range r.CommandsToRun {
+		log.Printf("[DEBUG] Running %q..", cmd)
+		result, err := runCommand(client, cmd)
+		if result != nil {
+			results = append(results, *result)
+		}
+		if err != nil {
+			return results, pluginsdk.NonRetryableError(err)
+		}
 	}
 
+	return results, nil
+}
+
+// runCommand runs the command in a new session, since a session can only be used to run a single command
+func runCommand(client *ssh.Client, cmd string) (*CommandResult, error) {
 	session, err := client.NewSession()
 	if err != nil {
-		return pluginsdk.RetryableError(fmt.Errorf("creating session: %+v", err))
+		return nil, fmt.Errorf("creating session: %+v", err)
 	}
 	defer session.Close()
 
-	for _, cmd := range r.CommandsToRun {
-		log.Printf("[DEBUG] Running %q..", cmd)
-		var b bytes.Buffer
-		session.Stdout = &b
-		if err := session.Run(cmd); err != nil {
-			return pluginsdk.NonRetryableError(fmt.Errorf("failure running command %q: %+v", cmd, err))
+	var stdout, stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+
+	result := &CommandResult{
+		Command: cmd,
+	}
+	err = session.Run(cmd)
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+	if err != nil {
+		var exitErr *ssh.ExitError
+		if errors.As(err, &exitErr) {
+			result.ExitStatus = exitErr.ExitStatus()
 		}
+		return result, fmt.Errorf("failure running command %q: %+v\n\nStdout: %s\nStderr: %s", cmd, err, result.Stdout, result.Stderr)
 	}
 
-	return nil
+	return result, nil
 }
@@ -0,0 +1,366 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ssh
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// testServer is a minimal SSH Server which supports running `echo`, `exit` and `scp -qt` commands
+type testServer struct {
+	t        *testing.T
+	listener net.Listener
+	hostKey  ssh.Signer
+
+	lock  sync.Mutex
+	files map[string]uploadedFile
+}
+
+type uploadedFile struct {
+	mode     string
+	contents string
+}
+
+func newTestServer(t *testing.T, authorizedKey ssh.PublicKey, password string) *testServer {
+	t.Helper()
+
+	hostKey, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("generating host key: %+v", err)
+	}
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if authorizedKey != nil && bytes.Equal(key.Marshal(), authorizedKey.Marshal()) {
+				return &ssh.Permissions{}, nil
+			}
+			return nil, fmt.Errorf("unauthorized key")
+		},
+		PasswordCallback: func(_ ssh.ConnMetadata, input []byte) (*ssh.Permissions, error) {
+			if password != "" && string(input) == password {
+				return &ssh.Permissions{}, nil
+			}
+			return nil, fmt.Errorf("unauthorized password")
+		},
+	}
+	config.AddHostKey(hostKey.PrivateKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %+v", err)
+	}
+
+	server := &testServer{
+		t:        t,
+		listener: listener,
+		hostKey:  hostKey.PrivateKey,
+		files:    make(map[string]uploadedFile),
+	}
+	t.Cleanup(func() {
+		listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.handleConnection(conn, config)
+		}
+	}()
+
+	return server
+}
+
+func (s *testServer) uploadedFile(path string) (uploadedFile, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	v, ok := s.files[path]
+	return v, ok
+}
+
+func (s *testServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *testServer) handleConnection(conn net.Conn, config *ssh.ServerConfig) {
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+
+		go func() {
+			defer channel.Close()
+			for req := range channelRequests {
+				if req.Type != "exec" {
+					_ = req.Reply(false, nil)
+					continue
+				}
+
+				// the payload is a uint32 length followed by the command
+				command := string(req.Payload[4:])
+				_ = req.Reply(true, nil)
+
+				exitStatus := s.runCommand(command, channel)
+				status := make([]byte, 4)
+				binary.BigEndian.PutUint32(status, uint32(exitStatus))
+				_, _ = channel.SendRequest("exit-status", false, status)
+				return
+			}
+		}()
+	}
+}
+
+func (s *testServer) runCommand(command string, channel ssh.Channel) int {
+	switch {
+	case strings.HasPrefix(command, "echo "):
+		fmt.Fprintln(channel, strings.TrimPrefix(command, "echo "))
+		return 0
+
+	case strings.HasPrefix(command, "exit "):
+		fmt.Fprintln(channel.Stderr(), "exiting")
+		v, _ := strconv.Atoi(strings.TrimPrefix(command, "exit "))
+		return v
+
+	case strings.HasPrefix(command, "scp -qt "):
+		directory := strings.Trim(strings.TrimPrefix(command, "scp -qt "), "'")
+		if err := s.receiveFile(directory, channel); err != nil {
+			s.t.Logf("receiving file: %+v", err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintf(channel.Stderr(), "unknown command %q\n", command)
+	return 127
+}
+
+func (s *testServer) receiveFile(directory string, channel ssh.Channel) error {
+	reader := bufio.NewReader(channel)
+	if _, err := channel.Write([]byte{0}); err != nil {
+		return err
+	}
+
+	header, err := reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	fields := strings.SplitN(strings.TrimSpace(header), " ", 3)
+	if len(fields) != 3 || !strings.HasPrefix(fields[0], "C") {
+		return fmt.Errorf("unexpected header %q", header)
+	}
+	size, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return err
+	}
+	if _, err := channel.Write([]byte{0}); err != nil {
+		return err
+	}
+
+	contents := make([]byte, size+1)
+	if _, err := io.ReadFull(reader, contents); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	s.files[directory+fields[2]] = uploadedFile{
+		mode:     strings.TrimPrefix(fields[0], "C"),
+		contents: string(contents[:size]),
+	}
+	s.lock.Unlock()
+
+	_, err = channel.Write([]byte{0})
+	return err
+}
+
+func TestRunnerKeyPairAuthentication(t *testing.T) {
+	keyPair, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("generating key pair: %+v", err)
+	}
+	server := newTestServer(t, keyPair.PrivateKey.PublicKey(), "")
+
+	hostKeys, err := ParseHostKeys(string(ssh.MarshalAuthorizedKey(server.hostKey.PublicKey())))
+	if err != nil {
+		t.Fatalf("parsing host keys: %+v", err)
+	}
+
+	runner := Runner{
+		Hostname:   "127.0.0.1",
+		Port:       server.port(),
+		Username:   "adminuser",
+		PrivateKey: keyPair.PrivateKey,
+		HostKeys:   hostKeys,
+		FilesToUpload: []File{
+			{
+				Path:     "/tmp/example.sh",
+				Contents: []byte("echo hello"),
+				Mode:     0o755,
+			},
+		},
+		CommandsToRun: []string{
+			"echo hello",
+			"echo world",
+		},
+	}
+
+	results, err := runner.RunWithOutput(context.TODO())
+	if err != nil {
+		t.Fatalf("running commands: %+v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results but got %d", len(results))
+	}
+	if results[0].Stdout != "hello\n" || results[1].Stdout != "world\n" {
+		t.Fatalf("unexpected output: %+v", results)
+	}
+
+	file, ok := server.uploadedFile("/tmp/example.sh")
+	if !ok {
+		t.Fatalf("expected `/tmp/example.sh` to be uploaded")
+	}
+	if file.mode != "0755" || file.contents != "echo hello" {
+		t.Fatalf("unexpected file uploaded: %+v", file)
+	}
+}
+
+func TestRunnerHostKeyMismatch(t *testing.T) {
+	keyPair, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("generating key pair: %+v", err)
+	}
+	server := newTestServer(t, keyPair.PrivateKey.PublicKey(), "")
+
+	otherHostKey, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("generating key pair: %+v", err)
+	}
+
+	runner := Runner{
+		Hostname:      "127.0.0.1",
+		Port:          server.port(),
+		Username:      "adminuser",
+		PrivateKey:    keyPair.PrivateKey,
+		HostKeys:      []ssh.PublicKey{otherHostKey.PrivateKey.PublicKey()},
+		CommandsToRun: []string{"echo hello"},
+		Timeout:       time.Minute,
+	}
+
+	start := time.Now()
+	_, err = runner.RunWithOutput(context.TODO())
+	if err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+	if !strings.Contains(err.Error(), "did not match any of the known host keys") {
+		t.Fatalf("expected a host key mismatch error but got: %+v", err)
+	}
+	if time.Since(start) > 30*time.Second {
+		t.Fatalf("expected a host key mismatch not to be retried")
+	}
+}
+
+func TestRunnerCommandFailure(t *testing.T) {
+	server := newTestServer(t, nil, "P@ssw0rd1234!")
+
+	runner := Runner{
+		Hostname:              "127.0.0.1",
+		Port:                  server.port(),
+		Username:              "adminuser",
+		Password:              "P@ssw0rd1234!",
+		InsecureIgnoreHostKey: true,
+		CommandsToRun: []string{
+			"echo first",
+			"exit 3",
+			"echo never",
+		},
+	}
+
+	results, err := runner.RunWithOutput(context.TODO())
+	if err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results but got %d", len(results))
+	}
+	if results[1].ExitStatus != 3 || results[1].Stderr != "exiting\n" {
+		t.Fatalf("unexpected result for the failed command: %+v", results[1])
+	}
+}
+
+func TestRunnerRequiresHostKeyVerification(t *testing.T) {
+	runner := Runner{
+		Hostname: "127.0.0.1",
+		Port:     22,
+		Username: "adminuser",
+		Password: "P@ssw0rd1234!",
+	}
+
+	if _, err := runner.RunWithOutput(context.TODO()); err == nil {
+		t.Fatalf("expected an error when neither `HostKeys` or `InsecureIgnoreHostKey` are specified")
+	}
+}
+
+func TestHostKeysFromSerialConsoleLog(t *testing.T) {
+	first, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("generating key pair: %+v", err)
+	}
+	second, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("generating key pair: %+v", err)
+	}
+
+	serialLog := fmt.Sprintf(`[  OK  ] Started OpenBSD Secure Shell server.
+[   25.123456] cloud-init[1234]: Cloud-init v. 23.1 running 'modules:final'
+-----BEGIN SSH HOST KEY KEYS-----
+[   25.654321] cloud-init[1234]: %s root@example
+%s root@example
+-----END SSH HOST KEY KEYS-----
+[   26.000000] cloud-init[1234]: Cloud-init v. 23.1 finished
+`, first.PublicKey, second.PublicKey)
+
+	keys, err := HostKeysFromSerialConsoleLog(serialLog)
+	if err != nil {
+		t.Fatalf("parsing serial console log: %+v", err)
+	}
+
+	if len(keys) != 2 {
+		t.Fatalf("expected 2 keys but got %d", len(keys))
+	}
+	if !bytes.Equal(keys[0].Marshal(), first.PrivateKey.PublicKey().Marshal()) || !bytes.Equal(keys[1].Marshal(), second.PrivateKey.PublicKey().Marshal()) {
+		t.Fatalf("the parsed keys didn't match the expected keys")
+	}
+
+	if _, err := HostKeysFromSerialConsoleLog("[  OK  ] Started OpenBSD Secure Shell server."); err == nil {
+		t.Fatalf("expected an error when the serial console log contains no host keys")
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ssh
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// File is a file which is uploaded to the host
+type File struct {
+	// Path is the absolute path on the host the file should be uploaded to
+	Path string
+
+	Contents []byte
+
+	// Mode is the permissions for the file, defaulting to 0644
+	Mode os.FileMode
+}
+
+// uploadFile uploads the file using the SCP protocol, which is supported by the OpenSSH server on all of the
+// Linux images available in Azure without any additional configuration
+func uploadFile(ctx context.Context, client *ssh.Client, file File) error {
+	if !path.IsAbs(file.Path) {
+		return fmt.Errorf("the path %q must be absolute", file.Path)
+	}
+	directory, filename := path.Split(file.Path)
+	if filename == "" || strings.ContainsAny(filename, "\n") {
+		return fmt.Errorf("the path %q must contain a valid file name", file.Path)
+	}
+
+	mode := file.Mode
+	if mode == 0 {
+		mode = 0o644
+	}
+
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("creating session: %+v", err)
+	}
+	defer session.Close()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("opening stdin: %+v", err)
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("opening stdout: %+v", err)
+	}
+	var stderr bytes.Buffer
+	session.Stderr = &stderr
+
+	if err := session.Start(fmt.Sprintf("scp -qt %s", shellQuote(directory))); err != nil {
+		return fmt.Errorf("starting scp: %+v", err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- sendFile(stdin, bufio.NewReader(stdout), filename, mode, file.Contents)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errCh:
+		if err != nil {
+			return fmt.Errorf("%+v\n\nStderr: %s", err, stderr.String())
+		}
+	}
+
+	if err := session.Wait(); err != nil {
+		return fmt.Errorf("waiting for scp: %+v\n\nStderr: %s", err, stderr.String())
+	}
+
+	return nil
+}
+
+func sendFile(stdin io.WriteCloser, stdout *bufio.Reader, filename string, mode os.FileMode, contents []byte) error {
+	defer stdin.Close()
+
+	// the remote end acknowledges that it's ready, then each message we send
+	if err := readScpAck(stdout); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(stdin, "C%04o %d %s\n", mode.Perm(), len(contents), filename); err != nil {
+		return fmt.Errorf("sending file header: %+v", err)
+	}
+	if err := readScpAck(stdout); err != nil {
+		return err
+	}
+
+	if _, err := stdin.Write(contents); err != nil {
+		return fmt.Errorf("sending file contents: %+v", err)
+	}
+	if _, err := stdin.Write([]byte{0}); err != nil {
+		return fmt.Errorf("sending file terminator: %+v", err)
+	}
+
+	return readScpAck(stdout)
+}
+
+// readScpAck reads the acknowledgement from the remote end, which is either `0` for success or `1` / `2`
+// followed by an error message
+func readScpAck(stdout *bufio.Reader) error {
+	code, err := stdout.ReadByte()
+	if err != nil {
+		return fmt.Errorf("reading scp acknowledgement: %+v", err)
+	}
+
+	if code == 0 {
+		return nil
+	}
+
+	message, _ := stdout.ReadString('\n')
+	return fmt.Errorf("scp returned an error (%d): %s", code, strings.TrimSpace(message))
+}
+
+// shellQuote quotes the input for use within a POSIX shell
+func shellQuote(input string) string {
+	return "'" + strings.ReplaceAll(input, "'", `'\''`) + "'"
+}
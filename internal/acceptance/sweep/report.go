@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sweep
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Report contains the Results from running each of the Sweepers
+type Report struct {
+	DryRun  bool
+	Results []Result
+}
+
+// Result is the outcome of running a single Sweeper
+type Result struct {
+	Sweeper string
+
+	// Matched are the Resources which matched one of the Sweeper's Prefixes
+	Matched []Resource
+
+	// Deleted are the Resources which were deleted, which is empty for a Dry Run
+	Deleted []Resource
+
+	// Failed are the Resources which couldn't be deleted
+	Failed []Failure
+
+	// Error is the error returned when listing the Resources
+	Error error
+}
+
+// Failure is a Resource which couldn't be deleted
+type Failure struct {
+	Resource Resource
+	Error    error
+}
+
+// HasErrors returns whether any of the Sweepers failed to list or delete Resources
+func (r Report) HasErrors() bool {
+	for _, result := range r.Results {
+		if result.Error != nil || len(result.Failed) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a human readable summary of the Report
+func (r Report) String() string {
+	var out strings.Builder
+
+	if r.DryRun {
+		out.WriteString("Dry Run - the following Resources would be deleted:\n")
+	}
+
+	for _, result := range r.Results {
+		fmt.Fprintf(&out, "\n%s:\n", result.Sweeper)
+		if result.Error != nil {
+			fmt.Fprintf(&out, "  Error: %+v\n", result.Error)
+			continue
+		}
+
+		if len(result.Matched) == 0 {
+			out.WriteString("  No Resources found\n")
+			continue
+		}
+
+		if r.DryRun {
+			for _, resource := range result.Matched {
+				fmt.Fprintf(&out, "  - %s\n", resource.ID)
+			}
+			continue
+		}
+
+		fmt.Fprintf(&out, "  Deleted %d of %d Resources\n", len(result.Deleted), len(result.Matched))
+		for _, resource := range result.Deleted {
+			fmt.Fprintf(&out, "  - %s\n", resource.ID)
+		}
+		for _, failure := range result.Failed {
+			fmt.Fprintf(&out, "  ! %s: %+v\n", failure.Resource.ID, failure.Error)
+		}
+	}
+
+	return out.String()
+}
@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// Resource is a Resource which has been found by a Sweeper
+type Resource struct {
+	// ID is the Resource ID, which is passed to the Delete function
+	ID string
+
+	// Name is the name of the Resource, which is matched against the Prefixes for the Sweeper
+	Name string
+}
+
+// ListFunc lists all of the candidate Resources for a Sweeper, which are filtered by the Sweeper's Prefixes
+type ListFunc func(ctx context.Context, client *clients.Client) ([]Resource, error)
+
+// DeleteFunc deletes a Resource which has been matched by a Sweeper
+type DeleteFunc func(ctx context.Context, client *clients.Client, resource Resource) error
+
+// Sweeper removes the Resources leaked by failed acceptance test runs
+type Sweeper struct {
+	// Name is a unique name for this Sweeper, e.g. `azurerm_resource_group`
+	Name string
+
+	// Dependencies are the names of the Sweepers which must be run before this Sweeper - for example the
+	// Sweepers for Resources which prevent a Resource Group from being deleted
+	Dependencies []string
+
+	// Prefixes are the name prefixes used by the acceptance tests (e.g. `acctestRG-`) - only the Resources
+	// whose name starts with one of these prefixes are deleted
+	Prefixes []string
+
+	List   ListFunc
+	Delete DeleteFunc
+}
+
+// Registry contains the Sweepers which have been registered
+type Registry struct {
+	sweepers map[string]Sweeper
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		sweepers: make(map[string]Sweeper),
+	}
+}
+
+// Register adds the Sweepers to the Registry, returning an error if a Sweeper is invalid or already registered
+func (r *Registry) Register(sweepers ...Sweeper) error {
+	for _, s := range sweepers {
+		if s.Name == "" {
+			return fmt.Errorf("a Sweeper must have a Name")
+		}
+		if _, exists := r.sweepers[s.Name]; exists {
+			return fmt.Errorf("the Sweeper %q is already registered", s.Name)
+		}
+		if len(s.Prefixes) == 0 {
+			return fmt.Errorf("the Sweeper %q must specify at least one Prefix", s.Name)
+		}
+		for _, prefix := range s.Prefixes {
+			if strings.TrimSpace(prefix) == "" {
+				return fmt.Errorf("the Sweeper %q has an empty Prefix", s.Name)
+			}
+		}
+		if s.List == nil || s.Delete == nil {
+			return fmt.Errorf("the Sweeper %q must specify both a List and a Delete function", s.Name)
+		}
+
+		r.sweepers[s.Name] = s
+	}
+
+	return nil
+}
+
+// Options configures how the Sweepers are run
+type Options struct {
+	// DryRun lists the Resources which would be deleted, without deleting them
+	DryRun bool
+
+	// Sweepers limits the Sweepers which are run to the specified names (and their Dependencies),
+	// all of the registered Sweepers are run when this is empty
+	Sweepers []string
+}
+
+// Run runs the Sweepers in dependency order, continuing when a Sweeper fails so that as many leaked Resources
+// as possible are removed. An error is returned when the Sweepers couldn't be ordered, the Report should be
+// checked for the failures of individual Sweepers.
+func (r *Registry) Run(ctx context.Context, client *clients.Client, options Options) (*Report, error) {
+	order, err := r.order(options.Sweepers)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		DryRun: options.DryRun,
+	}
+	for _, name := range order {
+		report.Results = append(report.Results, r.runSweeper(ctx, client, r.sweepers[name], options.DryRun))
+	}
+
+	return report, nil
+}
+
+func (r *Registry) runSweeper(ctx context.Context, client *clients.Client, sweeper Sweeper, dryRun bool) Result {
+	result := Result{
+		Sweeper: sweeper.Name,
+	}
+
+	log.Printf("[DEBUG] Sweeper %q: listing Resources..", sweeper.Name)
+	resources, err := sweeper.List(ctx, client)
+	if err != nil {
+		result.Error = fmt.Errorf("listing Resources: %+v", err)
+		return result
+	}
+
+	for _, resource := range resources {
+		if !matchesPrefix(resource.Name, sweeper.Prefixes) {
+			continue
+		}
+
+		result.Matched = append(result.Matched, resource)
+		if dryRun {
+			continue
+		}
+
+		log.Printf("[DEBUG] Sweeper %q: deleting %q..", sweeper.Name, resource.ID)
+		if err := sweeper.Delete(ctx, client, resource); err != nil {
+			result.Failed = append(result.Failed, Failure{
+				Resource: resource,
+				Error:    err,
+			})
+			continue
+		}
+		result.Deleted = append(result.Deleted, resource)
+	}
+
+	return result
+}
+
+// order returns the names of the Sweepers to run, where each Sweeper is preceded by its Dependencies
+func (r *Registry) order(input []string) ([]string, error) {
+	names := append([]string{}, input...)
+	if len(names) == 0 {
+		for name := range r.sweepers {
+			names = append(names, name)
+		}
+	}
+	// sorting ensures the order is stable between runs
+	sort.Strings(names)
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+	order := make([]string, 0)
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		sweeper, ok := r.sweepers[name]
+		if !ok {
+			if len(path) == 0 {
+				return fmt.Errorf("the Sweeper %q is not registered", name)
+			}
+			return fmt.Errorf("the Sweeper %q depends on %q which is not registered", path[len(path)-1], name)
+		}
+
+		if state[name] == visited {
+			return nil
+		}
+
+		next := append(append([]string{}, path...), name)
+		if state[name] == visiting {
+			return fmt.Errorf("the Sweepers contain a dependency cycle: %s", strings.Join(next, " -> "))
+		}
+
+		state[name] = visiting
+		dependencies := append([]string{}, sweeper.Dependencies...)
+		sort.Strings(dependencies)
+		for _, dependency := range dependencies {
+			if err := visit(dependency, next); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		order = append(order, name)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+func matchesPrefix(name string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
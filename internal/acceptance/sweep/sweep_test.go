@@ -0,0 +1,224 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// fakeClient records the Resources which were deleted, in the order they were deleted
+type fakeClient struct {
+	resources map[string][]Resource
+	failures  map[string]error
+	deleted   []string
+}
+
+func (f *fakeClient) sweeper(name string, prefixes []string, dependencies ...string) Sweeper {
+	return Sweeper{
+		Name:         name,
+		Prefixes:     prefixes,
+		Dependencies: dependencies,
+		List: func(_ context.Context, _ *clients.Client) ([]Resource, error) {
+			if err, ok := f.failures[name]; ok {
+				return nil, err
+			}
+			return f.resources[name], nil
+		},
+		Delete: func(_ context.Context, _ *clients.Client, resource Resource) error {
+			if err, ok := f.failures[resource.ID]; ok {
+				return err
+			}
+			f.deleted = append(f.deleted, resource.ID)
+			return nil
+		},
+	}
+}
+
+func TestRegistryRegisterValidation(t *testing.T) {
+	fake := &fakeClient{}
+	noPrefixes := fake.sweeper("no_prefixes", nil)
+	emptyPrefix := fake.sweeper("empty_prefix", []string{" "})
+	noDelete := fake.sweeper("no_delete", []string{"acctest"})
+	noDelete.Delete = nil
+
+	for _, v := range []Sweeper{{}, noPrefixes, emptyPrefix, noDelete} {
+		if err := NewRegistry().Register(v); err == nil {
+			t.Fatalf("expected an error registering %q but didn't get one", v.Name)
+		}
+	}
+
+	registry := NewRegistry()
+	if err := registry.Register(fake.sweeper("example", []string{"acctest"})); err != nil {
+		t.Fatalf("registering sweeper: %+v", err)
+	}
+	if err := registry.Register(fake.sweeper("example", []string{"acctest"})); err == nil {
+		t.Fatalf("expected an error registering a duplicate sweeper but didn't get one")
+	}
+}
+
+func TestRegistryOrder(t *testing.T) {
+	fake := &fakeClient{}
+	registry := NewRegistry()
+	err := registry.Register(
+		fake.sweeper("azurerm_resource_group", []string{"acctestRG-"}, "azurerm_key_vault", "azurerm_management_lock"),
+		fake.sweeper("azurerm_key_vault", []string{"acctestkv-"}, "azurerm_management_lock"),
+		fake.sweeper("azurerm_management_lock", []string{"acctestlock-"}),
+		fake.sweeper("azurerm_storage_account", []string{"acctestsa"}),
+	)
+	if err != nil {
+		t.Fatalf("registering sweepers: %+v", err)
+	}
+
+	testCases := []struct {
+		input    []string
+		expected []string
+	}{
+		{
+			input:    nil,
+			expected: []string{"azurerm_management_lock", "azurerm_key_vault", "azurerm_resource_group", "azurerm_storage_account"},
+		},
+		{
+			input:    []string{"azurerm_resource_group"},
+			expected: []string{"azurerm_management_lock", "azurerm_key_vault", "azurerm_resource_group"},
+		},
+		{
+			input:    []string{"azurerm_storage_account"},
+			expected: []string{"azurerm_storage_account"},
+		},
+	}
+
+	for _, v := range testCases {
+		actual, err := registry.order(v.input)
+		if err != nil {
+			t.Fatalf("ordering %+v: %+v", v.input, err)
+		}
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+
+	if _, err := registry.order([]string{"azurerm_unknown"}); err == nil {
+		t.Fatalf("expected an error for an unknown sweeper but didn't get one")
+	}
+}
+
+func TestRegistryOrderInvalidDependencies(t *testing.T) {
+	fake := &fakeClient{}
+
+	missing := NewRegistry()
+	if err := missing.Register(fake.sweeper("first", []string{"acctest"}, "second")); err != nil {
+		t.Fatalf("registering sweepers: %+v", err)
+	}
+	if _, err := missing.order(nil); err == nil || !strings.Contains(err.Error(), "not registered") {
+		t.Fatalf("expected an error for a missing dependency but got: %+v", err)
+	}
+
+	cycle := NewRegistry()
+	err := cycle.Register(
+		fake.sweeper("first", []string{"acctest"}, "second"),
+		fake.sweeper("second", []string{"acctest"}, "third"),
+		fake.sweeper("third", []string{"acctest"}, "first"),
+	)
+	if err != nil {
+		t.Fatalf("registering sweepers: %+v", err)
+	}
+	if _, err := cycle.order(nil); err == nil || !strings.Contains(err.Error(), "first -> second -> third -> first") {
+		t.Fatalf("expected an error for a dependency cycle but got: %+v", err)
+	}
+}
+
+func TestRegistryRun(t *testing.T) {
+	fake := &fakeClient{
+		resources: map[string][]Resource{
+			"azurerm_resource_group": {
+				{ID: "/subscriptions/0000/resourceGroups/acctestRG-1", Name: "acctestRG-1"},
+				{ID: "/subscriptions/0000/resourceGroups/production", Name: "production"},
+				{ID: "/subscriptions/0000/resourceGroups/acctestRG-2", Name: "acctestRG-2"},
+			},
+			"azurerm_key_vault": {
+				{ID: "/subscriptions/0000/providers/Microsoft.KeyVault/deletedVaults/acctestkv-1", Name: "acctestkv-1"},
+			},
+		},
+		failures: map[string]error{
+			"/subscriptions/0000/resourceGroups/acctestRG-2": fmt.Errorf("the Resource Group is locked"),
+			"azurerm_storage_account":                        fmt.Errorf("listing failed"),
+		},
+	}
+
+	registry := NewRegistry()
+	err := registry.Register(
+		fake.sweeper("azurerm_resource_group", []string{"acctestRG-"}, "azurerm_key_vault"),
+		fake.sweeper("azurerm_key_vault", []string{"acctestkv-"}),
+		fake.sweeper("azurerm_storage_account", []string{"acctestsa"}),
+	)
+	if err != nil {
+		t.Fatalf("registering sweepers: %+v", err)
+	}
+
+	report, err := registry.Run(context.TODO(), nil, Options{})
+	if err != nil {
+		t.Fatalf("running sweepers: %+v", err)
+	}
+
+	expectedDeleted := []string{
+		"/subscriptions/0000/providers/Microsoft.KeyVault/deletedVaults/acctestkv-1",
+		"/subscriptions/0000/resourceGroups/acctestRG-1",
+	}
+	if !reflect.DeepEqual(fake.deleted, expectedDeleted) {
+		t.Fatalf("expected %+v to be deleted but got %+v", expectedDeleted, fake.deleted)
+	}
+
+	if !report.HasErrors() {
+		t.Fatalf("expected the report to contain errors")
+	}
+	output := report.String()
+	for _, expected := range []string{
+		"Deleted 1 of 2 Resources",
+		"! /subscriptions/0000/resourceGroups/acctestRG-2: the Resource Group is locked",
+		"Error: listing Resources: listing failed",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected the report to contain %q but got:\n%s", expected, output)
+		}
+	}
+}
+
+func TestRegistryRunDryRun(t *testing.T) {
+	fake := &fakeClient{
+		resources: map[string][]Resource{
+			"azurerm_resource_group": {
+				{ID: "/subscriptions/0000/resourceGroups/acctestRG-1", Name: "acctestRG-1"},
+				{ID: "/subscriptions/0000/resourceGroups/production", Name: "production"},
+			},
+		},
+	}
+
+	registry := NewRegistry()
+	if err := registry.Register(fake.sweeper("azurerm_resource_group", []string{"acctestRG-"})); err != nil {
+		t.Fatalf("registering sweepers: %+v", err)
+	}
+
+	report, err := registry.Run(context.TODO(), nil, Options{DryRun: true})
+	if err != nil {
+		t.Fatalf("running sweepers: %+v", err)
+	}
+
+	if len(fake.deleted) > 0 {
+		t.Fatalf("expected nothing to be deleted during a dry run but got %+v", fake.deleted)
+	}
+	if len(report.Results) != 1 || len(report.Results[0].Matched) != 1 {
+		t.Fatalf("expected a single Resource to be matched but got %+v", report.Results)
+	}
+
+	output := report.String()
+	if !strings.Contains(output, "- /subscriptions/0000/resourceGroups/acctestRG-1") || strings.Contains(output, "production") {
+		t.Fatalf("unexpected report:\n%s", output)
+	}
+}
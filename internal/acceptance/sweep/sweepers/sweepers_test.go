@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sweepers
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/sweep"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/testclient"
+	resourceSweepers "github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/sweepers"
+)
+
+// NOTE: `-sweep` and `-sweep-run` are defined by terraform-plugin-testing, which is linked into this binary - so
+// these are looked up rather than redefined
+var flagSweepDryRun = flag.Bool("sweep-dry-run", false, "List the Resources which would be removed by the Sweepers without deleting them")
+
+// TestMain runs the Sweepers when `-sweep` is specified, for example:
+//
+//	go test ./internal/acceptance/sweep/sweepers -v -timeout 60m -sweep=all -sweep-run=azurerm_resource_group -sweep-dry-run
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	if flagValue("sweep") == "" {
+		os.Exit(m.Run())
+	}
+
+	if err := runSweepers(); err != nil {
+		log.Printf("[ERROR] %+v", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func runSweepers() error {
+	registry, err := buildRegistry()
+	if err != nil {
+		return err
+	}
+
+	client, err := testclient.Build()
+	if err != nil {
+		return fmt.Errorf("building client: %+v", err)
+	}
+
+	options := sweep.Options{
+		DryRun: *flagSweepDryRun,
+	}
+	if v := flagValue("sweep-run"); v != "" {
+		for _, name := range strings.Split(v, ",") {
+			options.Sweepers = append(options.Sweepers, strings.TrimSpace(name))
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Hour)
+	defer cancel()
+
+	report, err := registry.Run(ctx, client, options)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(report.String())
+	if report.HasErrors() {
+		return fmt.Errorf("one or more Sweepers failed")
+	}
+
+	return nil
+}
+
+// serviceSweepers returns the Sweepers for each Service, which are defined in a `sweepers` package within the
+// Service so that they're only linked into this test binary (rather than the Provider)
+func serviceSweepers() map[string][]sweep.Sweeper {
+	return map[string][]sweep.Sweeper{
+		"resource": resourceSweepers.Sweepers(),
+	}
+}
+
+// buildRegistry registers the Sweepers for each of the Services
+func buildRegistry() (*sweep.Registry, error) {
+	registry := sweep.NewRegistry()
+
+	for name, sweepers := range serviceSweepers() {
+		if err := registry.Register(sweepers...); err != nil {
+			return nil, fmt.Errorf("registering the Sweepers for the %q Service: %+v", name, err)
+		}
+	}
+
+	return registry, nil
+}
+
+func flagValue(name string) string {
+	if f := flag.Lookup(name); f != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+func TestSweepersAreValid(t *testing.T) {
+	registry, err := buildRegistry()
+	if err != nil {
+		t.Fatalf("building registry: %+v", err)
+	}
+
+	if _, err := registry.Run(context.TODO(), nil, sweep.Options{DryRun: true, Sweepers: []string{"azurerm_unknown"}}); err == nil {
+		t.Fatalf("expected an error for an unknown Sweeper")
+	}
+}
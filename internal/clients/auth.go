@@ -5,6 +5,7 @@ package clients
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -17,6 +18,172 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients/graph"
 )
 
+// AuthMethod is a method of authenticating which can be used by the Provider
+type AuthMethod string
+
+const (
+	AuthMethodClientCertificate AuthMethod = "client_certificate"
+	AuthMethodClientSecret      AuthMethod = "client_secret"
+	AuthMethodOIDC              AuthMethod = "oidc"
+	AuthMethodManagedIdentity   AuthMethod = "msi"
+	AuthMethodAzureCLI          AuthMethod = "cli"
+	AuthMethodAzureDeveloperCLI AuthMethod = "azd"
+	AuthMethodAzurePowerShell   AuthMethod = "powershell"
+)
+
+func PossibleValuesForAuthMethod() []string {
+	return []string{
+		string(AuthMethodClientCertificate),
+		string(AuthMethodClientSecret),
+		string(AuthMethodOIDC),
+		string(AuthMethodManagedIdentity),
+		string(AuthMethodAzureCLI),
+		string(AuthMethodAzureDeveloperCLI),
+		string(AuthMethodAzurePowerShell),
+	}
+}
+
+// DefaultAuthMethodOrder is the order in which the authentication methods are attempted when no order is specified,
+// which matches the order used by go-azure-sdk with the developer tools supported by the Provider appended
+func DefaultAuthMethodOrder() []AuthMethod {
+	return []AuthMethod{
+		AuthMethodClientCertificate,
+		AuthMethodClientSecret,
+		AuthMethodOIDC,
+		AuthMethodManagedIdentity,
+		AuthMethodAzureCLI,
+		AuthMethodAzureDeveloperCLI,
+		AuthMethodAzurePowerShell,
+	}
+}
+
+// AuthConfig extends the Credentials supported by go-azure-sdk with the additional authentication methods
+// supported by the Provider, and the order in which the authentication methods should be attempted
+type AuthConfig struct {
+	Credentials auth.Credentials
+
+	EnableAuthenticatingUsingAzureDeveloperCLI bool
+	EnableAuthenticatingUsingAzurePowerShell   bool
+
+	// AuthMethodOrder is the order in which the enabled authentication methods are attempted, any methods
+	// which are omitted aren't attempted. Defaults to DefaultAuthMethodOrder when empty.
+	AuthMethodOrder []AuthMethod
+}
+
+// NewAuthorizer returns an Authorizer for the specified API using the first authentication method (in the order
+// specified by the AuthConfig) which is both enabled and configured.
+//
+// As with go-azure-sdk, if a method which has been configured (e.g. a Client Secret) fails then an error is returned
+// and later methods aren't attempted. The developer tools (Azure CLI, Azure Developer CLI and Azure PowerShell) don't
+// require any configuration, so are instead skipped when they fail - since a tool may not be installed or logged in.
+func NewAuthorizer(ctx context.Context, config AuthConfig, api environments.Api) (auth.Authorizer, error) {
+	order := config.AuthMethodOrder
+	if len(order) == 0 {
+		order = DefaultAuthMethodOrder()
+	}
+
+	c := config.Credentials
+	hasTenantAndClient := strings.TrimSpace(c.TenantID) != "" && strings.TrimSpace(c.ClientID) != ""
+
+	// the errors from any developer tools which were skipped, to surface if no Authorizer could be configured
+	toolErrors := make([]error, 0)
+
+	for _, method := range order {
+		var (
+			authorizer auth.Authorizer
+			err        error
+		)
+
+		switch method {
+		case AuthMethodClientCertificate:
+			if !c.EnableAuthenticatingUsingClientCertificate || !hasTenantAndClient || (len(c.ClientCertificateData) == 0 && strings.TrimSpace(c.ClientCertificatePath) == "") {
+				continue
+			}
+			authorizer, err = auth.NewAuthorizerFromCredentials(ctx, credentialsForMethod(c, method), api)
+
+		case AuthMethodClientSecret:
+			if !c.EnableAuthenticatingUsingClientSecret || !hasTenantAndClient || strings.TrimSpace(c.ClientSecret) == "" {
+				continue
+			}
+			authorizer, err = auth.NewAuthorizerFromCredentials(ctx, credentialsForMethod(c, method), api)
+
+		case AuthMethodOIDC:
+			oidc := c.EnableAuthenticationUsingOIDC && strings.TrimSpace(c.OIDCAssertionToken) != ""
+			githubOidc := c.EnableAuthenticationUsingGitHubOIDC && strings.TrimSpace(c.GitHubOIDCTokenRequestURL) != "" && strings.TrimSpace(c.GitHubOIDCTokenRequestToken) != ""
+			if !hasTenantAndClient || (!oidc && !githubOidc) {
+				continue
+			}
+			authorizer, err = auth.NewAuthorizerFromCredentials(ctx, credentialsForMethod(c, method), api)
+
+		case AuthMethodManagedIdentity:
+			if !c.EnableAuthenticatingUsingManagedIdentity {
+				continue
+			}
+			authorizer, err = auth.NewAuthorizerFromCredentials(ctx, credentialsForMethod(c, method), api)
+
+		case AuthMethodAzureCLI:
+			if !c.EnableAuthenticatingUsingAzureCLI {
+				continue
+			}
+			authorizer, err = auth.NewAuthorizerFromCredentials(ctx, credentialsForMethod(c, method), api)
+
+		case AuthMethodAzureDeveloperCLI:
+			if !config.EnableAuthenticatingUsingAzureDeveloperCLI {
+				continue
+			}
+			authorizer, err = NewAzureDeveloperCliAuthorizer(ctx, AzureDeveloperCliAuthorizerOptions{
+				Api:          api,
+				TenantId:     c.TenantID,
+				AuxTenantIds: c.AuxiliaryTenantIDs,
+			})
+
+		case AuthMethodAzurePowerShell:
+			if !config.EnableAuthenticatingUsingAzurePowerShell {
+				continue
+			}
+			authorizer, err = NewAzurePowerShellAuthorizer(ctx, AzurePowerShellAuthorizerOptions{
+				Api:          api,
+				TenantId:     c.TenantID,
+				AuxTenantIds: c.AuxiliaryTenantIDs,
+			})
+
+		default:
+			return nil, fmt.Errorf("unsupported authentication method %q", string(method))
+		}
+
+		if err != nil {
+			if method == AuthMethodAzureCLI || method == AuthMethodAzureDeveloperCLI || method == AuthMethodAzurePowerShell {
+				log.Printf("[WARN] Skipping authentication method %q since it couldn't be used: %+v", string(method), err)
+				toolErrors = append(toolErrors, err)
+				continue
+			}
+			return nil, err
+		}
+
+		log.Printf("[DEBUG] Authenticating using the authentication method %q for the API %q", string(method), api.Name())
+		return authorizer, nil
+	}
+
+	if len(toolErrors) > 0 {
+		return nil, fmt.Errorf("no Authorizer could be configured, please check your configuration: %+v", errors.Join(toolErrors...))
+	}
+
+	return nil, fmt.Errorf("no Authorizer could be configured, please check your configuration")
+}
+
+// credentialsForMethod returns a copy of the Credentials with only the specified authentication method enabled, so
+// that go-azure-sdk can be used to build the Authorizer for a single authentication method
+func credentialsForMethod(input auth.Credentials, method AuthMethod) auth.Credentials {
+	output := input
+	output.EnableAuthenticatingUsingClientCertificate = input.EnableAuthenticatingUsingClientCertificate && method == AuthMethodClientCertificate
+	output.EnableAuthenticatingUsingClientSecret = input.EnableAuthenticatingUsingClientSecret && method == AuthMethodClientSecret
+	output.EnableAuthenticationUsingOIDC = input.EnableAuthenticationUsingOIDC && method == AuthMethodOIDC
+	output.EnableAuthenticationUsingGitHubOIDC = input.EnableAuthenticationUsingGitHubOIDC && method == AuthMethodOIDC
+	output.EnableAuthenticatingUsingManagedIdentity = input.EnableAuthenticatingUsingManagedIdentity && method == AuthMethodManagedIdentity
+	output.EnableAuthenticatingUsingAzureCLI = input.EnableAuthenticatingUsingAzureCLI && method == AuthMethodAzureCLI
+	return output
+}
+
 type ResourceManagerAccount struct {
 	Environment environments.Environment
 
@@ -32,8 +199,10 @@ type ResourceManagerAccount struct {
 	AzureEnvironment azure.Environment
 }
 
-func NewResourceManagerAccount(ctx context.Context, config auth.Credentials, subscriptionId string, skipResourceProviderRegistration bool, azureEnvironment azure.Environment) (*ResourceManagerAccount, error) {
-	authorizer, err := auth.NewAuthorizerFromCredentials(ctx, config, config.Environment.MicrosoftGraph)
+func NewResourceManagerAccount(ctx context.Context, tokenCache *TokenCache, subscriptionId string, skipResourceProviderRegistration bool, azureEnvironment azure.Environment) (*ResourceManagerAccount, error) {
+	config := tokenCache.config.Credentials
+
+	authorizer, err := tokenCache.Authorizer(ctx, config.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Microsoft Graph API: %+v", err)
 	}
@@ -89,7 +258,10 @@ func NewResourceManagerAccount(ctx context.Context, config auth.Credentials, sub
 
 	// Finally, defer to Azure CLI to obtain tenant ID, subscription ID and client ID when not specified and missing from claims
 	realAuthorizer := authorizer
-	if cache, ok := authorizer.(*auth.CachedAuthorizer); ok {
+	if shared, ok := realAuthorizer.(*SharedAuthorizer); ok {
+		realAuthorizer = shared.Source
+	}
+	if cache, ok := realAuthorizer.(*auth.CachedAuthorizer); ok {
 		realAuthorizer = cache.Source
 	}
 	if cli, ok := realAuthorizer.(*auth.AzureCliAuthorizer); ok {
@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"golang.org/x/oauth2"
+)
+
+const azureDeveloperCliExecutable = "azd"
+
+type AzureDeveloperCliAuthorizerOptions struct {
+	// Api describes the Azure API being used
+	Api environments.Api
+
+	// TenantId is the tenant to authenticate against, when not specified the default tenant for azd is used
+	TenantId string
+
+	// AuxTenantIds lists additional tenants to authenticate against, currently only
+	// used for Resource Manager when auxiliary tenants are needed.
+	AuxTenantIds []string
+}
+
+// NewAzureDeveloperCliAuthorizer returns an Authorizer which authenticates using the Azure Developer CLI (azd).
+func NewAzureDeveloperCliAuthorizer(ctx context.Context, options AzureDeveloperCliAuthorizerOptions) (auth.Authorizer, error) {
+	if _, err := exec.LookPath(azureDeveloperCliExecutable); err != nil {
+		return nil, fmt.Errorf("could not find the Azure Developer CLI (%q) in the path: %+v", azureDeveloperCliExecutable, err)
+	}
+
+	scope, err := environments.Scope(options.Api)
+	if err != nil {
+		return nil, fmt.Errorf("determining scope for %q: %+v", options.Api.Name(), err)
+	}
+
+	// Cache access tokens internally to avoid unnecessary `azd` invocations
+	authorizer, err := auth.NewCachedAuthorizer(&AzureDeveloperCliAuthorizer{
+		TenantID:     options.TenantId,
+		AuxTenantIDs: options.AuxTenantIds,
+		scope:        *scope,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// azd may be installed but not logged in, in which case an error is returned so that the next authentication method is used
+	if _, err := authorizer.Token(ctx, &http.Request{}); err != nil {
+		return nil, fmt.Errorf("the Azure Developer CLI is installed but couldn't be used to authenticate, ensure you've logged in using `azd auth login`: %+v", err)
+	}
+
+	return authorizer, nil
+}
+
+var _ auth.Authorizer = &AzureDeveloperCliAuthorizer{}
+
+// AzureDeveloperCliAuthorizer is an Authorizer which supports the Azure Developer CLI.
+type AzureDeveloperCliAuthorizer struct {
+	// TenantID is the specified tenant ID, or empty to use the default tenant for azd
+	TenantID string
+
+	// AuxTenantIDs is an optional list of tenant IDs for which to obtain additional tokens
+	AuxTenantIDs []string
+
+	scope string
+}
+
+// Token returns an access token using the Azure Developer CLI as an authentication mechanism.
+func (a *AzureDeveloperCliAuthorizer) Token(ctx context.Context, _ *http.Request) (*oauth2.Token, error) {
+	return a.tokenForTenant(ctx, a.TenantID)
+}
+
+// AuxiliaryTokens returns additional tokens for auxiliary tenant IDs, for use in multi-tenant scenarios
+func (a *AzureDeveloperCliAuthorizer) AuxiliaryTokens(ctx context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	tokens := make([]*oauth2.Token, 0)
+	for _, tenantId := range a.AuxTenantIDs {
+		token, err := a.tokenForTenant(ctx, tenantId)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+type azureDeveloperCliToken struct {
+	Token     string `json:"token"`
+	ExpiresOn string `json:"expiresOn"`
+}
+
+func (a *AzureDeveloperCliAuthorizer) tokenForTenant(ctx context.Context, tenantId string) (*oauth2.Token, error) {
+	args := []string{"auth", "token", "--output", "json", "--scope", a.scope}
+	if tenantId != "" {
+		args = append(args, "--tenant-id", tenantId)
+	}
+
+	output, err := runTokenCommand(ctx, azureDeveloperCliExecutable, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var token azureDeveloperCliToken
+	if err := json.Unmarshal(output, &token); err != nil {
+		return nil, fmt.Errorf("unmarshaling the output of %q: %+v", azureDeveloperCliExecutable, err)
+	}
+	if token.Token == "" {
+		return nil, fmt.Errorf("the Azure Developer CLI returned an empty access token")
+	}
+
+	var expiry time.Time
+	if token.ExpiresOn != "" {
+		if expiry, err = time.Parse(time.RFC3339, token.ExpiresOn); err != nil {
+			return nil, fmt.Errorf("parsing expiresOn value %q for the azd token: %+v", token.ExpiresOn, err)
+		}
+	}
+
+	return &oauth2.Token{
+		AccessToken: token.Token,
+		Expiry:      expiry,
+		TokenType:   "Bearer",
+	}, nil
+}
+
+// runTokenCommand runs the command used to obtain an access token from a developer tool, returning the
+// output of the command - or an error containing anything written to stderr when the command fails
+func runTokenCommand(ctx context.Context, executable string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, executable, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return nil, fmt.Errorf("obtaining an access token using %q: %s", executable, message)
+	}
+
+	return stdout.Bytes(), nil
+}
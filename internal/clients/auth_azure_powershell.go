@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"golang.org/x/oauth2"
+)
+
+// azurePowerShellExecutables are the PowerShell executables which are looked up in the path, in order - `pwsh` is
+// PowerShell 7+ (available on all platforms) whilst `powershell` is Windows PowerShell
+var azurePowerShellExecutables = []string{"pwsh", "powershell"}
+
+// azurePowerShellTokenScript obtains an access token using the Az.Accounts module, outputting the token and the
+// expiry (as unix seconds) as JSON. The placeholders are the Resource URL and the (optional) Tenant ID.
+const azurePowerShellTokenScript = `$ErrorActionPreference = 'Stop'
+$module = Import-Module Az.Accounts -MinimumVersion 2.2.0 -PassThru -ErrorAction SilentlyContinue
+if (-not $module) {
+  Write-Error 'the Az.Accounts module (version 2.2.0 or later) is not installed'
+}
+$params = @{ ResourceUrl = '%s' }
+if ('%s') { $params['TenantId'] = '%s' }
+$token = Get-AzAccessToken @params
+$value = $token.Token
+if ($value -is [System.Security.SecureString]) {
+  $value = [System.Net.NetworkCredential]::new('', $value).Password
+}
+@{ token = $value; expiresOn = $token.ExpiresOn.ToUnixTimeSeconds() } | ConvertTo-Json -Compress
+`
+
+// the values interpolated into the script are validated to ensure they can't escape the quoted strings
+var azurePowerShellSafeValue = regexp.MustCompile(`^[a-zA-Z0-9._:/-]*$`)
+
+type AzurePowerShellAuthorizerOptions struct {
+	// Api describes the Azure API being used
+	Api environments.Api
+
+	// TenantId is the tenant to authenticate against, when not specified the tenant of the current Azure context is used
+	TenantId string
+
+	// AuxTenantIds lists additional tenants to authenticate against, currently only
+	// used for Resource Manager when auxiliary tenants are needed.
+	AuxTenantIds []string
+}
+
+// NewAzurePowerShellAuthorizer returns an Authorizer which authenticates using Azure PowerShell.
+func NewAzurePowerShellAuthorizer(ctx context.Context, options AzurePowerShellAuthorizerOptions) (auth.Authorizer, error) {
+	executable := ""
+	for _, v := range azurePowerShellExecutables {
+		if _, err := exec.LookPath(v); err == nil {
+			executable = v
+			break
+		}
+	}
+	if executable == "" {
+		return nil, fmt.Errorf("could not find PowerShell (any of %s) in the path", strings.Join(azurePowerShellExecutables, ", "))
+	}
+
+	scope, err := environments.Scope(options.Api)
+	if err != nil {
+		return nil, fmt.Errorf("determining scope for %q: %+v", options.Api.Name(), err)
+	}
+	resourceUrl := strings.TrimSuffix(*scope, "/.default")
+	if !azurePowerShellSafeValue.MatchString(resourceUrl) {
+		return nil, fmt.Errorf("the resource URL %q for %q contains unsupported characters", resourceUrl, options.Api.Name())
+	}
+
+	for _, tenantId := range append([]string{options.TenantId}, options.AuxTenantIds...) {
+		if !azurePowerShellSafeValue.MatchString(tenantId) {
+			return nil, fmt.Errorf("the tenant ID %q contains unsupported characters", tenantId)
+		}
+	}
+
+	// Cache access tokens internally to avoid unnecessary PowerShell invocations
+	authorizer, err := auth.NewCachedAuthorizer(&AzurePowerShellAuthorizer{
+		TenantID:     options.TenantId,
+		AuxTenantIDs: options.AuxTenantIds,
+		executable:   executable,
+		resourceUrl:  resourceUrl,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// PowerShell may be installed without the Az module or without being logged in, in which case an error is
+	// returned so that the next authentication method is used
+	if _, err := authorizer.Token(ctx, &http.Request{}); err != nil {
+		return nil, fmt.Errorf("Azure PowerShell is installed but couldn't be used to authenticate, ensure the Az module is installed and you've logged in using `Connect-AzAccount`: %+v", err)
+	}
+
+	return authorizer, nil
+}
+
+var _ auth.Authorizer = &AzurePowerShellAuthorizer{}
+
+// AzurePowerShellAuthorizer is an Authorizer which supports Azure PowerShell.
+type AzurePowerShellAuthorizer struct {
+	// TenantID is the specified tenant ID, or empty to use the tenant of the current Azure context
+	TenantID string
+
+	// AuxTenantIDs is an optional list of tenant IDs for which to obtain additional tokens
+	AuxTenantIDs []string
+
+	executable  string
+	resourceUrl string
+}
+
+// Token returns an access token using Azure PowerShell as an authentication mechanism.
+func (a *AzurePowerShellAuthorizer) Token(ctx context.Context, _ *http.Request) (*oauth2.Token, error) {
+	return a.tokenForTenant(ctx, a.TenantID)
+}
+
+// AuxiliaryTokens returns additional tokens for auxiliary tenant IDs, for use in multi-tenant scenarios
+func (a *AzurePowerShellAuthorizer) AuxiliaryTokens(ctx context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	tokens := make([]*oauth2.Token, 0)
+	for _, tenantId := range a.AuxTenantIDs {
+		token, err := a.tokenForTenant(ctx, tenantId)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+type azurePowerShellToken struct {
+	Token     string `json:"token"`
+	ExpiresOn int64  `json:"expiresOn"`
+}
+
+func (a *AzurePowerShellAuthorizer) tokenForTenant(ctx context.Context, tenantId string) (*oauth2.Token, error) {
+	script := fmt.Sprintf(azurePowerShellTokenScript, a.resourceUrl, tenantId, tenantId)
+
+	output, err := runTokenCommand(ctx, a.executable, "-NoProfile", "-NonInteractive", "-Command", script)
+	if err != nil {
+		return nil, err
+	}
+
+	var token azurePowerShellToken
+	if err := json.Unmarshal(output, &token); err != nil {
+		return nil, fmt.Errorf("unmarshaling the output of %q: %+v", a.executable, err)
+	}
+	if token.Token == "" {
+		return nil, fmt.Errorf("Azure PowerShell returned an empty access token")
+	}
+
+	var expiry time.Time
+	if token.ExpiresOn > 0 {
+		expiry = time.Unix(token.ExpiresOn, 0)
+	}
+
+	return &oauth2.Token{
+		AccessToken: token.Token,
+		Expiry:      expiry,
+		TokenType:   "Bearer",
+	}, nil
+}
@@ -0,0 +1,292 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+)
+
+// fakeExecutable writes a shell script named `name` into `dir` which records the arguments it was called with
+// into `name.args` (one per line) and then runs `body`
+func fakeExecutable(t *testing.T, dir, name, body string) {
+	t.Helper()
+
+	script := "#!/bin/sh\n" +
+		"for arg in \"$@\"; do echo \"$arg\" >> \"" + filepath.Join(dir, name+".args") + "\"; done\n" +
+		body + "\n"
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o755); err != nil {
+		t.Fatalf("writing fake executable %q: %+v", name, err)
+	}
+}
+
+func fakeExecutableArgs(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	v, err := os.ReadFile(filepath.Join(dir, name+".args"))
+	if err != nil {
+		t.Fatalf("reading the arguments for the fake executable %q: %+v", name, err)
+	}
+	return string(v)
+}
+
+func setupFakeExecutables(t *testing.T) string {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("fake executables are shell scripts, which aren't supported on Windows")
+	}
+
+	dir := t.TempDir()
+	t.Setenv("PATH", dir)
+	return dir
+}
+
+func TestAzureDeveloperCliAuthorizer(t *testing.T) {
+	dir := setupFakeExecutables(t)
+	fakeExecutable(t, dir, "azd", `echo '{"token": "azd-token", "expiresOn": "2030-01-02T03:04:05Z"}'`)
+
+	authorizer, err := NewAzureDeveloperCliAuthorizer(context.TODO(), AzureDeveloperCliAuthorizerOptions{
+		Api:          environments.AzurePublic().ResourceManager,
+		TenantId:     "00000000-0000-0000-0000-000000000000",
+		AuxTenantIds: []string{"11111111-1111-1111-1111-111111111111"},
+	})
+	if err != nil {
+		t.Fatalf("building authorizer: %+v", err)
+	}
+
+	token, err := authorizer.Token(context.TODO(), &http.Request{})
+	if err != nil {
+		t.Fatalf("obtaining token: %+v", err)
+	}
+	if token.AccessToken != "azd-token" {
+		t.Fatalf("expected the access token `azd-token` but got %q", token.AccessToken)
+	}
+	if expiry := token.Expiry.UTC().Format("2006-01-02T15:04:05Z"); expiry != "2030-01-02T03:04:05Z" {
+		t.Fatalf("expected the token to expire at `2030-01-02T03:04:05Z` but got %q", expiry)
+	}
+
+	args := fakeExecutableArgs(t, dir, "azd")
+	for _, expected := range []string{"auth\ntoken\n", "--scope\nhttps://management.azure.com/.default\n", "--tenant-id\n00000000-0000-0000-0000-000000000000\n"} {
+		if !strings.Contains(args, expected) {
+			t.Fatalf("expected azd to be called with %q but got %q", expected, args)
+		}
+	}
+
+	auxTokens, err := authorizer.AuxiliaryTokens(context.TODO(), &http.Request{})
+	if err != nil {
+		t.Fatalf("obtaining auxiliary tokens: %+v", err)
+	}
+	if len(auxTokens) != 1 {
+		t.Fatalf("expected 1 auxiliary token but got %d", len(auxTokens))
+	}
+	if args := fakeExecutableArgs(t, dir, "azd"); !strings.Contains(args, "--tenant-id\n11111111-1111-1111-1111-111111111111\n") {
+		t.Fatalf("expected azd to be called for the auxiliary tenant but got %q", args)
+	}
+}
+
+func TestAzureDeveloperCliAuthorizer_notLoggedIn(t *testing.T) {
+	dir := setupFakeExecutables(t)
+	fakeExecutable(t, dir, "azd", `echo 'ERROR: not logged in, run azd auth login to login' >&2; exit 1`)
+
+	_, err := NewAzureDeveloperCliAuthorizer(context.TODO(), AzureDeveloperCliAuthorizerOptions{
+		Api: environments.AzurePublic().ResourceManager,
+	})
+	if err == nil {
+		t.Fatalf("expected an error when azd isn't logged in")
+	}
+	if !strings.Contains(err.Error(), "not logged in") {
+		t.Fatalf("expected the error to contain the output from azd but got %q", err.Error())
+	}
+	if args := fakeExecutableArgs(t, dir, "azd"); strings.Contains(args, "--tenant-id") {
+		t.Fatalf("expected azd to use the default tenant when no tenant is specified but got %q", args)
+	}
+}
+
+func TestAzureDeveloperCliAuthorizer_notInstalled(t *testing.T) {
+	setupFakeExecutables(t)
+
+	if _, err := NewAzureDeveloperCliAuthorizer(context.TODO(), AzureDeveloperCliAuthorizerOptions{
+		Api: environments.AzurePublic().ResourceManager,
+	}); err == nil {
+		t.Fatalf("expected an error when azd isn't installed")
+	}
+}
+
+func TestAzurePowerShellAuthorizer(t *testing.T) {
+	dir := setupFakeExecutables(t)
+	fakeExecutable(t, dir, "pwsh", `echo '{"token":"powershell-token","expiresOn":1893553445}'`)
+
+	authorizer, err := NewAzurePowerShellAuthorizer(context.TODO(), AzurePowerShellAuthorizerOptions{
+		Api:      environments.AzurePublic().KeyVault,
+		TenantId: "00000000-0000-0000-0000-000000000000",
+	})
+	if err != nil {
+		t.Fatalf("building authorizer: %+v", err)
+	}
+
+	token, err := authorizer.Token(context.TODO(), &http.Request{})
+	if err != nil {
+		t.Fatalf("obtaining token: %+v", err)
+	}
+	if token.AccessToken != "powershell-token" {
+		t.Fatalf("expected the access token `powershell-token` but got %q", token.AccessToken)
+	}
+	if token.Expiry.Unix() != 1893553445 {
+		t.Fatalf("expected the token to expire at 1893553445 but got %d", token.Expiry.Unix())
+	}
+
+	args := fakeExecutableArgs(t, dir, "pwsh")
+	for _, expected := range []string{"-NonInteractive\n", "ResourceUrl = 'https://vault.azure.net'", "$params['TenantId'] = '00000000-0000-0000-0000-000000000000'"} {
+		if !strings.Contains(args, expected) {
+			t.Fatalf("expected pwsh to be called with %q but got %q", expected, args)
+		}
+	}
+}
+
+func TestAzurePowerShellAuthorizer_unsafeTenantId(t *testing.T) {
+	dir := setupFakeExecutables(t)
+	fakeExecutable(t, dir, "pwsh", `echo '{"token":"powershell-token","expiresOn":1893553445}'`)
+
+	if _, err := NewAzurePowerShellAuthorizer(context.TODO(), AzurePowerShellAuthorizerOptions{
+		Api:      environments.AzurePublic().ResourceManager,
+		TenantId: "'; Remove-Item -Recurse /; '",
+	}); err == nil {
+		t.Fatalf("expected an error when the tenant ID contains unsupported characters")
+	}
+}
+
+func TestNewAuthorizer_order(t *testing.T) {
+	dir := setupFakeExecutables(t)
+	fakeExecutable(t, dir, "azd", `echo '{"token": "azd-token", "expiresOn": "2030-01-02T03:04:05Z"}'`)
+	fakeExecutable(t, dir, "pwsh", `echo '{"token":"powershell-token","expiresOn":1893553445}'`)
+
+	testData := []struct {
+		name     string
+		config   AuthConfig
+		expected string
+	}{
+		{
+			name: "default order",
+			config: AuthConfig{
+				EnableAuthenticatingUsingAzureDeveloperCLI: true,
+				EnableAuthenticatingUsingAzurePowerShell:   true,
+			},
+			expected: "azd-token",
+		},
+		{
+			name: "custom order",
+			config: AuthConfig{
+				EnableAuthenticatingUsingAzureDeveloperCLI: true,
+				EnableAuthenticatingUsingAzurePowerShell:   true,
+				AuthMethodOrder:                            []AuthMethod{AuthMethodAzurePowerShell, AuthMethodAzureDeveloperCLI},
+			},
+			expected: "powershell-token",
+		},
+		{
+			name: "omitted from the order",
+			config: AuthConfig{
+				EnableAuthenticatingUsingAzureDeveloperCLI: true,
+				EnableAuthenticatingUsingAzurePowerShell:   true,
+				AuthMethodOrder:                            []AuthMethod{AuthMethodAzurePowerShell},
+			},
+			expected: "powershell-token",
+		},
+		{
+			name: "only powershell enabled",
+			config: AuthConfig{
+				EnableAuthenticatingUsingAzurePowerShell: true,
+			},
+			expected: "powershell-token",
+		},
+		{
+			name: "azure cli not installed",
+			config: AuthConfig{
+				Credentials: auth.Credentials{
+					EnableAuthenticatingUsingAzureCLI: true,
+				},
+				EnableAuthenticatingUsingAzureDeveloperCLI: true,
+			},
+			expected: "azd-token",
+		},
+	}
+
+	for _, v := range testData {
+		t.Run(v.name, func(t *testing.T) {
+			v.config.Credentials.Environment = *environments.AzurePublic()
+
+			authorizer, err := NewAuthorizer(context.TODO(), v.config, v.config.Credentials.Environment.ResourceManager)
+			if err != nil {
+				t.Fatalf("building authorizer: %+v", err)
+			}
+
+			token, err := authorizer.Token(context.TODO(), &http.Request{})
+			if err != nil {
+				t.Fatalf("obtaining token: %+v", err)
+			}
+			if token.AccessToken != v.expected {
+				t.Fatalf("expected the access token %q but got %q", v.expected, token.AccessToken)
+			}
+		})
+	}
+}
+
+func TestNewAuthorizer_notLoggedIn(t *testing.T) {
+	dir := setupFakeExecutables(t)
+	fakeExecutable(t, dir, "azd", `echo 'ERROR: not logged in, run azd auth login to login' >&2; exit 1`)
+	fakeExecutable(t, dir, "pwsh", `echo '{"token":"powershell-token","expiresOn":1893553445}'`)
+
+	// azd is installed but not logged in, so should be skipped in favour of Azure PowerShell
+	config := AuthConfig{
+		Credentials: auth.Credentials{
+			Environment: *environments.AzurePublic(),
+		},
+		EnableAuthenticatingUsingAzureDeveloperCLI: true,
+		EnableAuthenticatingUsingAzurePowerShell:   true,
+	}
+	authorizer, err := NewAuthorizer(context.TODO(), config, config.Credentials.Environment.ResourceManager)
+	if err != nil {
+		t.Fatalf("building authorizer: %+v", err)
+	}
+
+	token, err := authorizer.Token(context.TODO(), &http.Request{})
+	if err != nil {
+		t.Fatalf("obtaining token: %+v", err)
+	}
+	if token.AccessToken != "powershell-token" {
+		t.Fatalf("expected the access token `powershell-token` but got %q", token.AccessToken)
+	}
+}
+
+func TestNewAuthorizer_noneConfigured(t *testing.T) {
+	setupFakeExecutables(t)
+
+	config := AuthConfig{
+		Credentials: auth.Credentials{
+			Environment:                           *environments.AzurePublic(),
+			EnableAuthenticatingUsingClientSecret: true,
+		},
+		EnableAuthenticatingUsingAzureDeveloperCLI: true,
+	}
+	_, err := NewAuthorizer(context.TODO(), config, config.Credentials.Environment.ResourceManager)
+	if err == nil {
+		t.Fatalf("expected an error when no authentication method could be configured")
+	}
+	if !strings.Contains(err.Error(), "azd") {
+		t.Fatalf("expected the error to include why azd was skipped but got %q", err.Error())
+	}
+
+	config.AuthMethodOrder = []AuthMethod{"unknown"}
+	if _, err := NewAuthorizer(context.TODO(), config, config.Credentials.Environment.ResourceManager); err == nil {
+		t.Fatalf("expected an error for an unsupported authentication method")
+	}
+}
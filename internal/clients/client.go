@@ -162,6 +162,13 @@ type Client struct {
 	Account  *ResourceManagerAccount
 	Features features.UserFeatures
 
+	// options are the ClientOptions used to build this Client, which are reused to build Clients for other Subscriptions
+	options *common.ClientOptions
+
+	// subscriptionClients are the Clients for each Subscription, shared between all of the Clients built from the same
+	// Provider configuration - see ForSubscription
+	subscriptionClients *subscriptionClients
+
 	AadB2c                            *aadb2c_v2021_04_01_preview.Client
 	Advisor                           *advisor.Client
 	AnalysisServices                  *analysisservices_v2017_08_01.Client
@@ -302,6 +309,10 @@ func (client *Client) Build(ctx context.Context, o *common.ClientOptions) error
 
 	client.Features = o.Features
 	client.StopContext = ctx
+	client.options = o
+	if client.subscriptionClients == nil {
+		client.subscriptionClients = newSubscriptionClients(client)
+	}
 
 	var err error
 
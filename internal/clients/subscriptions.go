@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+)
+
+// subscriptionClients caches the Clients built for each Subscription, keyed by the (lower-cased) Subscription ID
+type subscriptionClients struct {
+	// lock guards the map of entries only, each entry has its own lock which is held whilst the Client is built
+	lock    sync.Mutex
+	entries map[string]*subscriptionClientEntry
+}
+
+// subscriptionClientEntry is the (lazily built) Client for a single Subscription
+type subscriptionClientEntry struct {
+	lock   sync.Mutex
+	client *Client
+}
+
+func newSubscriptionClients(client *Client) *subscriptionClients {
+	output := &subscriptionClients{
+		entries: map[string]*subscriptionClientEntry{},
+	}
+	if client.Account != nil {
+		output.entries[strings.ToLower(client.Account.SubscriptionId)] = &subscriptionClientEntry{
+			client: client,
+		}
+	}
+	return output
+}
+
+// entryFor returns the cache entry for the specified Subscription, adding an (empty) entry if one doesn't exist
+func (c *subscriptionClients) entryFor(subscriptionId string) *subscriptionClientEntry {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := strings.ToLower(subscriptionId)
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &subscriptionClientEntry{}
+		c.entries[key] = entry
+	}
+	return entry
+}
+
+// ForSubscription returns a Client targeting the specified Subscription, which is this Client when the Subscription
+// is the one that the Provider is configured to use.
+//
+// Clients for other Subscriptions are built lazily (using the same credentials and configuration as this Client) and
+// cached for the lifetime of the Provider - the first time a Client is built for a Subscription the required Resource
+// Providers are registered within that Subscription, unless Resource Provider Registration is skipped.
+func (client *Client) ForSubscription(ctx context.Context, subscriptionId string) (*Client, error) {
+	if subscriptionId == "" || (client.Account != nil && strings.EqualFold(client.Account.SubscriptionId, subscriptionId)) {
+		return client, nil
+	}
+
+	if client.options == nil || client.subscriptionClients == nil || client.Account == nil {
+		return nil, fmt.Errorf("internal-error: the Client must be built before it can be used for another Subscription")
+	}
+
+	// building the Clients (and registering the Resource Providers) can take a while, so only calls for the same
+	// Subscription wait on one another
+	cache := client.subscriptionClients
+	entry := cache.entryFor(subscriptionId)
+	entry.lock.Lock()
+	defer entry.lock.Unlock()
+
+	if entry.client != nil {
+		return entry.client, nil
+	}
+
+	log.Printf("[DEBUG] Building Clients for Subscription %q", subscriptionId)
+	account := *client.Account
+	account.SubscriptionId = subscriptionId
+
+	options := *client.options
+	options.SubscriptionId = subscriptionId
+
+	subscriptionClient := &Client{
+		Account:             &account,
+		subscriptionClients: cache,
+	}
+	if err := subscriptionClient.Build(ctx, &options); err != nil {
+		return nil, fmt.Errorf("building Clients for Subscription %q: %+v", subscriptionId, err)
+	}
+	subscriptionClient.StopContext = client.StopContext
+
+	if !account.SkipResourceProviderRegistration {
+		ctx2, cancel := context.WithTimeout(ctx, 30*time.Minute)
+		defer cancel()
+
+		id := commonids.NewSubscriptionID(subscriptionId)
+		if err := resourceproviders.EnsureRegistered(ctx2, subscriptionClient.Resource.ResourceProvidersClient, id, resourceproviders.Required()); err != nil {
+			return nil, fmt.Errorf("ensuring the required Resource Providers are registered in %s: %+v", id, err)
+		}
+	}
+
+	entry.client = subscriptionClient
+	return subscriptionClient, nil
+}
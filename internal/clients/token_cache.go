@@ -0,0 +1,274 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// defaultTokenRefreshWindow is how long before a token expires that a replacement token is acquired in the
+	// background, so that long-running operations don't end up using a token which expires mid-request
+	defaultTokenRefreshWindow = 10 * time.Minute
+
+	// tokenExpirySkew is the minimum remaining validity a cached token must have to be returned, below which
+	// a new token is acquired before returning
+	tokenExpirySkew = 30 * time.Second
+
+	// tokenRefreshTimeout is the maximum duration a background refresh can take
+	tokenRefreshTimeout = 5 * time.Minute
+
+	// tokenRefreshInterval is the minimum duration between background refreshes, since some sources (such as
+	// the Azure CLI) may return the same token until it's closer to expiry
+	tokenRefreshInterval = time.Minute
+)
+
+type tokenCacheKey struct {
+	tenantId string
+	audience string
+}
+
+func (k tokenCacheKey) String() string {
+	tenantId := k.tenantId
+	if tenantId == "" {
+		tenantId = "(default)"
+	}
+	return fmt.Sprintf("tenant %q / audience %q", tenantId, k.audience)
+}
+
+// TokenCache shares a single Authorizer (and the access tokens it acquires) between every client which
+// authenticates against the same Tenant and Audience, rather than each client acquiring its own tokens.
+type TokenCache struct {
+	config AuthConfig
+
+	// refreshWindow is how long before expiry a token is proactively refreshed
+	refreshWindow time.Duration
+
+	// newAuthorizer and now can be overridden in tests
+	newAuthorizer func(ctx context.Context, config AuthConfig, api environments.Api) (auth.Authorizer, error)
+	now           func() time.Time
+
+	lock        sync.Mutex
+	authorizers map[tokenCacheKey]*SharedAuthorizer
+}
+
+// NewTokenCache returns a TokenCache which builds Authorizers using the specified AuthConfig
+func NewTokenCache(config AuthConfig) *TokenCache {
+	return &TokenCache{
+		config:        config,
+		refreshWindow: defaultTokenRefreshWindow,
+		newAuthorizer: NewAuthorizer,
+		now:           time.Now,
+		authorizers:   make(map[tokenCacheKey]*SharedAuthorizer),
+	}
+}
+
+// Authorizer returns the shared Authorizer for the specified API, building it the first time it's requested
+func (c *TokenCache) Authorizer(ctx context.Context, api environments.Api) (auth.Authorizer, error) {
+	scope, err := environments.Scope(api)
+	if err != nil {
+		return nil, fmt.Errorf("determining scope for %q: %+v", api.Name(), err)
+	}
+
+	key := tokenCacheKey{
+		tenantId: c.config.Credentials.TenantID,
+		audience: *scope,
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if existing, ok := c.authorizers[key]; ok {
+		log.Printf("[DEBUG] Token Cache: reusing the shared Authorizer for %s", key)
+		return existing, nil
+	}
+
+	authorizer, err := c.newAuthorizer(ctx, c.config, api)
+	if err != nil {
+		return nil, err
+	}
+
+	// the Authorizers from go-azure-sdk cache tokens themselves, since the shared Authorizer handles caching
+	// and refreshing we unwrap these so that a refresh acquires a new token, rather than the cached one
+	source := authorizer
+	if cached, ok := authorizer.(*auth.CachedAuthorizer); ok {
+		source = cached.Source
+	}
+
+	log.Printf("[DEBUG] Token Cache: building a shared Authorizer for %s", key)
+	shared := &SharedAuthorizer{
+		Source:        source,
+		key:           key,
+		refreshWindow: c.refreshWindow,
+		now:           c.now,
+	}
+	c.authorizers[key] = shared
+	return shared, nil
+}
+
+var _ auth.CachingAuthorizer = &SharedAuthorizer{}
+
+// SharedAuthorizer caches the tokens acquired from Source, which are refreshed in the background once they're
+// within the refresh window - and acquired synchronously when they're about to (or have) expired.
+type SharedAuthorizer struct {
+	// Source contains the underlying Authorizer for obtaining tokens
+	Source auth.Authorizer
+
+	key           tokenCacheKey
+	refreshWindow time.Duration
+	now           func() time.Time
+
+	mutex         sync.Mutex
+	token         *oauth2.Token
+	auxTokens     []*oauth2.Token
+	refreshing    bool
+	refreshingAux bool
+
+	// nextRefresh and nextAuxRefresh are the earliest time the next background refresh can be attempted
+	nextRefresh    time.Time
+	nextAuxRefresh time.Time
+}
+
+// Token returns the cached token when it's valid, else acquires a new token
+func (a *SharedAuthorizer) Token(ctx context.Context, _ *http.Request) (*oauth2.Token, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.isUsable(a.token) {
+		if a.isDueForRefresh(a.token) && !a.refreshing && !a.now().Before(a.nextRefresh) {
+			a.refreshing = true
+			go a.refreshToken()
+		}
+		return a.token, nil
+	}
+
+	log.Printf("[DEBUG] Token Cache: acquiring an access token for %s", a.key)
+	token, err := a.Source.Token(ctx, &http.Request{})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[DEBUG] Token Cache: acquired an access token for %s which expires at %s", a.key, formatExpiry(token))
+	a.token = token
+	return a.token, nil
+}
+
+// AuxiliaryTokens returns the cached tokens for the auxiliary tenants when they're valid, else acquires new tokens
+func (a *SharedAuthorizer) AuxiliaryTokens(ctx context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	usable := len(a.auxTokens) > 0
+	dueForRefresh := false
+	for _, token := range a.auxTokens {
+		usable = usable && a.isUsable(token)
+		dueForRefresh = dueForRefresh || a.isDueForRefresh(token)
+	}
+
+	if usable {
+		if dueForRefresh && !a.refreshingAux && !a.now().Before(a.nextAuxRefresh) {
+			a.refreshingAux = true
+			go a.refreshAuxiliaryTokens()
+		}
+		return a.auxTokens, nil
+	}
+
+	log.Printf("[DEBUG] Token Cache: acquiring auxiliary access tokens for %s", a.key)
+	tokens, err := a.Source.AuxiliaryTokens(ctx, &http.Request{})
+	if err != nil {
+		return nil, err
+	}
+	a.auxTokens = tokens
+	return a.auxTokens, nil
+}
+
+// InvalidateCachedTokens removes the cached tokens, so that new tokens are acquired when next requested
+func (a *SharedAuthorizer) InvalidateCachedTokens() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	log.Printf("[DEBUG] Token Cache: invalidating the cached access tokens for %s", a.key)
+	a.token = nil
+	a.auxTokens = nil
+	return nil
+}
+
+func (a *SharedAuthorizer) refreshToken() {
+	ctx, cancel := context.WithTimeout(context.Background(), tokenRefreshTimeout)
+	defer cancel()
+
+	log.Printf("[DEBUG] Token Cache: proactively refreshing the access token for %s", a.key)
+	token, err := a.Source.Token(ctx, &http.Request{})
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.refreshing = false
+	a.nextRefresh = a.now().Add(tokenRefreshInterval)
+
+	if err != nil {
+		// the existing token remains valid, so we'll try again on the next request
+		log.Printf("[DEBUG] Token Cache: refreshing the access token for %s: %+v", a.key, err)
+		return
+	}
+
+	log.Printf("[DEBUG] Token Cache: refreshed the access token for %s which now expires at %s", a.key, formatExpiry(token))
+	a.token = token
+}
+
+func (a *SharedAuthorizer) refreshAuxiliaryTokens() {
+	ctx, cancel := context.WithTimeout(context.Background(), tokenRefreshTimeout)
+	defer cancel()
+
+	log.Printf("[DEBUG] Token Cache: proactively refreshing the auxiliary access tokens for %s", a.key)
+	tokens, err := a.Source.AuxiliaryTokens(ctx, &http.Request{})
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.refreshingAux = false
+	a.nextAuxRefresh = a.now().Add(tokenRefreshInterval)
+
+	if err != nil {
+		log.Printf("[DEBUG] Token Cache: refreshing the auxiliary access tokens for %s: %+v", a.key, err)
+		return
+	}
+
+	a.auxTokens = tokens
+}
+
+// isUsable returns whether the token can be used, which requires it to be valid beyond the expiry skew
+func (a *SharedAuthorizer) isUsable(token *oauth2.Token) bool {
+	if token == nil {
+		return false
+	}
+	if token.Expiry.IsZero() {
+		return true
+	}
+	return a.now().Add(tokenExpirySkew).Before(token.Expiry)
+}
+
+// isDueForRefresh returns whether the token expires within the refresh window
+func (a *SharedAuthorizer) isDueForRefresh(token *oauth2.Token) bool {
+	if token == nil {
+		return true
+	}
+	if token.Expiry.IsZero() {
+		return false
+	}
+	return !a.now().Add(a.refreshWindow).Before(token.Expiry)
+}
+
+func formatExpiry(token *oauth2.Token) string {
+	if token == nil || token.Expiry.IsZero() {
+		return "(never)"
+	}
+	return token.Expiry.Format(time.RFC3339)
+}
@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"golang.org/x/oauth2"
+)
+
+type fakeTokenSource struct {
+	lock     sync.Mutex
+	calls    int
+	validity time.Duration
+	now      func() time.Time
+}
+
+func (f *fakeTokenSource) Token(_ context.Context, _ *http.Request) (*oauth2.Token, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.calls++
+	return &oauth2.Token{
+		AccessToken: fmt.Sprintf("token-%d", f.calls),
+		Expiry:      f.now().Add(f.validity),
+	}, nil
+}
+
+func (f *fakeTokenSource) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return nil, nil
+}
+
+func (f *fakeTokenSource) callCount() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.calls
+}
+
+type fakeClock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func testTokenCache(t *testing.T, tenantId string) (*TokenCache, *fakeClock, *int) {
+	clock := &fakeClock{now: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}
+	built := 0
+
+	cache := NewTokenCache(AuthConfig{
+		Credentials: auth.Credentials{
+			Environment: *environments.AzurePublic(),
+			TenantID:    tenantId,
+		},
+	})
+	cache.now = clock.Now
+	cache.newAuthorizer = func(_ context.Context, _ AuthConfig, _ environments.Api) (auth.Authorizer, error) {
+		built++
+		return auth.NewCachedAuthorizer(&fakeTokenSource{
+			validity: time.Hour,
+			now:      clock.Now,
+		})
+	}
+	return cache, clock, &built
+}
+
+func TestTokenCacheSharesAuthorizersPerAudience(t *testing.T) {
+	cache, _, built := testTokenCache(t, "00000000-0000-0000-0000-000000000000")
+	env := environments.AzurePublic()
+
+	first, err := cache.Authorizer(context.TODO(), env.ResourceManager)
+	if err != nil {
+		t.Fatalf("building the Resource Manager authorizer: %+v", err)
+	}
+	second, err := cache.Authorizer(context.TODO(), env.ResourceManager)
+	if err != nil {
+		t.Fatalf("building the Resource Manager authorizer: %+v", err)
+	}
+	if first != second {
+		t.Fatalf("expected the same Authorizer to be returned for the same audience")
+	}
+
+	keyVault, err := cache.Authorizer(context.TODO(), env.KeyVault)
+	if err != nil {
+		t.Fatalf("building the Key Vault authorizer: %+v", err)
+	}
+	if keyVault == first {
+		t.Fatalf("expected a different Authorizer to be returned for a different audience")
+	}
+
+	if *built != 2 {
+		t.Fatalf("expected 2 Authorizers to be built but got %d", *built)
+	}
+
+	// the go-azure-sdk cache is unwrapped, since the shared Authorizer caches the tokens
+	if _, ok := first.(*SharedAuthorizer).Source.(*fakeTokenSource); !ok {
+		t.Fatalf("expected the Source to be unwrapped but got %T", first.(*SharedAuthorizer).Source)
+	}
+}
+
+func TestTokenCacheReusesTokens(t *testing.T) {
+	cache, clock, _ := testTokenCache(t, "")
+	authorizer, err := cache.Authorizer(context.TODO(), environments.AzurePublic().Storage)
+	if err != nil {
+		t.Fatalf("building the Storage authorizer: %+v", err)
+	}
+	source := authorizer.(*SharedAuthorizer).Source.(*fakeTokenSource)
+
+	for i := 0; i < 5; i++ {
+		token, err := authorizer.Token(context.TODO(), &http.Request{})
+		if err != nil {
+			t.Fatalf("acquiring token: %+v", err)
+		}
+		if token.AccessToken != "token-1" {
+			t.Fatalf("expected `token-1` but got %q", token.AccessToken)
+		}
+		clock.Advance(5 * time.Minute)
+	}
+
+	if calls := source.callCount(); calls != 1 {
+		t.Fatalf("expected the Source to be called once but got %d", calls)
+	}
+}
+
+func TestTokenCacheProactivelyRefreshesTokens(t *testing.T) {
+	cache, clock, _ := testTokenCache(t, "")
+	authorizer, err := cache.Authorizer(context.TODO(), environments.AzurePublic().ResourceManager)
+	if err != nil {
+		t.Fatalf("building the Resource Manager authorizer: %+v", err)
+	}
+	source := authorizer.(*SharedAuthorizer).Source.(*fakeTokenSource)
+
+	if _, err := authorizer.Token(context.TODO(), &http.Request{}); err != nil {
+		t.Fatalf("acquiring token: %+v", err)
+	}
+
+	// within the refresh window the existing token is returned whilst a new token is acquired in the background
+	clock.Advance(55 * time.Minute)
+	token, err := authorizer.Token(context.TODO(), &http.Request{})
+	if err != nil {
+		t.Fatalf("acquiring token: %+v", err)
+	}
+	if token.AccessToken != "token-1" {
+		t.Fatalf("expected the existing token `token-1` but got %q", token.AccessToken)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for source.callCount() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for the token to be refreshed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	deadline = time.Now().Add(10 * time.Second)
+	for {
+		token, err = authorizer.Token(context.TODO(), &http.Request{})
+		if err != nil {
+			t.Fatalf("acquiring token: %+v", err)
+		}
+		if token.AccessToken == "token-2" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the refreshed token `token-2` but got %q", token.AccessToken)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTokenCacheAcquiresExpiredTokens(t *testing.T) {
+	cache, clock, _ := testTokenCache(t, "")
+	authorizer, err := cache.Authorizer(context.TODO(), environments.AzurePublic().KeyVault)
+	if err != nil {
+		t.Fatalf("building the Key Vault authorizer: %+v", err)
+	}
+
+	if _, err := authorizer.Token(context.TODO(), &http.Request{}); err != nil {
+		t.Fatalf("acquiring token: %+v", err)
+	}
+
+	clock.Advance(2 * time.Hour)
+	token, err := authorizer.Token(context.TODO(), &http.Request{})
+	if err != nil {
+		t.Fatalf("acquiring token: %+v", err)
+	}
+	if token.AccessToken != "token-2" {
+		t.Fatalf("expected a new token `token-2` but got %q", token.AccessToken)
+	}
+
+	if err := authorizer.(auth.CachingAuthorizer).InvalidateCachedTokens(); err != nil {
+		t.Fatalf("invalidating tokens: %+v", err)
+	}
+	token, err = authorizer.Token(context.TODO(), &http.Request{})
+	if err != nil {
+		t.Fatalf("acquiring token: %+v", err)
+	}
+	if token.AccessToken != "token-3" {
+		t.Fatalf("expected a new token `token-3` after invalidation but got %q", token.AccessToken)
+	}
+}
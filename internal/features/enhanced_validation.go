@@ -24,3 +24,21 @@ func EnhancedValidationEnabled() bool {
 
 	return strings.EqualFold(value, "true")
 }
+
+// EnhancedSkuValidationEnabled returns whether or not the feature for Enhanced SKU Validation is
+// enabled.
+//
+// This functionality extends Enhanced Validation by caching the SKUs which are available for
+// Virtual Machines, Managed Disks and App Service Plans in each Azure Location (and Availability
+// Zone) - and then uses that to validate the SKU at plan time, rather than failing during the apply.
+//
+// This is opt-in since it requires listing the SKUs available to the Subscription, and can be
+// enabled by setting the Environment Variable `ARM_PROVIDER_ENHANCED_SKU_VALIDATION` to `true`.
+// This has no effect when Enhanced Validation is disabled.
+func EnhancedSkuValidationEnabled() bool {
+	if !EnhancedValidationEnabled() {
+		return false
+	}
+
+	return strings.EqualFold(os.Getenv("ARM_PROVIDER_ENHANCED_SKU_VALIDATION"), "true")
+}
@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package features
+
+import (
+	"os"
+	"strings"
+)
+
+type QuotaPreflightMode string
+
+const (
+	QuotaPreflightModeDisabled QuotaPreflightMode = ""
+	QuotaPreflightModeError    QuotaPreflightMode = "error"
+	QuotaPreflightModeWarn     QuotaPreflightMode = "warn"
+)
+
+// QuotaPreflight returns the mode used for the Quota Pre-flight checks.
+//
+// This functionality sums the vCPUs and Public IP Addresses requested by the Resources in the plan and
+// compares these against the Usages API for the Location - so that an exhausted Quota can be surfaced
+// at plan time, rather than once the apply has been running for some time.
+//
+// This is opt-in and can be enabled by setting the Environment Variable `ARM_PROVIDER_QUOTA_PREFLIGHT`
+// to either `warn` (which returns a warning when the Resource is created/updated) or `error` (which fails the plan).
+func QuotaPreflight() QuotaPreflightMode {
+	value := strings.ToLower(os.Getenv("ARM_PROVIDER_QUOTA_PREFLIGHT"))
+	switch QuotaPreflightMode(value) {
+	case QuotaPreflightModeError, QuotaPreflightModeWarn:
+		return QuotaPreflightMode(value)
+	}
+
+	return QuotaPreflightModeDisabled
+}
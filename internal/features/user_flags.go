@@ -48,6 +48,8 @@ type KeyVaultFeatures struct {
 	RecoverSoftDeletedKeys           bool
 	RecoverSoftDeletedCerts          bool
 	RecoverSoftDeletedSecrets        bool
+
+	TemporarilyAllowClientIPInNetworkAcls bool
 }
 
 type TemplateDeploymentFeatures struct {
@@ -156,6 +156,13 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 						Optional:    true,
 						Default:     true,
 					},
+
+					"temporarily_allow_client_ip_in_network_acls": {
+						Description: "When enabled the IP Address of the client will be temporarily added to the Network ACLs of the Key Vault when a request to the Data Plane API is rejected by the Key Vault's firewall, and removed once the operation has completed",
+						Type:        pluginsdk.TypeBool,
+						Optional:    true,
+						Default:     false,
+					},
 				},
 			},
 		},
@@ -450,6 +457,9 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 			if v, ok := keyVaultRaw["recover_soft_deleted_secrets"]; ok {
 				featuresMap.KeyVault.RecoverSoftDeletedSecrets = v.(bool)
 			}
+			if v, ok := keyVaultRaw["temporarily_allow_client_ip_in_network_acls"]; ok {
+				featuresMap.KeyVault.TemporarilyAllowClientIPInNetworkAcls = v.(bool)
+			}
 		}
 	}
 
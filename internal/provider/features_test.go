@@ -121,6 +121,7 @@ func TestExpandFeatures(t *testing.T) {
 							"recover_soft_deleted_keys":                               true,
 							"recover_soft_deleted_key_vaults":                         true,
 							"recover_soft_deleted_secrets":                            true,
+							"temporarily_allow_client_ip_in_network_acls":             true,
 						},
 					},
 					"log_analytics_workspace": []interface{}{
@@ -197,15 +198,16 @@ func TestExpandFeatures(t *testing.T) {
 					PurgeSoftDeleteOnDestroy: true,
 				},
 				KeyVault: features.KeyVaultFeatures{
-					PurgeSoftDeletedCertsOnDestroy:   true,
-					PurgeSoftDeletedKeysOnDestroy:    true,
-					PurgeSoftDeletedSecretsOnDestroy: true,
-					PurgeSoftDeleteOnDestroy:         true,
-					PurgeSoftDeletedHSMsOnDestroy:    true,
-					RecoverSoftDeletedCerts:          true,
-					RecoverSoftDeletedKeys:           true,
-					RecoverSoftDeletedKeyVaults:      true,
-					RecoverSoftDeletedSecrets:        true,
+					PurgeSoftDeletedCertsOnDestroy:        true,
+					PurgeSoftDeletedKeysOnDestroy:         true,
+					PurgeSoftDeletedSecretsOnDestroy:      true,
+					PurgeSoftDeleteOnDestroy:              true,
+					PurgeSoftDeletedHSMsOnDestroy:         true,
+					RecoverSoftDeletedCerts:               true,
+					RecoverSoftDeletedKeys:                true,
+					RecoverSoftDeletedKeyVaults:           true,
+					RecoverSoftDeletedSecrets:             true,
+					TemporarilyAllowClientIPInNetworkAcls: true,
 				},
 				LogAnalyticsWorkspace: features.LogAnalyticsWorkspaceFeatures{
 					PermanentlyDeleteOnDestroy: true,
@@ -282,6 +284,7 @@ func TestExpandFeatures(t *testing.T) {
 							"recover_soft_deleted_keys":                               false,
 							"recover_soft_deleted_key_vaults":                         false,
 							"recover_soft_deleted_secrets":                            false,
+							"temporarily_allow_client_ip_in_network_acls":             false,
 						},
 					},
 					"log_analytics_workspace": []interface{}{
@@ -358,15 +361,16 @@ func TestExpandFeatures(t *testing.T) {
 					PurgeSoftDeleteOnDestroy: false,
 				},
 				KeyVault: features.KeyVaultFeatures{
-					PurgeSoftDeletedCertsOnDestroy:   false,
-					PurgeSoftDeletedKeysOnDestroy:    false,
-					PurgeSoftDeletedSecretsOnDestroy: false,
-					PurgeSoftDeletedHSMsOnDestroy:    false,
-					PurgeSoftDeleteOnDestroy:         false,
-					RecoverSoftDeletedCerts:          false,
-					RecoverSoftDeletedKeys:           false,
-					RecoverSoftDeletedKeyVaults:      false,
-					RecoverSoftDeletedSecrets:        false,
+					PurgeSoftDeletedCertsOnDestroy:        false,
+					PurgeSoftDeletedKeysOnDestroy:         false,
+					PurgeSoftDeletedSecretsOnDestroy:      false,
+					PurgeSoftDeletedHSMsOnDestroy:         false,
+					PurgeSoftDeleteOnDestroy:              false,
+					RecoverSoftDeletedCerts:               false,
+					RecoverSoftDeletedKeys:                false,
+					RecoverSoftDeletedKeyVaults:           false,
+					RecoverSoftDeletedSecrets:             false,
+					TemporarilyAllowClientIPInNetworkAcls: false,
 				},
 				LogAnalyticsWorkspace: features.LogAnalyticsWorkspaceFeatures{
 					PermanentlyDeleteOnDestroy: false,
@@ -730,21 +734,23 @@ func TestExpandFeaturesKeyVault(t *testing.T) {
 							"recover_soft_deleted_keys":                               true,
 							"recover_soft_deleted_key_vaults":                         true,
 							"recover_soft_deleted_secrets":                            true,
+							"temporarily_allow_client_ip_in_network_acls":             true,
 						},
 					},
 				},
 			},
 			Expected: features.UserFeatures{
 				KeyVault: features.KeyVaultFeatures{
-					PurgeSoftDeletedCertsOnDestroy:   true,
-					PurgeSoftDeletedKeysOnDestroy:    true,
-					PurgeSoftDeletedSecretsOnDestroy: true,
-					PurgeSoftDeletedHSMsOnDestroy:    true,
-					PurgeSoftDeleteOnDestroy:         true,
-					RecoverSoftDeletedCerts:          true,
-					RecoverSoftDeletedKeys:           true,
-					RecoverSoftDeletedKeyVaults:      true,
-					RecoverSoftDeletedSecrets:        true,
+					PurgeSoftDeletedCertsOnDestroy:        true,
+					PurgeSoftDeletedKeysOnDestroy:         true,
+					PurgeSoftDeletedSecretsOnDestroy:      true,
+					PurgeSoftDeletedHSMsOnDestroy:         true,
+					PurgeSoftDeleteOnDestroy:              true,
+					RecoverSoftDeletedCerts:               true,
+					RecoverSoftDeletedKeys:                true,
+					RecoverSoftDeletedKeyVaults:           true,
+					RecoverSoftDeletedSecrets:             true,
+					TemporarilyAllowClientIPInNetworkAcls: true,
 				},
 			},
 		},
@@ -763,21 +769,23 @@ func TestExpandFeaturesKeyVault(t *testing.T) {
 							"recover_soft_deleted_keys":                               false,
 							"recover_soft_deleted_key_vaults":                         false,
 							"recover_soft_deleted_secrets":                            false,
+							"temporarily_allow_client_ip_in_network_acls":             false,
 						},
 					},
 				},
 			},
 			Expected: features.UserFeatures{
 				KeyVault: features.KeyVaultFeatures{
-					PurgeSoftDeletedCertsOnDestroy:   false,
-					PurgeSoftDeletedKeysOnDestroy:    false,
-					PurgeSoftDeletedSecretsOnDestroy: false,
-					PurgeSoftDeleteOnDestroy:         false,
-					PurgeSoftDeletedHSMsOnDestroy:    false,
-					RecoverSoftDeletedCerts:          false,
-					RecoverSoftDeletedKeyVaults:      false,
-					RecoverSoftDeletedKeys:           false,
-					RecoverSoftDeletedSecrets:        false,
+					PurgeSoftDeletedCertsOnDestroy:        false,
+					PurgeSoftDeletedKeysOnDestroy:         false,
+					PurgeSoftDeletedSecretsOnDestroy:      false,
+					PurgeSoftDeleteOnDestroy:              false,
+					PurgeSoftDeletedHSMsOnDestroy:         false,
+					RecoverSoftDeletedCerts:               false,
+					RecoverSoftDeletedKeyVaults:           false,
+					RecoverSoftDeletedKeys:                false,
+					RecoverSoftDeletedSecrets:             false,
+					TemporarilyAllowClientIPInNetworkAcls: false,
 				},
 			},
 		},
@@ -10,6 +10,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -143,3 +144,35 @@ func getTenantId(d *pluginsdk.ResourceData) (*string, error) {
 
 	return &tenantId, nil
 }
+
+func expandAuthMethodOrder(d *pluginsdk.ResourceData) ([]clients.AuthMethod, error) {
+	var input []string
+	if v, ok := d.Get("authentication_order").([]interface{}); ok && len(v) > 0 {
+		for _, item := range v {
+			input = append(input, item.(string))
+		}
+	} else if v := os.Getenv("ARM_AUTHENTICATION_ORDER"); v != "" {
+		input = strings.Split(v, ";")
+	}
+
+	possibleValues := make(map[string]struct{})
+	for _, v := range clients.PossibleValuesForAuthMethod() {
+		possibleValues[v] = struct{}{}
+	}
+
+	output := make([]clients.AuthMethod, 0)
+	seen := make(map[string]struct{})
+	for _, v := range input {
+		v = strings.TrimSpace(v)
+		if _, ok := possibleValues[v]; !ok {
+			return nil, fmt.Errorf("unsupported authentication method %q in `authentication_order` - possible values are: %s", v, strings.Join(clients.PossibleValuesForAuthMethod(), ", "))
+		}
+		if _, ok := seen[v]; ok {
+			return nil, fmt.Errorf("the authentication method %q was specified more than once in `authentication_order`", v)
+		}
+		seen[v] = struct{}{}
+		output = append(output, clients.AuthMethod(v))
+	}
+
+	return output, nil
+}
@@ -286,6 +286,32 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				Description: "Allow Azure CLI to be used for Authentication.",
 			},
 
+			// Azure Developer CLI specific fields
+			"use_azd": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_USE_AZD", false),
+				Description: "Allow Azure Developer CLI (azd) to be used for Authentication.",
+			},
+
+			// Azure PowerShell specific fields
+			"use_powershell": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_USE_POWERSHELL", false),
+				Description: "Allow Azure PowerShell to be used for Authentication.",
+			},
+
+			"authentication_order": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(clients.PossibleValuesForAuthMethod(), false),
+				},
+				Description: "The order in which the enabled authentication methods should be attempted. Authentication methods which are omitted aren't attempted.",
+			},
+
 			// Azure AKS Workload Identity fields
 			"use_aks_workload_identity": {
 				Type:        schema.TypeBool,
@@ -443,8 +469,14 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData, authConfig *auth.Credentials) (*clients.Client, diag.Diagnostics) {
 	skipProviderRegistration := d.Get("skip_provider_registration").(bool)
 
+	authMethodOrder, err := expandAuthMethodOrder(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
 	clientBuilder := clients.ClientBuilder{
 		AuthConfig:                  authConfig,
+		AuthMethodOrder:             authMethodOrder,
 		DisableCorrelationRequestID: d.Get("disable_correlation_request_id").(bool),
 		DisableTerraformPartnerID:   d.Get("disable_terraform_partner_id").(bool),
 		Features:                    expandFeatures(d.Get("features").([]interface{})),
@@ -455,6 +487,9 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 		SubscriptionID:              d.Get("subscription_id").(string),
 		TerraformVersion:            p.TerraformVersion,
 
+		EnableAuthenticatingUsingAzureDeveloperCLI: d.Get("use_azd").(bool),
+		EnableAuthenticatingUsingAzurePowerShell:   d.Get("use_powershell").(bool),
+
 		// this field is intentionally not exposed in the provider block, since it's only used for
 		// platform level tracing
 		CustomCorrelationRequestID: os.Getenv("ARM_CORRELATION_REQUEST_ID"),
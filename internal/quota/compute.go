@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package quota
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+const (
+	computeUsageRegionalCores = "cores"
+	computeUsageSpotCores     = "lowPriorityCores"
+)
+
+// ComputeRequest describes the vCPUs requested by a Virtual Machine, Virtual Machine Scale Set or Node Pool
+type ComputeRequest struct {
+	// Key uniquely identifies this Resource within the plan, e.g. the Resource ID
+	Key string
+
+	Location string
+
+	// Size is the Virtual Machine Size being requested, e.g. `Standard_D2s_v3`
+	Size string
+
+	// Count is the number of instances of this Size being requested
+	Count int64
+
+	// Spot specifies whether these are Spot instances, which use a separate Quota
+	Spot bool
+
+	// PreviousSize and PreviousCount are the Size and number of instances which exist prior to an update,
+	// which are subtracted from the vCPUs being requested
+	PreviousSize  string
+	PreviousCount int64
+}
+
+// CheckCompute validates that the vCPU Quota within the Location is sufficient for this Resource and the other
+// Resources in the plan - recording a warning (see WithWarnings) or returning an error depending on the mode configured in
+// `ARM_PROVIDER_QUOTA_PREFLIGHT`.
+func CheckCompute(ctx context.Context, client *clients.Client, request ComputeRequest) error {
+	if features.QuotaPreflight() == features.QuotaPreflightModeDisabled {
+		return nil
+	}
+
+	return checkerForClient(client).checkCompute(ctx, request)
+}
+
+func (c *checker) checkCompute(ctx context.Context, request ComputeRequest) error {
+	if c.mode == features.QuotaPreflightModeDisabled || request.Size == "" || request.Location == "" {
+		return nil
+	}
+
+	locationName := location.Normalize(request.Location)
+	size, err := c.virtualMachineSize(ctx, locationName, request.Size)
+	if err != nil {
+		log.Printf("[DEBUG] Quota Pre-flight: unable to retrieve the Virtual Machine Sizes for %q: %+v", locationName, err)
+		return nil
+	}
+	if size == nil {
+		// the Size isn't available in this Location, which the API will surface
+		return nil
+	}
+
+	var previous *VirtualMachineSize
+	if request.PreviousSize != "" && request.PreviousCount > 0 {
+		previous, err = c.virtualMachineSize(ctx, locationName, request.PreviousSize)
+		if err != nil {
+			log.Printf("[DEBUG] Quota Pre-flight: unable to retrieve the Virtual Machine Sizes for %q: %+v", locationName, err)
+			return nil
+		}
+	}
+
+	return c.check(ctx, usageProviderCompute, locationName, request.Key, computeUsagesRequested(request, *size, previous))
+}
+
+// computeUsagesRequested returns the additional vCPUs requested for each of the Compute Usages
+func computeUsagesRequested(request ComputeRequest, size VirtualMachineSize, previous *VirtualMachineSize) map[string]int64 {
+	requested := size.VCPUs * request.Count
+
+	existing := int64(0)
+	existingInFamily := int64(0)
+	if previous != nil {
+		existing = previous.VCPUs * request.PreviousCount
+		if previous.Family == size.Family {
+			existingInFamily = existing
+		}
+	}
+
+	if request.Spot {
+		return map[string]int64{
+			computeUsageSpotCores: requested - existing,
+		}
+	}
+
+	output := map[string]int64{
+		computeUsageRegionalCores: requested - existing,
+	}
+	if size.Family != "" {
+		output[size.Family] = requested - existingInFamily
+	}
+	return output
+}
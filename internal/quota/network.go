@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package quota
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+const (
+	networkUsagePublicIPAddresses       = "PublicIPAddresses"
+	networkUsageStaticPublicIPAddresses = "StaticPublicIPAddresses"
+)
+
+// CheckPublicIPAddress validates that the Public IP Address Quota within the Location is sufficient for this
+// Public IP Address and the other Public IP Addresses in the plan - recording a warning (see WithWarnings) or returning an error
+// depending on the mode configured in `ARM_PROVIDER_QUOTA_PREFLIGHT`.
+func CheckPublicIPAddress(ctx context.Context, client *clients.Client, key string, location string, static bool) error {
+	if features.QuotaPreflight() == features.QuotaPreflightModeDisabled {
+		return nil
+	}
+
+	return checkerForClient(client).checkPublicIPAddress(ctx, key, location, static)
+}
+
+func (c *checker) checkPublicIPAddress(ctx context.Context, key string, location string, static bool) error {
+	if location == "" {
+		return nil
+	}
+
+	requested := map[string]int64{
+		networkUsagePublicIPAddresses: 1,
+	}
+	if static {
+		requested[networkUsageStaticPublicIPAddresses] = 1
+	}
+
+	return c.check(ctx, usageProviderNetwork, location, key, requested)
+}
@@ -0,0 +1,246 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package quota
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+// Usage is the current usage and limit of a Quota within a Location
+type Usage struct {
+	Current int64
+	Limit   int64
+}
+
+// VirtualMachineSize contains the information about a Virtual Machine Size required to calculate the vCPUs requested
+type VirtualMachineSize struct {
+	// Family is the name of the vCPU Quota for this Size, e.g. `standardDSv3Family`
+	Family string
+
+	// VCPUs is the number of vCPUs used by a single instance of this Size
+	VCPUs int64
+}
+
+type usageProvider string
+
+const (
+	usageProviderCompute usageProvider = "Microsoft.Compute"
+	usageProviderNetwork usageProvider = "Microsoft.Network"
+)
+
+// source retrieves the Usages and Virtual Machine Sizes from Azure, this is an interface to aid testing
+type source interface {
+	Usages(ctx context.Context, provider usageProvider, location string) (map[string]Usage, error)
+	VirtualMachineSizes(ctx context.Context, location string) (map[string]VirtualMachineSize, error)
+}
+
+// checker sums the Quota requested by each of the Resources in the plan and compares this against the Usages,
+// since Quotas are per-Subscription a checker only contains the Usages and requests for a single Subscription
+type checker struct {
+	subscriptionId string
+	mode           features.QuotaPreflightMode
+	source         source
+
+	lock sync.Mutex
+
+	// usages is keyed on the subscription/provider/location and then the lower-cased Usage name
+	usages map[string]map[string]Usage
+
+	// sizes is keyed on the location and then the lower-cased Virtual Machine Size
+	sizes map[string]map[string]VirtualMachineSize
+
+	// requests is keyed on the subscription/provider/location/lower-cased Usage name and then the key for the Resource
+	requests map[string]map[string]int64
+
+	// warnings is keyed on the key for the Resource and then the subscription/provider/location, and contains the message for
+	// the Quotas which would be exceeded when running in `warn` mode
+	warnings map[string]map[string]string
+}
+
+func newChecker(subscriptionId string, mode features.QuotaPreflightMode, source source) *checker {
+	return &checker{
+		subscriptionId: subscriptionId,
+		mode:           mode,
+		source:         source,
+		usages:         make(map[string]map[string]Usage),
+		sizes:          make(map[string]map[string]VirtualMachineSize),
+		requests:       make(map[string]map[string]int64),
+		warnings:       make(map[string]map[string]string),
+	}
+}
+
+var (
+	// checkers is keyed on the Subscription ID, since multiple (aliased) Providers can target different Subscriptions
+	checkers     = make(map[string]*checker)
+	checkersLock sync.Mutex
+)
+
+// checkerForClient returns the checker shared by all Resources within the Subscription used by this client, since
+// the Quota requested is summed across the plan
+func checkerForClient(client *clients.Client) *checker {
+	subscriptionId := client.Account.SubscriptionId
+
+	checkersLock.Lock()
+	defer checkersLock.Unlock()
+
+	if v, ok := checkers[subscriptionId]; ok {
+		return v
+	}
+
+	v := newChecker(subscriptionId, features.QuotaPreflight(), clientSource{
+		client: client,
+	})
+	checkers[subscriptionId] = v
+	return v
+}
+
+// usageKey returns the key for the Usages of this provider within this location
+func (c *checker) usageKey(provider usageProvider, locationName string) string {
+	return fmt.Sprintf("%s/%s/%s", c.subscriptionId, provider, locationName)
+}
+
+// check records the Quota requested by the Resource identified by `key` and then validates that the total Quota
+// requested across the plan is available - since CustomizeDiff can be called multiple times for the same Resource
+// the requests are keyed on the Resource, rather than being added to a running total.
+func (c *checker) check(ctx context.Context, provider usageProvider, locationName string, key string, requested map[string]int64) error {
+	if c.mode == features.QuotaPreflightModeDisabled {
+		return nil
+	}
+
+	locationName = location.Normalize(locationName)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	usages, err := c.usagesFor(ctx, provider, locationName)
+	if err != nil {
+		// this is best-effort, so we shouldn't block the plan when the Usages couldn't be retrieved
+		log.Printf("[DEBUG] Quota Pre-flight: unable to retrieve the %s Usages for %q: %+v", provider, locationName, err)
+		return nil
+	}
+
+	names := make([]string, 0)
+	for name, amount := range requested {
+		requestKey := fmt.Sprintf("%s/%s", c.usageKey(provider, locationName), strings.ToLower(name))
+		if _, ok := c.requests[requestKey]; !ok {
+			c.requests[requestKey] = make(map[string]int64)
+		}
+		c.requests[requestKey][key] = amount
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	exceeded := make([]string, 0)
+	for _, name := range names {
+		if requested[name] <= 0 {
+			continue
+		}
+
+		usage, ok := usages[strings.ToLower(name)]
+		if !ok {
+			continue
+		}
+
+		total := int64(0)
+		for _, amount := range c.requests[fmt.Sprintf("%s/%s", c.usageKey(provider, locationName), strings.ToLower(name))] {
+			if amount > 0 {
+				total += amount
+			}
+		}
+
+		if usage.Current+total > usage.Limit {
+			exceeded = append(exceeded, fmt.Sprintf("%s: %d requested in this plan but only %d of %d are available", name, total, usage.Limit-usage.Current, usage.Limit))
+		}
+	}
+
+	warningKey := c.usageKey(provider, locationName)
+	if len(exceeded) == 0 {
+		delete(c.warnings[key], warningKey)
+		return nil
+	}
+
+	message := fmt.Sprintf("the %s Quota in %q is insufficient for this plan:\n\n* %s", provider, locationName, strings.Join(exceeded, "\n* "))
+	if c.mode == features.QuotaPreflightModeWarn {
+		// SDKv2 doesn't support returning warnings from CustomizeDiff, so these are surfaced from Create/Update
+		log.Printf("[WARN] Quota Pre-flight: %s", message)
+		if _, ok := c.warnings[key]; !ok {
+			c.warnings[key] = make(map[string]string)
+		}
+		c.warnings[key][warningKey] = message
+		return nil
+	}
+
+	return fmt.Errorf("%s\n\nA Quota increase can be requested via the Azure Portal - alternatively this check can be disabled by unsetting the Environment Variable `ARM_PROVIDER_QUOTA_PREFLIGHT`", message)
+}
+
+// popWarnings returns (and then removes) the messages for the Quotas which would be exceeded by the Resource
+// identified by `key` when running in `warn` mode
+func (c *checker) popWarnings(key string) []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	output := make([]string, 0)
+	for _, message := range c.warnings[key] {
+		output = append(output, message)
+	}
+	sort.Strings(output)
+	delete(c.warnings, key)
+	return output
+}
+
+// usagesFor returns the Usages for this provider/location, which are retrieved once per run - the lock must be held
+func (c *checker) usagesFor(ctx context.Context, provider usageProvider, locationName string) (map[string]Usage, error) {
+	cacheKey := c.usageKey(provider, locationName)
+	if v, ok := c.usages[cacheKey]; ok {
+		return v, nil
+	}
+
+	usages, err := c.source.Usages(ctx, provider, locationName)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make(map[string]Usage)
+	for k, v := range usages {
+		output[strings.ToLower(k)] = v
+	}
+	c.usages[cacheKey] = output
+	return output, nil
+}
+
+// virtualMachineSize returns the details for the specified Virtual Machine Size, which are retrieved once per
+// Location per run - nil is returned when the Size isn't available in this Location
+func (c *checker) virtualMachineSize(ctx context.Context, locationName string, size string) (*VirtualMachineSize, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	sizes, ok := c.sizes[locationName]
+	if !ok {
+		v, err := c.source.VirtualMachineSizes(ctx, locationName)
+		if err != nil {
+			return nil, err
+		}
+
+		sizes = make(map[string]VirtualMachineSize)
+		for k, size := range v {
+			sizes[strings.ToLower(k)] = size
+		}
+		c.sizes[locationName] = sizes
+	}
+
+	if v, ok := sizes[strings.ToLower(size)]; ok {
+		return &v, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,278 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package quota
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+const testSubscriptionId = "00000000-0000-0000-0000-000000000000"
+
+type fakeSource struct {
+	usages map[usageProvider]map[string]Usage
+	sizes  map[string]VirtualMachineSize
+	calls  int
+}
+
+func (f *fakeSource) Usages(_ context.Context, provider usageProvider, _ string) (map[string]Usage, error) {
+	f.calls++
+	v, ok := f.usages[provider]
+	if !ok {
+		return nil, fmt.Errorf("no usages for %s", provider)
+	}
+	return v, nil
+}
+
+func (f *fakeSource) VirtualMachineSizes(_ context.Context, _ string) (map[string]VirtualMachineSize, error) {
+	return f.sizes, nil
+}
+
+func newFakeSource() *fakeSource {
+	return &fakeSource{
+		usages: map[usageProvider]map[string]Usage{
+			usageProviderCompute: {
+				"cores": {
+					Current: 12,
+					Limit:   20,
+				},
+				"standardDSv3Family": {
+					Current: 4,
+					Limit:   12,
+				},
+				"standardFSv2Family": {
+					Current: 0,
+					Limit:   100,
+				},
+				"lowPriorityCores": {
+					Current: 0,
+					Limit:   4,
+				},
+			},
+			usageProviderNetwork: {
+				"PublicIPAddresses": {
+					Current: 8,
+					Limit:   10,
+				},
+			},
+		},
+		sizes: map[string]VirtualMachineSize{
+			"Standard_D2s_v3": {
+				Family: "standardDSv3Family",
+				VCPUs:  2,
+			},
+			"Standard_D4s_v3": {
+				Family: "standardDSv3Family",
+				VCPUs:  4,
+			},
+			"Standard_F2s_v2": {
+				Family: "standardFSv2Family",
+				VCPUs:  2,
+			},
+		},
+	}
+}
+
+func TestCheckComputeSumsRequestsAcrossThePlan(t *testing.T) {
+	source := newFakeSource()
+	c := newChecker(testSubscriptionId, features.QuotaPreflightModeError, source)
+	ctx := context.TODO()
+
+	first := ComputeRequest{
+		Key:      "/subscriptions/0000/resourceGroups/example/providers/Microsoft.Compute/virtualMachines/first",
+		Location: "West Europe",
+		Size:     "Standard_D2s_v3",
+		Count:    1,
+	}
+	if err := c.checkCompute(ctx, first); err != nil {
+		t.Fatalf("expected the first request to fit within the Quota but got: %+v", err)
+	}
+
+	// CustomizeDiff can be called multiple times for the same Resource, which shouldn't be counted twice
+	if err := c.checkCompute(ctx, first); err != nil {
+		t.Fatalf("expected a repeated request to fit within the Quota but got: %+v", err)
+	}
+
+	second := ComputeRequest{
+		Key:      "/subscriptions/0000/resourceGroups/example/providers/Microsoft.Compute/virtualMachineScaleSets/second",
+		Location: "westeurope",
+		Size:     "standard_d2s_v3",
+		Count:    3,
+	}
+	// 4 in use + 2 + 6 = 12 of 12 for the family
+	if err := c.checkCompute(ctx, second); err != nil {
+		t.Fatalf("expected the second request to fit within the Quota but got: %+v", err)
+	}
+
+	third := ComputeRequest{
+		Key:      "/subscriptions/0000/resourceGroups/example/providers/Microsoft.Compute/virtualMachines/third",
+		Location: "westeurope",
+		Size:     "Standard_D2s_v3",
+		Count:    1,
+	}
+	err := c.checkCompute(ctx, third)
+	if err == nil {
+		t.Fatalf("expected an error when the Quota is exceeded but didn't get one")
+	}
+	for _, expected := range []string{
+		"cores: 10 requested in this plan but only 8 of 20 are available",
+		"standardDSv3Family: 10 requested in this plan but only 8 of 12 are available",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Fatalf("expected the error to contain %q but got: %+v", expected, err)
+		}
+	}
+
+	if source.calls != 1 {
+		t.Fatalf("expected the Usages to be retrieved once but they were retrieved %d times", source.calls)
+	}
+}
+
+func TestCheckComputeUpdates(t *testing.T) {
+	c := newChecker(testSubscriptionId, features.QuotaPreflightModeError, newFakeSource())
+	ctx := context.TODO()
+
+	// resizing within the same family only requests the additional vCPUs
+	err := c.checkCompute(ctx, ComputeRequest{
+		Key:           "resize",
+		Location:      "westeurope",
+		Size:          "Standard_D4s_v3",
+		Count:         4,
+		PreviousSize:  "Standard_D2s_v3",
+		PreviousCount: 4,
+	})
+	if err != nil {
+		t.Fatalf("expected a resize within the Quota to succeed but got: %+v", err)
+	}
+
+	// scaling down doesn't request any vCPUs
+	err = c.checkCompute(ctx, ComputeRequest{
+		Key:           "scale-down",
+		Location:      "westeurope",
+		Size:          "Standard_D4s_v3",
+		Count:         1,
+		PreviousSize:  "Standard_D4s_v3",
+		PreviousCount: 10,
+	})
+	if err != nil {
+		t.Fatalf("expected scaling down to succeed but got: %+v", err)
+	}
+
+	// Spot instances use a separate Quota
+	err = c.checkCompute(ctx, ComputeRequest{
+		Key:      "spot",
+		Location: "westeurope",
+		Size:     "Standard_F2s_v2",
+		Count:    3,
+		Spot:     true,
+	})
+	if err == nil || !strings.Contains(err.Error(), "lowPriorityCores: 6 requested") {
+		t.Fatalf("expected an error for the Spot Quota but got: %+v", err)
+	}
+}
+
+func TestComputeUsagesRequested(t *testing.T) {
+	d2 := VirtualMachineSize{Family: "standardDSv3Family", VCPUs: 2}
+	f2 := VirtualMachineSize{Family: "standardFSv2Family", VCPUs: 2}
+
+	actual := computeUsagesRequested(ComputeRequest{Count: 2, PreviousCount: 3}, f2, &d2)
+	if actual["cores"] != -2 || actual["standardFSv2Family"] != 4 {
+		t.Fatalf("unexpected Usages when changing family: %+v", actual)
+	}
+}
+
+func TestCheckPublicIPAddress(t *testing.T) {
+	ctx := context.TODO()
+
+	warn := newChecker(testSubscriptionId, features.QuotaPreflightModeWarn, newFakeSource())
+	for i := 0; i < 3; i++ {
+		if err := warn.checkPublicIPAddress(ctx, fmt.Sprintf("ip-%d", i), "westeurope", true); err != nil {
+			t.Fatalf("expected no error in warn mode but got: %+v", err)
+		}
+	}
+
+	errorMode := newChecker(testSubscriptionId, features.QuotaPreflightModeError, newFakeSource())
+	for i := 0; i < 2; i++ {
+		if err := errorMode.checkPublicIPAddress(ctx, fmt.Sprintf("ip-%d", i), "westeurope", true); err != nil {
+			t.Fatalf("expected Public IP %d to fit within the Quota but got: %+v", i, err)
+		}
+	}
+	if err := errorMode.checkPublicIPAddress(ctx, "ip-2", "westeurope", true); err == nil || !strings.Contains(err.Error(), "PublicIPAddresses: 3 requested") {
+		t.Fatalf("expected an error when the Quota is exceeded but got: %+v", err)
+	}
+}
+
+func TestCheckWarnModeRecordsWarnings(t *testing.T) {
+	ctx := context.TODO()
+
+	c := newChecker(testSubscriptionId, features.QuotaPreflightModeWarn, newFakeSource())
+	for i := 0; i < 3; i++ {
+		if err := c.checkPublicIPAddress(ctx, fmt.Sprintf("ip-%d", i), "westeurope", true); err != nil {
+			t.Fatalf("expected no error in warn mode but got: %+v", err)
+		}
+	}
+
+	if warnings := c.popWarnings("ip-0"); len(warnings) != 0 {
+		t.Fatalf("expected no warnings for the Public IP within the Quota but got: %+v", warnings)
+	}
+
+	warnings := c.popWarnings("ip-2")
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "PublicIPAddresses: 3 requested") {
+		t.Fatalf("expected a warning for the Public IP exceeding the Quota but got: %+v", warnings)
+	}
+	if warnings := c.popWarnings("ip-2"); len(warnings) != 0 {
+		t.Fatalf("expected the warnings to be removed once returned but got: %+v", warnings)
+	}
+
+	// re-planning the Resource once it fits within the Quota should clear the warning
+	if err := c.checkPublicIPAddress(ctx, "ip-2", "westeurope", true); err != nil {
+		t.Fatalf("expected no error in warn mode but got: %+v", err)
+	}
+	delete(c.requests[fmt.Sprintf("%s/%s", c.usageKey(usageProviderNetwork, "westeurope"), strings.ToLower(networkUsagePublicIPAddresses))], "ip-0")
+	delete(c.requests[fmt.Sprintf("%s/%s", c.usageKey(usageProviderNetwork, "westeurope"), strings.ToLower(networkUsageStaticPublicIPAddresses))], "ip-0")
+	if err := c.checkPublicIPAddress(ctx, "ip-2", "westeurope", true); err != nil {
+		t.Fatalf("expected no error in warn mode but got: %+v", err)
+	}
+	if warnings := c.popWarnings("ip-2"); len(warnings) != 0 {
+		t.Fatalf("expected no warnings once the Public IP fits within the Quota but got: %+v", warnings)
+	}
+}
+
+func TestCheckIgnoresUnavailableUsages(t *testing.T) {
+	source := newFakeSource()
+	delete(source.usages, usageProviderNetwork)
+
+	c := newChecker(testSubscriptionId, features.QuotaPreflightModeError, source)
+	if err := c.checkPublicIPAddress(context.TODO(), "ip", "westeurope", false); err != nil {
+		t.Fatalf("expected no error when the Usages are unavailable but got: %+v", err)
+	}
+}
+
+func TestCheckerForClientIsScopedToTheSubscription(t *testing.T) {
+	first := &clients.Client{
+		Account: &clients.ResourceManagerAccount{
+			SubscriptionId: "11111111-1111-1111-1111-111111111111",
+		},
+	}
+	second := &clients.Client{
+		Account: &clients.ResourceManagerAccount{
+			SubscriptionId: "22222222-2222-2222-2222-222222222222",
+		},
+	}
+
+	if checkerForClient(first) != checkerForClient(first) {
+		t.Fatalf("expected the same checker to be returned for the same Subscription")
+	}
+	if checkerForClient(first) == checkerForClient(second) {
+		t.Fatalf("expected a different checker to be returned for a different Subscription")
+	}
+	if actual := checkerForClient(second).subscriptionId; actual != second.Account.SubscriptionId {
+		t.Fatalf("expected the checker to be for the Subscription %q but got %q", second.Account.SubscriptionId, actual)
+	}
+}
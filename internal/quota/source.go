@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package quota
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/skus"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-09-01/usages"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+var _ source = clientSource{}
+
+// clientSource retrieves the Usages and Virtual Machine Sizes using the Azure API
+type clientSource struct {
+	client *clients.Client
+}
+
+func (s clientSource) Usages(ctx context.Context, provider usageProvider, location string) (map[string]Usage, error) {
+	output := make(map[string]Usage)
+
+	switch provider {
+	case usageProviderCompute:
+		iterator, err := s.client.Compute.UsageClient.ListComplete(ctx, location)
+		if err != nil {
+			return nil, fmt.Errorf("listing Compute Usages: %+v", err)
+		}
+		for iterator.NotDone() {
+			item := iterator.Value()
+			if item.Name != nil && item.Name.Value != nil {
+				output[*item.Name.Value] = Usage{
+					Current: int64(pointer.From(item.CurrentValue)),
+					Limit:   pointer.From(item.Limit),
+				}
+			}
+
+			if err := iterator.NextWithContext(ctx); err != nil {
+				return nil, fmt.Errorf("listing Compute Usages: %+v", err)
+			}
+		}
+
+	case usageProviderNetwork:
+		id := usages.NewLocationID(s.client.Account.SubscriptionId, location)
+		resp, err := s.client.Network.Usages.ListComplete(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("listing Network Usages: %+v", err)
+		}
+		for _, item := range resp.Items {
+			if item.Name.Value != nil {
+				output[*item.Name.Value] = Usage{
+					Current: item.CurrentValue,
+					Limit:   item.Limit,
+				}
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported Usage provider %q", provider)
+	}
+
+	return output, nil
+}
+
+func (s clientSource) VirtualMachineSizes(ctx context.Context, location string) (map[string]VirtualMachineSize, error) {
+	subscriptionId := commonids.NewSubscriptionID(s.client.Account.SubscriptionId)
+	options := skus.ResourceSkusListOperationOptions{
+		Filter: pointer.To(fmt.Sprintf("location eq '%s'", location)),
+	}
+	resp, err := s.client.Compute.SkusClient.ResourceSkusListComplete(ctx, subscriptionId, options)
+	if err != nil {
+		return nil, fmt.Errorf("listing Compute SKUs in %q: %+v", location, err)
+	}
+
+	output := make(map[string]VirtualMachineSize)
+	for _, item := range resp.Items {
+		if item.Name == nil || !strings.EqualFold(pointer.From(item.ResourceType), "virtualMachines") {
+			continue
+		}
+
+		size := VirtualMachineSize{
+			Family: pointer.From(item.Family),
+		}
+		for _, capability := range pointer.From(item.Capabilities) {
+			if !strings.EqualFold(pointer.From(capability.Name), "vCPUs") {
+				continue
+			}
+
+			vcpus, err := strconv.ParseInt(pointer.From(capability.Value), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("parsing the vCPUs %q for %q: %+v", pointer.From(capability.Value), *item.Name, err)
+			}
+			size.VCPUs = vcpus
+		}
+
+		output[*item.Name] = size
+	}
+
+	return output, nil
+}
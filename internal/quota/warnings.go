@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package quota
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// ResourceGetter is implemented by both a ResourceDiff (when planning) and ResourceData (when applying)
+type ResourceGetter interface {
+	Id() string
+	Get(key string) interface{}
+}
+
+// ResourceKeyFunc returns the key which uniquely identifies the Resource within the plan - this is called both when
+// planning and when applying, so must return the same key for each, e.g. the Resource ID.
+type ResourceKeyFunc func(d ResourceGetter, meta interface{}) (string, error)
+
+// WithWarnings wraps the Create and Update functions of the Resource so that, when `ARM_PROVIDER_QUOTA_PREFLIGHT`
+// is set to `warn`, any Quotas which would be exceeded by this Resource are returned as warning diagnostics.
+//
+// SDKv2 doesn't support returning warnings from CustomizeDiff - however since Terraform plans the Resource again
+// within the same process during the apply, the warnings recorded at that point can be returned from Create/Update.
+func WithWarnings(resource *pluginsdk.Resource, keyFunc ResourceKeyFunc) *pluginsdk.Resource {
+	if v := resource.Create; v != nil { //nolint:staticcheck
+		resource.Create = nil //nolint:staticcheck
+		resource.CreateContext = withWarningsFunc(v, keyFunc)
+	}
+	if v := resource.Update; v != nil { //nolint:staticcheck
+		resource.Update = nil //nolint:staticcheck
+		resource.UpdateContext = withWarningsFunc(v, keyFunc)
+	}
+	return resource
+}
+
+func withWarningsFunc(f func(d *pluginsdk.ResourceData, meta interface{}) error, keyFunc ResourceKeyFunc) func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+		if features.QuotaPreflight() != features.QuotaPreflightModeWarn {
+			return diag.FromErr(f(d, meta))
+		}
+
+		// the key must be obtained prior to calling Create, since the Resource ID is set once it's been created
+		key, err := keyFunc(d, meta)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := f(d, meta); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return warningDiagnostics(checkerForClient(meta.(*clients.Client)).popWarnings(key))
+	}
+}
+
+func warningDiagnostics(messages []string) diag.Diagnostics {
+	output := make(diag.Diagnostics, 0)
+	for _, message := range messages {
+		output = append(output, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Quota Pre-flight",
+			Detail:   fmt.Sprintf("%s\n\nA Quota increase can be requested via the Azure Portal.", message),
+		})
+	}
+	return output
+}
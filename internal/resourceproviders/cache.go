@@ -18,6 +18,9 @@ var cachedResourceProviders *[]string
 var registeredResourceProviders *map[string]struct{}
 var unregisteredResourceProviders *map[string]struct{}
 
+// cachedSubscriptionId is the Subscription which the registered/unregistered Resource Providers were retrieved from
+var cachedSubscriptionId string
+
 var cacheLock = &sync.Mutex{}
 
 // CacheSupportedProviders attempts to retrieve the supported Resource Providers from the Resource Manager API
@@ -40,6 +43,7 @@ func ClearCache() {
 	cachedResourceProviders = nil
 	registeredResourceProviders = nil
 	unregisteredResourceProviders = nil
+	cachedSubscriptionId = ""
 	cacheLock.Unlock()
 }
 
@@ -47,9 +51,22 @@ func populateCache(ctx context.Context, client *providers.ProvidersClient, subsc
 	cacheLock.Lock()
 	defer cacheLock.Unlock()
 
+	providerNames, registeredProviders, unregisteredProviders, err := listResourceProviders(ctx, client, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	cachedResourceProviders = providerNames
+	registeredResourceProviders = registeredProviders
+	unregisteredResourceProviders = unregisteredProviders
+	cachedSubscriptionId = subscriptionId.SubscriptionId
+	return nil
+}
+
+func listResourceProviders(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId) (*[]string, *map[string]struct{}, *map[string]struct{}, error) {
 	providers, err := client.ListComplete(ctx, subscriptionId, providers.DefaultListOperationOptions())
 	if err != nil {
-		return fmt.Errorf("listing Resource Providers: %+v", err)
+		return nil, nil, nil, fmt.Errorf("listing Resource Providers: %+v", err)
 	}
 
 	providerNames := make([]string, 0)
@@ -69,8 +86,5 @@ func populateCache(ctx context.Context, client *providers.ProvidersClient, subsc
 		}
 	}
 
-	cachedResourceProviders = &providerNames
-	registeredResourceProviders = &registeredProviders
-	unregisteredResourceProviders = &unregisteredProviders
-	return nil
+	return &providerNames, &registeredProviders, &unregisteredProviders, nil
 }
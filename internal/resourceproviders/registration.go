@@ -17,6 +17,10 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders/custompollers"
 )
 
+// EnsureRegistered ensures that the required Resource Providers are registered in the specified Subscription.
+//
+// The Resource Providers for the first Subscription are cached (for use in validation) - however the Provider can
+// also be used with other Subscriptions, in which case the Resource Providers are retrieved for that Subscription.
 func EnsureRegistered(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId, requiredRPs map[string]struct{}) error {
 	if cachedResourceProviders == nil || registeredResourceProviders == nil || unregisteredResourceProviders == nil {
 		if err := populateCache(ctx, client, subscriptionId); err != nil {
@@ -24,8 +28,17 @@ func EnsureRegistered(ctx context.Context, client *providers.ProvidersClient, su
 		}
 	}
 
+	registered, unregistered := registeredResourceProviders, unregisteredResourceProviders
+	if !strings.EqualFold(cachedSubscriptionId, subscriptionId.SubscriptionId) {
+		log.Printf("[DEBUG] Retrieving the Resource Providers for %s", subscriptionId)
+		var err error
+		if _, registered, unregistered, err = listResourceProviders(ctx, client, subscriptionId); err != nil {
+			return fmt.Errorf("retrieving Resource Providers for %s: %+v", subscriptionId, err)
+		}
+	}
+
 	log.Printf("[DEBUG] Determining which Resource Providers require Registration")
-	providersToRegister, err := DetermineWhichRequiredResourceProvidersRequireRegistration(requiredRPs)
+	providersToRegister, err := determineWhichRequireRegistration(requiredRPs, registered, unregistered)
 	if err != nil {
 		return fmt.Errorf("determining which Required Resource Providers require registration: %+v", err)
 	}
@@ -10,6 +10,10 @@ import (
 
 // DetermineWhichRequiredResourceProvidersRequireRegistration determines which Resource Providers require registration to be able to be used
 func DetermineWhichRequiredResourceProvidersRequireRegistration(requiredResourceProviders map[string]struct{}) (*[]string, error) {
+	return determineWhichRequireRegistration(requiredResourceProviders, registeredResourceProviders, unregisteredResourceProviders)
+}
+
+func determineWhichRequireRegistration(requiredResourceProviders map[string]struct{}, registeredResourceProviders, unregisteredResourceProviders *map[string]struct{}) (*[]string, error) {
 	if registeredResourceProviders == nil || unregisteredResourceProviders == nil {
 		return nil, fmt.Errorf("internal-error: the registered/unregistered Resource Provider cache isn't populated")
 	}
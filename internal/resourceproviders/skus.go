@@ -0,0 +1,357 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/skus"
+	webResourceProviders "github.com/hashicorp/go-azure-sdk/resource-manager/web/2023-01-01/resourceproviders"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// SkuResourceType is the type of Resource which a SKU applies to, as returned by the Resource Provider
+type SkuResourceType string
+
+const (
+	SkuResourceTypeDisks           SkuResourceType = "disks"
+	SkuResourceTypeServerFarms     SkuResourceType = "serverFarms"
+	SkuResourceTypeVirtualMachines SkuResourceType = "virtualMachines"
+)
+
+// SkuAvailability describes the Locations (and Availability Zones) where a SKU is offered
+type SkuAvailability struct {
+	ResourceType SkuResourceType `json:"resourceType"`
+	Name         string          `json:"name"`
+	Locations    []SkuLocation   `json:"locations"`
+}
+
+// SkuLocation describes the availability of a SKU within a single Location
+type SkuLocation struct {
+	// Location is the normalized name of the Azure Location, e.g. `westeurope`
+	Location string `json:"location"`
+
+	// Zones are the Availability Zones within this Location where this SKU is offered
+	Zones []string `json:"zones,omitempty"`
+
+	// Restricted specifies that this SKU isn't available to this Subscription within this Location
+	Restricted bool `json:"restricted,omitempty"`
+
+	// RestrictedZones are the Availability Zones where this SKU isn't available to this Subscription
+	RestrictedZones []string `json:"restrictedZones,omitempty"`
+
+	// ReasonCode is the reason this SKU is restricted, e.g. `NotAvailableForSubscription`
+	ReasonCode string `json:"reasonCode,omitempty"`
+}
+
+// cachedSkus can be (validly) nil - as such this shouldn't be relied on
+// this is keyed on the lower-cased Resource Type and then the lower-cased SKU Name
+var cachedSkus *map[string]map[string]SkuAvailability
+
+var skuCacheLock = &sync.RWMutex{}
+
+// this is only here to aid testing
+var enhancedSkuValidationEnabled = features.EnhancedSkuValidationEnabled()
+
+// CacheSupportedSkus attempts to retrieve the SKUs available to this Subscription for Virtual Machines, Managed Disks
+// and App Service Plans and caches them, for use in enhanced validation.
+//
+// The SKUs are persisted to disk (see `skuCachePath`) so that these are only retrieved from the API once a day - and
+// when the Environment Variable `ARM_PROVIDER_SKU_CACHE_FIXTURE` is set, the SKUs are loaded from that file without
+// calling the API at all.
+func CacheSupportedSkus(ctx context.Context, computeClient *skus.SkusClient, webClient *webResourceProviders.ResourceProvidersClient, subscriptionId commonids.SubscriptionId) error {
+	// already populated
+	skuCacheLock.RLock()
+	populated := cachedSkus != nil
+	skuCacheLock.RUnlock()
+	if populated {
+		return nil
+	}
+
+	if fixturePath := os.Getenv("ARM_PROVIDER_SKU_CACHE_FIXTURE"); fixturePath != "" {
+		file, err := readSkuCacheFile(fixturePath)
+		if err != nil {
+			return fmt.Errorf("loading the SKU fixture from %q: %+v", fixturePath, err)
+		}
+		setCachedSkus(file.Skus)
+		return nil
+	}
+
+	cachePath, err := skuCachePath(subscriptionId)
+	if err != nil {
+		log.Printf("[DEBUG] unable to determine the path for the SKU cache: %+v - the SKUs won't be persisted", err)
+	}
+
+	if cachePath != "" {
+		file, err := readSkuCacheFile(cachePath)
+		if err == nil && !file.expired() && strings.EqualFold(file.SubscriptionId, subscriptionId.SubscriptionId) {
+			log.Printf("[DEBUG] loaded %d SKUs from the cache at %q", len(file.Skus), cachePath)
+			setCachedSkus(file.Skus)
+			return nil
+		}
+	}
+
+	available, err := listAvailableSkus(ctx, computeClient, webClient, subscriptionId)
+	if err != nil {
+		return err
+	}
+	setCachedSkus(available)
+
+	if cachePath != "" {
+		if err := writeSkuCacheFile(cachePath, subscriptionId, available); err != nil {
+			log.Printf("[DEBUG] unable to persist the SKU cache to %q: %+v", cachePath, err)
+		}
+	}
+
+	return nil
+}
+
+func ClearSkuCache() {
+	skuCacheLock.Lock()
+	cachedSkus = nil
+	skuCacheLock.Unlock()
+}
+
+func setCachedSkus(input []SkuAvailability) {
+	skuCacheLock.Lock()
+	defer skuCacheLock.Unlock()
+
+	output := make(map[string]map[string]SkuAvailability)
+	for _, sku := range input {
+		resourceType := strings.ToLower(string(sku.ResourceType))
+		if _, ok := output[resourceType]; !ok {
+			output[resourceType] = make(map[string]SkuAvailability)
+		}
+		output[resourceType][strings.ToLower(sku.Name)] = sku
+	}
+
+	cachedSkus = &output
+}
+
+func listAvailableSkus(ctx context.Context, computeClient *skus.SkusClient, webClient *webResourceProviders.ResourceProvidersClient, subscriptionId commonids.SubscriptionId) ([]SkuAvailability, error) {
+	output := make([]SkuAvailability, 0)
+
+	computeSkus, err := computeClient.ResourceSkusListComplete(ctx, subscriptionId, skus.ResourceSkusListOperationOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing Compute SKUs: %+v", err)
+	}
+	for _, item := range computeSkus.Items {
+		resourceType := SkuResourceType(pointer.From(item.ResourceType))
+		if item.Name == nil || (resourceType != SkuResourceTypeVirtualMachines && resourceType != SkuResourceTypeDisks) {
+			continue
+		}
+		output = append(output, flattenComputeSku(item))
+	}
+
+	webSkus, err := webClient.ListSkus(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("listing App Service SKUs: %+v", err)
+	}
+	if model := webSkus.Model; model != nil && model.Skus != nil {
+		for _, item := range *model.Skus {
+			if item.Name == nil {
+				continue
+			}
+
+			locations := make([]SkuLocation, 0)
+			for _, v := range pointer.From(item.Locations) {
+				locations = append(locations, SkuLocation{
+					Location: location.Normalize(v),
+				})
+			}
+			output = append(output, SkuAvailability{
+				ResourceType: SkuResourceTypeServerFarms,
+				Name:         *item.Name,
+				Locations:    locations,
+			})
+		}
+	}
+
+	return output, nil
+}
+
+func flattenComputeSku(input skus.ResourceSku) SkuAvailability {
+	locations := make(map[string]*SkuLocation)
+	for _, v := range pointer.From(input.LocationInfo) {
+		name := location.Normalize(pointer.From(v.Location))
+		locations[name] = &SkuLocation{
+			Location: name,
+			Zones:    pointer.From(v.Zones),
+		}
+	}
+	for _, v := range pointer.From(input.Locations) {
+		name := location.Normalize(v)
+		if _, ok := locations[name]; !ok {
+			locations[name] = &SkuLocation{
+				Location: name,
+			}
+		}
+	}
+
+	for _, restriction := range pointer.From(input.Restrictions) {
+		if restriction.RestrictionInfo == nil {
+			continue
+		}
+
+		reasonCode := string(pointer.From(restriction.ReasonCode))
+		switch pointer.From(restriction.Type) {
+		case skus.ResourceSkuRestrictionsTypeLocation:
+			for _, v := range pointer.From(restriction.RestrictionInfo.Locations) {
+				if existing, ok := locations[location.Normalize(v)]; ok {
+					existing.Restricted = true
+					existing.ReasonCode = reasonCode
+				}
+			}
+
+		case skus.ResourceSkuRestrictionsTypeZone:
+			for _, v := range pointer.From(restriction.RestrictionInfo.Locations) {
+				if existing, ok := locations[location.Normalize(v)]; ok {
+					existing.RestrictedZones = append(existing.RestrictedZones, pointer.From(restriction.RestrictionInfo.Zones)...)
+					existing.ReasonCode = reasonCode
+				}
+			}
+		}
+	}
+
+	output := SkuAvailability{
+		ResourceType: SkuResourceType(pointer.From(input.ResourceType)),
+		Name:         pointer.From(input.Name),
+		Locations:    make([]SkuLocation, 0),
+	}
+	for _, v := range locations {
+		output.Locations = append(output.Locations, *v)
+	}
+	sort.Slice(output.Locations, func(i, j int) bool {
+		return output.Locations[i].Location < output.Locations[j].Location
+	})
+
+	return output
+}
+
+// ValidateSkuAvailability validates that the SKU is available to this Subscription in the specified Location and
+// (optionally) Availability Zone.
+//
+// NOTE: this is best-effort - if Enhanced SKU Validation is disabled, the SKUs couldn't be retrieved or the Resource
+// Type isn't cached then no error is returned, so that the API can return the error (if any) during the apply.
+func ValidateSkuAvailability(resourceType SkuResourceType, skuName, locationName, zone string) error {
+	if !enhancedSkuValidationEnabled || skuName == "" || locationName == "" {
+		return nil
+	}
+
+	skuCacheLock.RLock()
+	defer skuCacheLock.RUnlock()
+
+	if cachedSkus == nil {
+		return nil
+	}
+	skusForType, ok := (*cachedSkus)[strings.ToLower(string(resourceType))]
+	if !ok {
+		return nil
+	}
+
+	sku, ok := skusForType[strings.ToLower(skuName)]
+	if !ok {
+		return fmt.Errorf("the SKU %q is not available to this Subscription for %q resources in any Location", skuName, resourceType)
+	}
+
+	normalizedLocation := location.Normalize(locationName)
+	var skuLocation *SkuLocation
+	availableLocations := make([]string, 0)
+	for i, v := range sku.Locations {
+		if !v.Restricted {
+			availableLocations = append(availableLocations, v.Location)
+		}
+		if v.Location == normalizedLocation {
+			skuLocation = &sku.Locations[i]
+		}
+	}
+	sort.Strings(availableLocations)
+
+	if skuLocation == nil || skuLocation.Restricted {
+		reason := ""
+		if skuLocation != nil && skuLocation.ReasonCode != "" {
+			reason = fmt.Sprintf(" (%s)", skuLocation.ReasonCode)
+		}
+		return fmt.Errorf("the SKU %q is not available to this Subscription in the Location %q%s - this SKU is available in the Locations: %s", skuName, normalizedLocation, reason, strings.Join(availableLocations, ", "))
+	}
+
+	if zone == "" {
+		return nil
+	}
+
+	availableZones := make([]string, 0)
+	for _, v := range skuLocation.Zones {
+		if !containsString(skuLocation.RestrictedZones, v) {
+			availableZones = append(availableZones, v)
+		}
+	}
+	sort.Strings(availableZones)
+
+	if !containsString(availableZones, zone) {
+		if len(availableZones) == 0 {
+			return fmt.Errorf("the SKU %q is not available in any Availability Zone in the Location %q", skuName, normalizedLocation)
+		}
+		return fmt.Errorf("the SKU %q is not available in Availability Zone %q in the Location %q - this SKU is available in the Availability Zones: %s", skuName, zone, normalizedLocation, strings.Join(availableZones, ", "))
+	}
+
+	return nil
+}
+
+// ValidateSkuAvailabilityForDiff validates the SKU specified in `skuField` is available in the Location (and when
+// `zoneField` is specified, the Availability Zone) being planned - for use within a CustomizeDiff.
+//
+// This is skipped when any of these fields are unknown at plan time, or when none of them are changing.
+func ValidateSkuAvailabilityForDiff(diff *pluginsdk.ResourceDiff, resourceType SkuResourceType, skuField, zoneField string) error {
+	if !enhancedSkuValidationEnabled {
+		return nil
+	}
+
+	fields := []string{"location", skuField}
+	if zoneField != "" {
+		fields = append(fields, zoneField)
+	}
+
+	hasChange := diff.Id() == ""
+	for _, field := range fields {
+		if !diff.NewValueKnown(field) {
+			return nil
+		}
+		if diff.HasChange(field) {
+			hasChange = true
+		}
+	}
+	if !hasChange {
+		return nil
+	}
+
+	zone := ""
+	if zoneField != "" {
+		zone = diff.Get(zoneField).(string)
+	}
+
+	if err := ValidateSkuAvailability(resourceType, diff.Get(skuField).(string), diff.Get("location").(string), zone); err != nil {
+		return fmt.Errorf("validating `%s`: %+v", skuField, err)
+	}
+
+	return nil
+}
+
+func containsString(input []string, value string) bool {
+	for _, v := range input {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
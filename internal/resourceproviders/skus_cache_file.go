@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+)
+
+// skuCacheDuration is how long the SKUs persisted to disk are used for before being retrieved again
+const skuCacheDuration = 24 * time.Hour
+
+// skuCacheFile is the format used both for the SKUs persisted to disk, and for the fixture files used in offline mode
+type skuCacheFile struct {
+	SubscriptionId string            `json:"subscriptionId"`
+	RetrievedAt    time.Time         `json:"retrievedAt"`
+	Skus           []SkuAvailability `json:"skus"`
+}
+
+func (f skuCacheFile) expired() bool {
+	return time.Since(f.RetrievedAt) > skuCacheDuration
+}
+
+// skuCachePath returns the path to the file used to persist the SKUs for this Subscription, which can be overridden
+// using the Environment Variable `ARM_PROVIDER_SKU_CACHE_PATH`
+func skuCachePath(subscriptionId commonids.SubscriptionId) (string, error) {
+	if v := os.Getenv("ARM_PROVIDER_SKU_CACHE_PATH"); v != "" {
+		return v, nil
+	}
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(cacheDir, "terraform-provider-azurerm", fmt.Sprintf("skus-%s.json", subscriptionId.SubscriptionId)), nil
+}
+
+func readSkuCacheFile(path string) (*skuCacheFile, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file skuCacheFile
+	if err := json.Unmarshal(contents, &file); err != nil {
+		return nil, fmt.Errorf("unmarshaling %q: %+v", path, err)
+	}
+
+	return &file, nil
+}
+
+func writeSkuCacheFile(path string, subscriptionId commonids.SubscriptionId, input []SkuAvailability) error {
+	file := skuCacheFile{
+		SubscriptionId: subscriptionId.SubscriptionId,
+		RetrievedAt:    time.Now().UTC(),
+		Skus:           input,
+	}
+	contents, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("marshaling: %+v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("creating directory: %+v", err)
+	}
+
+	// write to a temporary file and then rename it, so that concurrent runs don't read a partially written file
+	tempFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temporary file: %+v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(contents); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("writing temporary file: %+v", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("closing temporary file: %+v", err)
+	}
+
+	return os.Rename(tempFile.Name(), path)
+}
@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/skus"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+func TestValidateSkuAvailability(t *testing.T) {
+	t.Setenv("ARM_PROVIDER_SKU_CACHE_FIXTURE", filepath.Join("testdata", "skus.json"))
+	enhancedSkuValidationEnabled = true
+	defer func() {
+		enhancedSkuValidationEnabled = features.EnhancedSkuValidationEnabled()
+		ClearSkuCache()
+	}()
+
+	// the API clients shouldn't be used when a fixture is specified
+	if err := CacheSupportedSkus(context.TODO(), nil, nil, commonids.NewSubscriptionID("00000000-0000-0000-0000-000000000000")); err != nil {
+		t.Fatalf("caching SKUs: %+v", err)
+	}
+
+	testCases := []struct {
+		resourceType SkuResourceType
+		name         string
+		location     string
+		zone         string
+		expectedErr  string
+	}{
+		{
+			resourceType: SkuResourceTypeVirtualMachines,
+			name:         "Standard_D2s_v3",
+			location:     "West Europe",
+			zone:         "1",
+		},
+		{
+			resourceType: SkuResourceTypeVirtualMachines,
+			name:         "standard_d2s_v3",
+			location:     "northeurope",
+		},
+		{
+			resourceType: SkuResourceTypeVirtualMachines,
+			name:         "Standard_D2s_v3",
+			location:     "westeurope",
+			zone:         "3",
+			expectedErr:  "available in the Availability Zones: 1, 2",
+		},
+		{
+			resourceType: SkuResourceTypeVirtualMachines,
+			name:         "Standard_D2s_v3",
+			location:     "westus",
+			expectedErr:  "(NotAvailableForSubscription) - this SKU is available in the Locations: northeurope, westeurope",
+		},
+		{
+			resourceType: SkuResourceTypeVirtualMachines,
+			name:         "Standard_D2s_v3",
+			location:     "eastus",
+			expectedErr:  "not available to this Subscription in the Location \"eastus\"",
+		},
+		{
+			resourceType: SkuResourceTypeVirtualMachines,
+			name:         "Standard_Unknown",
+			location:     "westeurope",
+			expectedErr:  "in any Location",
+		},
+		{
+			resourceType: SkuResourceTypeDisks,
+			name:         "PremiumV2_LRS",
+			location:     "westeurope",
+			zone:         "2",
+		},
+		{
+			resourceType: SkuResourceTypeServerFarms,
+			name:         "P1v3",
+			location:     "eastus",
+			expectedErr:  "available in the Locations: northeurope, westeurope",
+		},
+		{
+			// Resource Types which aren't cached aren't validated
+			resourceType: SkuResourceType("containerGroups"),
+			name:         "Standard",
+			location:     "eastus",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Testing %q / %q in %q (zone %q)..", testCase.resourceType, testCase.name, testCase.location, testCase.zone)
+
+		err := ValidateSkuAvailability(testCase.resourceType, testCase.name, testCase.location, testCase.zone)
+		if testCase.expectedErr == "" {
+			if err != nil {
+				t.Fatalf("expected no error but got: %+v", err)
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), testCase.expectedErr) {
+			t.Fatalf("expected an error containing %q but got: %+v", testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestValidateSkuAvailabilityDisabled(t *testing.T) {
+	setCachedSkus([]SkuAvailability{
+		{
+			ResourceType: SkuResourceTypeVirtualMachines,
+			Name:         "Standard_D2s_v3",
+		},
+	})
+	enhancedSkuValidationEnabled = false
+	defer func() {
+		enhancedSkuValidationEnabled = features.EnhancedSkuValidationEnabled()
+		ClearSkuCache()
+	}()
+
+	if err := ValidateSkuAvailability(SkuResourceTypeVirtualMachines, "Standard_D2s_v3", "westeurope", ""); err != nil {
+		t.Fatalf("expected no error when Enhanced SKU Validation is disabled but got: %+v", err)
+	}
+}
+
+func TestSkuCacheFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "skus.json")
+	subscriptionId := commonids.NewSubscriptionID("00000000-0000-0000-0000-000000000000")
+
+	input := []SkuAvailability{
+		flattenComputeSku(skus.ResourceSku{
+			Name:         pointer.To("Standard_D2s_v3"),
+			ResourceType: pointer.To("virtualMachines"),
+			Locations:    pointer.To([]string{"West Europe"}),
+			LocationInfo: pointer.To([]skus.ResourceSkuLocationInfo{
+				{
+					Location: pointer.To("West Europe"),
+					Zones:    pointer.To([]string{"1", "2", "3"}),
+				},
+			}),
+			Restrictions: pointer.To([]skus.ResourceSkuRestrictions{
+				{
+					Type:       pointer.To(skus.ResourceSkuRestrictionsTypeZone),
+					ReasonCode: pointer.To(skus.ResourceSkuRestrictionsReasonCodeNotAvailableForSubscription),
+					RestrictionInfo: &skus.ResourceSkuRestrictionInfo{
+						Locations: pointer.To([]string{"westeurope"}),
+						Zones:     pointer.To([]string{"2"}),
+					},
+				},
+			}),
+		}),
+	}
+
+	if err := writeSkuCacheFile(path, subscriptionId, input); err != nil {
+		t.Fatalf("writing SKU cache file: %+v", err)
+	}
+
+	file, err := readSkuCacheFile(path)
+	if err != nil {
+		t.Fatalf("reading SKU cache file: %+v", err)
+	}
+	if file.expired() {
+		t.Fatalf("expected a newly written SKU cache file not to be expired")
+	}
+	if file.SubscriptionId != subscriptionId.SubscriptionId || len(file.Skus) != 1 {
+		t.Fatalf("unexpected SKU cache file: %+v", file)
+	}
+
+	locations := file.Skus[0].Locations
+	if len(locations) != 1 || locations[0].Location != "westeurope" || len(locations[0].Zones) != 3 || len(locations[0].RestrictedZones) != 1 {
+		t.Fatalf("unexpected Locations: %+v", locations)
+	}
+}
@@ -99,7 +99,7 @@ type StateUpgradeData struct {
 	Upgraders     map[int]pluginsdk.StateUpgrade
 }
 
-// TODO: a generic state migration for updating ID's
+// NOTE: State Upgrades which only update the format of the Resource ID can use `NewIDStateUpgrade`
 
 type ResourceWithCustomImporter interface {
 	Resource
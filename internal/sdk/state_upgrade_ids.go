@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// ResourceIDParserFunc parses the Resource ID in `input` - which can be in a legacy format (for example using
+// different casing, such as `resourcegroups` rather than `resourceGroups`) - into the current Resource ID type
+type ResourceIDParserFunc func(input string) (resourceids.ResourceId, error)
+
+// ParseResourceIDInsensitively returns a ResourceIDParserFunc which parses the Resource ID in `input` insensitively
+// into a new instance of the same type as `id` - which must be a pointer to a Resource ID type, for example
+// `&virtualmachines.VirtualMachineId{}`. This allows Resource IDs with legacy casing to be parsed.
+func ParseResourceIDInsensitively(id resourceids.ResourceId) ResourceIDParserFunc {
+	idType := reflect.TypeOf(id)
+	if idType.Kind() != reflect.Pointer {
+		panic(fmt.Sprintf("ParseResourceIDInsensitively requires a pointer to a Resource ID but got %T", id))
+	}
+
+	return func(input string) (resourceids.ResourceId, error) {
+		output := reflect.New(idType.Elem()).Interface().(resourceids.ResourceId)
+		parsed, err := resourceids.NewParserFromResourceIdType(output).Parse(input, true)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %q: %+v", input, err)
+		}
+		if err := output.FromParseResult(*parsed); err != nil {
+			return nil, err
+		}
+
+		return output, nil
+	}
+}
+
+var _ pluginsdk.StateUpgrade = IDStateUpgrade{}
+
+// IDStateUpgrade is a State Upgrade which rewrites the Resource ID in `id` (and optionally, other fields containing
+// Resource IDs) into the current format of the Resource ID - which is commonly needed when the casing of a Resource
+// ID changes, for example when switching to a Resource ID from `hashicorp/go-azure-sdk`.
+//
+// The `id` field is parsed insensitively using the Resource ID type by default:
+//
+//	func (s ServiceV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
+//		return sdk.NewIDStateUpgrade(s.Schema(), &communicationservices.CommunicationServiceId{}).UpgradeFunc()
+//	}
+//
+// When the format of the Resource ID has changed, `WithLegacyIDParser` can be used to convert the legacy Resource ID
+// into the current Resource ID - and fields referencing other Resources can be rewritten using `WithIDField`.
+type IDStateUpgrade struct {
+	schema   map[string]*pluginsdk.Schema
+	idParser ResourceIDParserFunc
+	fields   map[string]ResourceIDParserFunc
+}
+
+// NewIDStateUpgrade returns an IDStateUpgrade for the point-in-time Schema specified in `schema`, which rewrites the
+// `id` field by parsing it insensitively into the Resource ID type of `id` (which must be a pointer)
+func NewIDStateUpgrade(schema map[string]*pluginsdk.Schema, id resourceids.ResourceId) IDStateUpgrade {
+	return IDStateUpgrade{
+		schema:   schema,
+		idParser: ParseResourceIDInsensitively(id),
+		fields:   make(map[string]ResourceIDParserFunc),
+	}
+}
+
+// WithLegacyIDParser overrides the parser used for the `id` field, for when the format of the Resource ID has changed
+func (u IDStateUpgrade) WithLegacyIDParser(parser ResourceIDParserFunc) IDStateUpgrade {
+	u.idParser = parser
+	return u
+}
+
+// WithIDField rewrites the top-level field `field` by parsing it insensitively into the Resource ID type of `id`.
+// This supports both String fields and List/Set fields containing Strings, empty values are left as-is.
+func (u IDStateUpgrade) WithIDField(field string, id resourceids.ResourceId) IDStateUpgrade {
+	return u.WithLegacyIDField(field, ParseResourceIDInsensitively(id))
+}
+
+// WithLegacyIDField rewrites the top-level field `field` using the parser specified in `parser`
+func (u IDStateUpgrade) WithLegacyIDField(field string, parser ResourceIDParserFunc) IDStateUpgrade {
+	fields := make(map[string]ResourceIDParserFunc, len(u.fields)+1)
+	for k, v := range u.fields {
+		fields[k] = v
+	}
+	fields[field] = parser
+	u.fields = fields
+	return u
+}
+
+func (u IDStateUpgrade) Schema() map[string]*pluginsdk.Schema {
+	return u.schema
+}
+
+func (u IDStateUpgrade) UpgradeFunc() pluginsdk.StateUpgraderFunc {
+	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		oldId, ok := rawState["id"].(string)
+		if !ok {
+			return nil, fmt.Errorf("expected `id` to be a string but got %T", rawState["id"])
+		}
+
+		newId, err := u.idParser(oldId)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the existing ID %q: %+v", oldId, err)
+		}
+		log.Printf("[DEBUG] Updating ID from %q to %q", oldId, newId.ID())
+		rawState["id"] = newId.ID()
+
+		fieldNames := make([]string, 0, len(u.fields))
+		for field := range u.fields {
+			fieldNames = append(fieldNames, field)
+		}
+		sort.Strings(fieldNames)
+
+		for _, field := range fieldNames {
+			updated, err := upgradeIDField(rawState[field], u.fields[field])
+			if err != nil {
+				return nil, fmt.Errorf("updating `%s`: %+v", field, err)
+			}
+			if updated != nil {
+				log.Printf("[DEBUG] Updating `%s` from %v to %v", field, rawState[field], updated)
+				rawState[field] = updated
+			}
+		}
+
+		return rawState, nil
+	}
+}
+
+// upgradeIDField returns the updated value for a field containing either a single Resource ID, or a list of
+// Resource IDs - nil is returned when the field isn't set
+func upgradeIDField(input interface{}, parser ResourceIDParserFunc) (interface{}, error) {
+	switch v := input.(type) {
+	case string:
+		if v == "" {
+			return nil, nil
+		}
+		id, err := parser(v)
+		if err != nil {
+			return nil, err
+		}
+		return id.ID(), nil
+
+	case []interface{}:
+		output := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			value, ok := item.(string)
+			if !ok || value == "" {
+				output = append(output, item)
+				continue
+			}
+
+			id, err := parser(value)
+			if err != nil {
+				return nil, err
+			}
+			output = append(output, id.ID())
+		}
+		return output, nil
+
+	case nil:
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("expected a string or a list of strings but got %T", input)
+}
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestIDStateUpgrade(t *testing.T) {
+	schema := map[string]*pluginsdk.Schema{
+		"resource_group_id": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+		"subnet_ids": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+		"optional_subnet_id": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+	}
+
+	upgrade := NewIDStateUpgrade(schema, &commonids.VirtualNetworkId{}).
+		WithIDField("resource_group_id", &commonids.ResourceGroupId{}).
+		WithIDField("subnet_ids", &commonids.SubnetId{}).
+		WithIDField("optional_subnet_id", &commonids.SubnetId{})
+
+	if !reflect.DeepEqual(upgrade.Schema(), schema) {
+		t.Fatalf("expected the Schema to be returned as-is")
+	}
+
+	input := map[string]interface{}{
+		"id":                "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/example/providers/microsoft.network/virtualnetworks/network1",
+		"resource_group_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/example",
+		"subnet_ids": []interface{}{
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/example/providers/Microsoft.Network/virtualNetworks/network1/SUBNETS/subnet1",
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet2",
+		},
+		"optional_subnet_id": "",
+		"name":               "network1",
+	}
+	expected := map[string]interface{}{
+		"id":                "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/network1",
+		"resource_group_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+		"subnet_ids": []interface{}{
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1",
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet2",
+		},
+		"optional_subnet_id": "",
+		"name":               "network1",
+	}
+
+	actual, err := upgrade.UpgradeFunc()(context.TODO(), input, nil)
+	if err != nil {
+		t.Fatalf("upgrading state: %+v", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestIDStateUpgradeWithLegacyIDParser(t *testing.T) {
+	// e.g. a Resource ID which was previously a Resource Group ID with a suffix
+	upgrade := NewIDStateUpgrade(map[string]*pluginsdk.Schema{}, &commonids.ResourceGroupId{}).
+		WithLegacyIDParser(func(input string) (resourceids.ResourceId, error) {
+			return ParseResourceIDInsensitively(&commonids.ResourceGroupId{})(strings.TrimSuffix(input, "|legacy"))
+		})
+
+	actual, err := upgrade.UpgradeFunc()(context.TODO(), map[string]interface{}{
+		"id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/example|legacy",
+	}, nil)
+	if err != nil {
+		t.Fatalf("upgrading state: %+v", err)
+	}
+
+	expected := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example"
+	if actual["id"] != expected {
+		t.Fatalf("expected the ID to be %q but got %q", expected, actual["id"])
+	}
+}
+
+func TestIDStateUpgradeInvalidID(t *testing.T) {
+	upgrade := NewIDStateUpgrade(map[string]*pluginsdk.Schema{}, &commonids.ResourceGroupId{})
+
+	if _, err := upgrade.UpgradeFunc()(context.TODO(), map[string]interface{}{"id": "/subscriptions/00000000-0000-0000-0000-000000000000"}, nil); err == nil {
+		t.Fatalf("expected an error for an invalid ID but didn't get one")
+	}
+}
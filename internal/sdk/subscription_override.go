@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+// ResourceWithSubscriptionOverride is an optional interface
+//
+// Resources implementing this interface support a top-level `subscription_id` argument, which allows the Resource to
+// be managed in a Subscription other than the one the Provider is configured to use - without needing a Provider alias
+// for each Subscription. The Resource ID for these Resources must be scoped to a Subscription, since the Subscription
+// is determined from the Resource ID once the Resource exists.
+type ResourceWithSubscriptionOverride interface {
+	Resource
+
+	// SupportsSubscriptionOverride is a marker, which should return true
+	SupportsSubscriptionOverride() bool
+}
+
+const subscriptionOverrideField = "subscription_id"
+
+// SubscriptionOverrideSchema returns the Schema for the top-level `subscription_id` argument
+func SubscriptionOverrideSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:         pluginsdk.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.IsUUID,
+		Description:  "The ID of the Subscription where this Resource should exist. Defaults to the Subscription the Provider is configured to use.",
+	}
+}
+
+// WithSubscriptionOverride adds the top-level `subscription_id` argument to the Resource, and wraps the CRUD and
+// Import functions so that they're called with a Client targeting the relevant Subscription - which is either the
+// Subscription within the Resource ID (once the Resource exists) or the `subscription_id` specified in the configuration.
+//
+// This works for both Typed and Untyped Resources, since the Client is swapped out for the one targeting the relevant
+// Subscription - as such the Resource can continue to use `Account.SubscriptionId` and the Clients as usual.
+func WithSubscriptionOverride(resource *pluginsdk.Resource) *pluginsdk.Resource {
+	if _, exists := resource.Schema[subscriptionOverrideField]; exists {
+		panic(fmt.Sprintf("the Resource already contains a %q field", subscriptionOverrideField))
+	}
+	resource.Schema[subscriptionOverrideField] = SubscriptionOverrideSchema()
+
+	// Create/Update use the configured subscription, whereas Read/Delete only have the Resource ID
+	if v := resource.Create; v != nil { //nolint:staticcheck
+		resource.Create = subscriptionOverrideFunc(v) //nolint:staticcheck
+	}
+	if v := resource.CreateContext; v != nil {
+		resource.CreateContext = subscriptionOverrideContextFunc(v)
+	}
+	if v := resource.Read; v != nil { //nolint:staticcheck
+		resource.Read = subscriptionOverrideFunc(v) //nolint:staticcheck
+	}
+	if v := resource.ReadContext; v != nil {
+		resource.ReadContext = subscriptionOverrideContextFunc(v)
+	}
+	if v := resource.Update; v != nil { //nolint:staticcheck
+		resource.Update = subscriptionOverrideFunc(v) //nolint:staticcheck
+	}
+	if v := resource.UpdateContext; v != nil {
+		resource.UpdateContext = subscriptionOverrideContextFunc(v)
+	}
+	if v := resource.Delete; v != nil { //nolint:staticcheck
+		resource.Delete = subscriptionOverrideFunc(v) //nolint:staticcheck
+	}
+	if v := resource.DeleteContext; v != nil {
+		resource.DeleteContext = subscriptionOverrideContextFunc(v)
+	}
+
+	if resource.Importer != nil && resource.Importer.StateContext != nil {
+		importer := resource.Importer.StateContext
+		resource.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			client, err := clientForSubscriptionOverride(ctx, d, meta)
+			if err != nil {
+				return nil, err
+			}
+			return importer(ctx, d, client)
+		}
+	}
+
+	return resource
+}
+
+func subscriptionOverrideFunc(in func(d *schema.ResourceData, meta interface{}) error) func(d *schema.ResourceData, meta interface{}) error {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		client, err := clientForSubscriptionOverride(meta.(*clients.Client).StopContext, d, meta)
+		if err != nil {
+			return err
+		}
+
+		if err := in(d, client); err != nil {
+			return err
+		}
+
+		return setSubscriptionOverride(d)
+	}
+}
+
+func subscriptionOverrideContextFunc(in func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client, err := clientForSubscriptionOverride(ctx, d, meta)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if diags := in(ctx, d, client); diags.HasError() {
+			return diags
+		}
+
+		return diag.FromErr(setSubscriptionOverride(d))
+	}
+}
+
+// clientForSubscriptionOverride returns the Client for the Subscription this Resource exists in (or should exist in)
+func clientForSubscriptionOverride(ctx context.Context, d *schema.ResourceData, meta interface{}) (*clients.Client, error) {
+	client := meta.(*clients.Client)
+
+	subscriptionId := subscriptionIdFromResourceId(d.Id())
+	if subscriptionId == "" {
+		subscriptionId = d.Get(subscriptionOverrideField).(string)
+	}
+
+	return client.ForSubscription(ctx, subscriptionId)
+}
+
+// setSubscriptionOverride sets the `subscription_id` into the state from the Resource ID, once the Resource exists
+func setSubscriptionOverride(d *schema.ResourceData) error {
+	if subscriptionId := subscriptionIdFromResourceId(d.Id()); subscriptionId != "" {
+		return d.Set(subscriptionOverrideField, subscriptionId)
+	}
+	return nil
+}
+
+// subscriptionIdFromResourceId returns the Subscription ID from a Subscription-scoped Resource ID, or an empty
+// string when the Resource ID isn't scoped to a Subscription
+func subscriptionIdFromResourceId(input string) string {
+	segments := strings.Split(strings.TrimPrefix(input, "/"), "/")
+	if len(segments) < 2 || !strings.EqualFold(segments[0], "subscriptions") {
+		return ""
+	}
+	return segments[1]
+}
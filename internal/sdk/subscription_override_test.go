@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestSubscriptionIdFromResourceId(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "",
+			expected: "",
+		},
+		{
+			input:    "/subscriptions",
+			expected: "",
+		},
+		{
+			input:    "/subscriptions/12345678-1234-9876-4563-123456789012",
+			expected: "12345678-1234-9876-4563-123456789012",
+		},
+		{
+			input:    "/Subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example",
+			expected: "12345678-1234-9876-4563-123456789012",
+		},
+		{
+			input:    "/providers/Microsoft.Management/managementGroups/example",
+			expected: "",
+		},
+	}
+
+	for _, v := range testCases {
+		t.Logf("[DEBUG] Testing %q", v.input)
+		if actual := subscriptionIdFromResourceId(v.input); actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
+
+func TestWithSubscriptionOverrideAddsSchema(t *testing.T) {
+	resource := WithSubscriptionOverride(&pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+			},
+		},
+	})
+
+	v, ok := resource.Schema["subscription_id"]
+	if !ok {
+		t.Fatalf("expected `subscription_id` to be added to the schema")
+	}
+	if !v.Optional || !v.Computed || !v.ForceNew {
+		t.Fatalf("expected `subscription_id` to be Optional, Computed and ForceNew")
+	}
+}
@@ -179,6 +179,10 @@ and we recommend using the %[2]q resource instead.
 	}
 	// TODO: State Migrations
 
+	if v, ok := rw.resource.(ResourceWithSubscriptionOverride); ok && v.SupportsSubscriptionOverride() {
+		return WithSubscriptionOverride(&resource), nil
+	}
+
 	return &resource, nil
 }
 
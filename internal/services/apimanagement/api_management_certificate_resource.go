@@ -4,6 +4,7 @@
 package apimanagement
 
 import (
+	"encoding/base64"
 	"fmt"
 	"log"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/apimanagement/schemaz"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/certificatebundle"
 	keyVaultParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
 	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -23,7 +25,7 @@ import (
 )
 
 func resourceApiManagementCertificate() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
+	return certificatebundle.WithExpiryWarnings(&pluginsdk.Resource{
 		Create: resourceApiManagementCertificateCreateUpdate,
 		Read:   resourceApiManagementCertificateRead,
 		Update: resourceApiManagementCertificateCreateUpdate,
@@ -93,7 +95,7 @@ func resourceApiManagementCertificate() *pluginsdk.Resource {
 				Computed: true,
 			},
 		},
-	}
+	}, "data", "password")
 }
 
 func resourceApiManagementCertificateCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
@@ -142,7 +144,11 @@ func resourceApiManagementCertificateCreateUpdate(d *pluginsdk.ResourceData, met
 	}
 
 	if data != "" {
-		parameters.Properties.Data = pointer.To(data)
+		pfx, err := certificatebundle.NormaliseToPkcs12(data, password)
+		if err != nil {
+			return fmt.Errorf("validating `data`: %+v", err)
+		}
+		parameters.Properties.Data = pointer.To(base64.StdEncoding.EncodeToString(pfx))
 		parameters.Properties.Password = pointer.To(password)
 	}
 
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/web/2023-01-01/appserviceplans"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/appservice/helpers"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/appservice/migration"
@@ -32,6 +33,8 @@ var _ sdk.ResourceWithUpdate = ServicePlanResource{}
 
 var _ sdk.ResourceWithStateMigration = ServicePlanResource{}
 
+var _ sdk.ResourceWithCustomizeDiff = ServicePlanResource{}
+
 type OSType string
 
 const (
@@ -368,6 +371,15 @@ func isServicePlanSupportScaleOut(plan string) bool {
 	return support
 }
 
+func (r ServicePlanResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			return resourceproviders.ValidateSkuAvailabilityForDiff(metadata.ResourceDiff, resourceproviders.SkuResourceTypeServerFarms, "sku_name", "")
+		},
+	}
+}
+
 func (r ServicePlanResource) StateUpgraders() sdk.StateUpgradeData {
 	return sdk.StateUpgradeData{
 		SchemaVersion: 1,
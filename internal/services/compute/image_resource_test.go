@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sync"
 	"testing"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	networkParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/network/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	cryptossh "golang.org/x/crypto/ssh"
 )
 
 type ImageResource struct{}
@@ -203,7 +205,60 @@ func (ImageResource) Exists(ctx context.Context, clients *clients.Client, state
 	return utils.Bool(resp.Model != nil), nil
 }
 
-func (ImageResource) generalizeVirtualMachine(data acceptance.TestData) func(context.Context, *clients.Client, *pluginsdk.InstanceState) error {
+// imageTestKeyPairs contains the SSH Key Pair used to authenticate to the source Virtual Machine for each test,
+// keyed by the random integer for the test so that the configurations and checks within a test use the same one
+var imageTestKeyPairs sync.Map
+
+func (ImageResource) keyPair(data acceptance.TestData) *ssh.KeyPair {
+	if v, ok := imageTestKeyPairs.Load(data.RandomInteger); ok {
+		return v.(*ssh.KeyPair)
+	}
+
+	keyPair, err := ssh.GenerateKeyPair()
+	if err != nil {
+		panic(fmt.Sprintf("generating an SSH Key Pair: %+v", err))
+	}
+
+	v, _ := imageTestKeyPairs.LoadOrStore(data.RandomInteger, keyPair)
+	return v.(*ssh.KeyPair)
+}
+
+// generalizeVirtualMachine generalizes a Virtual Machine which uses the Key Pair from `keyPair` for authentication
+// and has Boot Diagnostics enabled, so that its Host Keys can be verified
+func (r ImageResource) generalizeVirtualMachine(data acceptance.TestData) func(context.Context, *clients.Client, *pluginsdk.InstanceState) error {
+	return r.generalizeVirtualMachineUsing(data, func(ctx context.Context, client *clients.Client, id virtualmachines.VirtualMachineId, runner *ssh.Runner) error {
+		// the Host Keys are written to the Serial Console Log once cloud-init has run, which can take a short while
+		// after the Virtual Machine has been provisioned
+		var hostKeys []cryptossh.PublicKey
+		err := pluginsdk.Retry(5*time.Minute, func() *pluginsdk.RetryError {
+			keys, err := ssh.HostKeysFromBootDiagnostics(ctx, client, id)
+			if err != nil {
+				return pluginsdk.RetryableError(err)
+			}
+			hostKeys = keys
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("retrieving the Host Keys for %s: %+v", id, err)
+		}
+
+		runner.PrivateKey = r.keyPair(data).PrivateKey
+		runner.HostKeys = hostKeys
+		return nil
+	})
+}
+
+// generalizeVirtualMachineUsingPassword generalizes a Virtual Machine which uses `Password1234!{randomInteger}` for authentication,
+// the Host Keys for these Virtual Machines aren't available since Boot Diagnostics isn't enabled
+func (r ImageResource) generalizeVirtualMachineUsingPassword(data acceptance.TestData) func(context.Context, *clients.Client, *pluginsdk.InstanceState) error {
+	return r.generalizeVirtualMachineUsing(data, func(_ context.Context, _ *clients.Client, _ virtualmachines.VirtualMachineId, runner *ssh.Runner) error {
+		runner.Password = fmt.Sprintf("Password1234!%d", data.RandomInteger)
+		runner.InsecureIgnoreHostKey = true
+		return nil
+	})
+}
+
+func (ImageResource) generalizeVirtualMachineUsing(data acceptance.TestData, authenticate func(context.Context, *clients.Client, virtualmachines.VirtualMachineId, *ssh.Runner) error) func(context.Context, *clients.Client, *pluginsdk.InstanceState) error {
 	return func(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) error {
 		id, err := virtualmachines.ParseVirtualMachineID(state.ID)
 		if err != nil {
@@ -216,9 +271,8 @@ func (ImageResource) generalizeVirtualMachine(data acceptance.TestData) func(con
 			defer cancel()
 		}
 
-		// these are nested in a Set in the Legacy VM resource, simpler to compute them
+		// this is nested in a Set in the Legacy VM resource, simpler to compute it
 		userName := fmt.Sprintf("testadmin%d", data.RandomInteger)
-		password := fmt.Sprintf("Password1234!%d", data.RandomInteger)
 
 		// first retrieve the Virtual Machine, since we need to find
 		nicIdRaw := state.Attributes["network_interface_ids.0"]
@@ -285,11 +339,13 @@ func (ImageResource) generalizeVirtualMachine(data acceptance.TestData) func(con
 			Hostname: fqdn,
 			Port:     22,
 			Username: userName,
-			Password: password,
 			CommandsToRun: []string{
 				ssh.LinuxAgentDeprovisionCommand,
 			},
 		}
+		if err := authenticate(ctx, client, *id, &sshGeneralizationCommand); err != nil {
+			return err
+		}
 		if err := sshGeneralizationCommand.Run(ctx); err != nil {
 			return fmt.Errorf("Bad: running generalization command: %+v", err)
 		}
@@ -375,6 +431,14 @@ resource "azurerm_network_interface" "testsource" {
   }
 }
 
+resource "azurerm_storage_account" "test" {
+  name                     = "accsa${local.random_string}"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
 resource "azurerm_virtual_machine" "testsource" {
   name                  = "testsource"
   location              = azurerm_resource_group.test.location
@@ -400,11 +464,20 @@ resource "azurerm_virtual_machine" "testsource" {
   os_profile {
     computer_name  = "mdimagetestsource"
     admin_username = local.admin_username
-    admin_password = local.admin_password
   }
 
   os_profile_linux_config {
-    disable_password_authentication = false
+    disable_password_authentication = true
+
+    ssh_keys {
+      path     = "/home/${local.admin_username}/.ssh/authorized_keys"
+      key_data = local.admin_public_key
+    }
+  }
+
+  boot_diagnostics {
+    enabled     = true
+    storage_uri = azurerm_storage_account.test.primary_blob_endpoint
   }
 
   tags = {
@@ -488,11 +561,20 @@ resource "azurerm_virtual_machine" "testsource" {
   os_profile {
     computer_name  = "mdimagetestsource"
     admin_username = local.admin_username
-    admin_password = local.admin_password
   }
 
   os_profile_linux_config {
-    disable_password_authentication = false
+    disable_password_authentication = true
+
+    ssh_keys {
+      path     = "/home/${local.admin_username}/.ssh/authorized_keys"
+      key_data = local.admin_public_key
+    }
+  }
+
+  boot_diagnostics {
+    enabled     = true
+    storage_uri = azurerm_storage_account.test.primary_blob_endpoint
   }
 
   tags = {
@@ -877,7 +959,7 @@ resource "azurerm_image" "test" {
 `, template, data.RandomInteger, data.RandomString)
 }
 
-func (ImageResource) template(data acceptance.TestData) string {
+func (r ImageResource) template(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 locals {
   number            = "%d"
@@ -886,6 +968,7 @@ locals {
   random_string     = %q
   admin_username    = "testadmin%d"
   admin_password    = "Password1234!%d"
+  admin_public_key  = %q
 }
 
 resource "azurerm_resource_group" "test" {
@@ -914,5 +997,5 @@ resource "azurerm_public_ip" "test" {
   allocation_method   = "Dynamic"
   domain_name_label   = local.domain_name_label
 }
-`, data.RandomInteger, data.Locations.Primary, data.RandomString, data.RandomString, data.RandomInteger, data.RandomInteger)
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, data.RandomString, data.RandomInteger, data.RandomInteger, r.keyPair(data).PublicKey)
 }
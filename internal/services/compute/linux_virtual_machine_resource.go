@@ -4,6 +4,7 @@
 package compute
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
@@ -26,6 +27,8 @@ import (
 	azValidate "github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/quota"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
 	computeValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
 	networkValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/network/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/base64"
@@ -37,7 +40,7 @@ import (
 )
 
 func resourceLinuxVirtualMachine() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
+	return quota.WithWarnings(&pluginsdk.Resource{
 		Create: resourceLinuxVirtualMachineCreate,
 		Read:   resourceLinuxVirtualMachineRead,
 		Update: resourceLinuxVirtualMachineUpdate,
@@ -410,7 +413,14 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 				Computed: true,
 			},
 		},
-	}
+
+		CustomizeDiff: pluginsdk.CustomDiffWithAll(
+			func(ctx context.Context, diff *pluginsdk.ResourceDiff, v interface{}) error {
+				return resourceproviders.ValidateSkuAvailabilityForDiff(diff, resourceproviders.SkuResourceTypeVirtualMachines, "size", "zone")
+			},
+			virtualMachineQuotaPreflight("azurerm_linux_virtual_machine", "size", ""),
+		),
+	}, virtualMachineQuotaKey("azurerm_linux_virtual_machine"))
 }
 
 func resourceLinuxVirtualMachineCreate(d *pluginsdk.ResourceData, meta interface{}) error {
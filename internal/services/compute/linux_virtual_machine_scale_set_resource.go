@@ -26,6 +26,7 @@ import (
 	azValidate "github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/quota"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/base64"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -36,7 +37,7 @@ import (
 )
 
 func resourceLinuxVirtualMachineScaleSet() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
+	return quota.WithWarnings(&pluginsdk.Resource{
 		Create: resourceLinuxVirtualMachineScaleSetCreate,
 		Read:   resourceLinuxVirtualMachineScaleSetRead,
 		Update: resourceLinuxVirtualMachineScaleSetUpdate,
@@ -58,7 +59,9 @@ func resourceLinuxVirtualMachineScaleSet() *pluginsdk.Resource {
 		// https://github.com/Azure/azure-rest-api-specs/pull/7246
 
 		Schema: resourceLinuxVirtualMachineScaleSetSchema(),
-	}
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(virtualMachineQuotaPreflight("azurerm_linux_virtual_machine_scale_set", "sku", "instances")),
+	}, virtualMachineQuotaKey("azurerm_linux_virtual_machine_scale_set"))
 }
 
 func resourceLinuxVirtualMachineScaleSetCreate(d *pluginsdk.ResourceData, meta interface{}) error {
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachines"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/quota"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// virtualMachineQuotaPreflight returns a CustomizeDiff function which checks the vCPU Quota is sufficient for the
+// Virtual Machine Size in `sizeField` (and when `instancesField` is specified, the number of instances)
+func virtualMachineQuotaPreflight(resourceType, sizeField, instancesField string) pluginsdk.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *pluginsdk.ResourceDiff, meta interface{}) error {
+		fields := []string{"location", "priority", sizeField}
+		if instancesField != "" {
+			fields = append(fields, instancesField)
+		}
+
+		hasChange := diff.Id() == ""
+		for _, field := range fields {
+			if !diff.NewValueKnown(field) {
+				return nil
+			}
+			if diff.HasChange(field) {
+				hasChange = true
+			}
+		}
+		if !hasChange {
+			return nil
+		}
+
+		key, err := virtualMachineQuotaKey(resourceType)(diff, meta)
+		if err != nil {
+			return err
+		}
+
+		oldSize, newSize := diff.GetChange(sizeField)
+		request := quota.ComputeRequest{
+			Key:      key,
+			Location: diff.Get("location").(string),
+			Size:     newSize.(string),
+			Count:    1,
+			Spot:     strings.EqualFold(diff.Get("priority").(string), string(virtualmachines.VirtualMachinePriorityTypesSpot)),
+		}
+
+		if instancesField != "" {
+			oldInstances, newInstances := diff.GetChange(instancesField)
+			request.Count = int64(newInstances.(int))
+			if diff.Id() != "" {
+				request.PreviousCount = int64(oldInstances.(int))
+			}
+		} else if diff.Id() != "" {
+			request.PreviousCount = 1
+		}
+		if diff.Id() != "" {
+			request.PreviousSize = oldSize.(string)
+		}
+
+		return quota.CheckCompute(ctx, meta.(*clients.Client), request)
+	}
+}
+
+// virtualMachineQuotaKey returns the key used to identify the Virtual Machine (Scale Set) within the Quota Pre-flight
+// checks, which is the Resource ID once it exists
+func virtualMachineQuotaKey(resourceType string) quota.ResourceKeyFunc {
+	return func(d quota.ResourceGetter, _ interface{}) (string, error) {
+		if id := d.Id(); id != "" {
+			return id, nil
+		}
+		return fmt.Sprintf("%s/%s/%s", resourceType, d.Get("resource_group_name").(string), d.Get("name").(string)), nil
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/quota"
 	computeValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/base64"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -35,7 +36,7 @@ import (
 )
 
 func resourceWindowsVirtualMachineScaleSet() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
+	return quota.WithWarnings(&pluginsdk.Resource{
 		Create: resourceWindowsVirtualMachineScaleSetCreate,
 		Read:   resourceWindowsVirtualMachineScaleSetRead,
 		Update: resourceWindowsVirtualMachineScaleSetUpdate,
@@ -57,7 +58,9 @@ func resourceWindowsVirtualMachineScaleSet() *pluginsdk.Resource {
 		// https://github.com/Azure/azure-rest-api-specs/pull/7246
 
 		Schema: resourceWindowsVirtualMachineScaleSetSchema(),
-	}
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(virtualMachineQuotaPreflight("azurerm_windows_virtual_machine_scale_set", "sku", "instances")),
+	}, virtualMachineQuotaKey("azurerm_windows_virtual_machine_scale_set"))
 }
 
 func resourceWindowsVirtualMachineScaleSetCreate(d *pluginsdk.ResourceData, meta interface{}) error {
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-06-02-preview/agentpools"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/quota"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// kubernetesClusterNodePoolQuotaPreflight checks the vCPU Quota is sufficient for the Nodes within this Node Pool,
+// using the Location of the Kubernetes Cluster
+func kubernetesClusterNodePoolQuotaPreflight(ctx context.Context, diff *pluginsdk.ResourceDiff, meta interface{}) error {
+	if features.QuotaPreflight() == features.QuotaPreflightModeDisabled {
+		return nil
+	}
+
+	for _, field := range []string{"kubernetes_cluster_id", "vm_size", "priority"} {
+		if !diff.NewValueKnown(field) {
+			return nil
+		}
+	}
+	// only the Node Count, VM Size and Priority affect the vCPUs requested, so there's no need to retrieve the
+	// Kubernetes Cluster when none of these have changed
+	if diff.Id() != "" && !diff.HasChanges("node_count", "vm_size", "priority") {
+		return nil
+	}
+
+	// when the Node Count isn't specified the Node Pool is created with the minimum number of Nodes
+	count := int64(0)
+	if diff.NewValueKnown("node_count") {
+		count = int64(diff.Get("node_count").(int))
+	}
+	if count == 0 {
+		count = int64(diff.Get("min_count").(int))
+	}
+
+	client := meta.(*clients.Client)
+	clusterId, err := commonids.ParseKubernetesClusterID(diff.Get("kubernetes_cluster_id").(string))
+	if err != nil {
+		return err
+	}
+
+	cluster, err := client.Containers.KubernetesClustersClient.Get(ctx, *clusterId)
+	if err != nil || cluster.Model == nil {
+		// the Kubernetes Cluster may not exist yet, in which case the Quota can't be checked
+		return nil
+	}
+
+	key, err := kubernetesClusterNodePoolQuotaKey(diff, meta)
+	if err != nil {
+		return err
+	}
+
+	request := quota.ComputeRequest{
+		Key:      key,
+		Location: cluster.Model.Location,
+		Size:     diff.Get("vm_size").(string),
+		Count:    count,
+		Spot:     strings.EqualFold(diff.Get("priority").(string), string(agentpools.ScaleSetPrioritySpot)),
+	}
+	if diff.Id() != "" {
+		oldCount, _ := diff.GetChange("node_count")
+		oldSize, _ := diff.GetChange("vm_size")
+		request.PreviousSize = oldSize.(string)
+		request.PreviousCount = int64(oldCount.(int))
+	}
+
+	if err := quota.CheckCompute(ctx, client, request); err != nil {
+		return fmt.Errorf("checking the vCPU Quota for the Node Pool: %+v", err)
+	}
+
+	return nil
+}
+
+// kubernetesClusterNodePoolQuotaKey returns the key used to identify the Node Pool within the Quota Pre-flight checks,
+// which is the Resource ID of the Node Pool
+func kubernetesClusterNodePoolQuotaKey(d quota.ResourceGetter, _ interface{}) (string, error) {
+	if id := d.Id(); id != "" {
+		return id, nil
+	}
+
+	clusterId, err := commonids.ParseKubernetesClusterID(d.Get("kubernetes_cluster_id").(string))
+	if err != nil {
+		return "", err
+	}
+	return agentpools.NewAgentPoolID(clusterId.SubscriptionId, clusterId.ResourceGroupName, clusterId.ManagedClusterName, d.Get("name").(string)).ID(), nil
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/quota"
 	computeValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/parse"
@@ -37,7 +38,7 @@ import (
 )
 
 func resourceKubernetesClusterNodePool() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
+	return quota.WithWarnings(&pluginsdk.Resource{
 		Create: resourceKubernetesClusterNodePoolCreate,
 		Read:   resourceKubernetesClusterNodePoolRead,
 		Update: resourceKubernetesClusterNodePoolUpdate,
@@ -61,7 +62,9 @@ func resourceKubernetesClusterNodePool() *pluginsdk.Resource {
 		}),
 
 		Schema: resourceKubernetesClusterNodePoolSchema(),
-	}
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(kubernetesClusterNodePoolQuotaPreflight),
+	}, kubernetesClusterNodePoolQuotaKey)
 }
 
 func resourceKubernetesClusterNodePoolSchema() map[string]*pluginsdk.Schema {
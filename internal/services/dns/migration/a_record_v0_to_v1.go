@@ -4,11 +4,9 @@
 package migration
 
 import (
-	"context"
-	"log"
-
 	"github.com/hashicorp/go-azure-sdk/resource-manager/dns/2018-05-01/recordsets"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -68,16 +66,6 @@ func (ARecordV0ToV1) Schema() map[string]*pluginsdk.Schema {
 	}
 }
 
-func (ARecordV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
-	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
-		oldId := rawState["id"].(string)
-		parsedId, err := recordsets.ParseRecordTypeIDInsensitively(oldId)
-		if err != nil {
-			return nil, err
-		}
-		newId := parsedId.ID()
-		log.Printf("[DEBUG] Updating ID from %q to %q", oldId, newId)
-		rawState["id"] = newId
-		return rawState, nil
-	}
+func (s ARecordV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
+	return sdk.NewIDStateUpgrade(s.Schema(), &recordsets.RecordTypeId{}).UpgradeFunc()
 }
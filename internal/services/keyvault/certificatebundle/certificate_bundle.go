@@ -0,0 +1,358 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package certificatebundle
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+const (
+	ContentTypePkcs12 = "application/x-pkcs12"
+	ContentTypePem    = "application/x-pem-file"
+)
+
+// expiryWarningThreshold is how far ahead of a certificate expiring a warning is returned
+const expiryWarningThreshold = 30 * 24 * time.Hour
+
+// Bundle is a certificate with its private key and (optional) chain of issuing certificates
+type Bundle struct {
+	PrivateKey crypto.Signer
+
+	// Certificate is the leaf certificate matching the PrivateKey
+	Certificate *x509.Certificate
+
+	// Chain contains the issuing certificates, ordered from the issuer of the leaf certificate towards the root
+	Chain []*x509.Certificate
+
+	// ContentType is the format the Bundle was decoded from, either ContentTypePkcs12 or ContentTypePem
+	ContentType string
+}
+
+// errUnsupportedPkcs12 is returned when a PKCS#12 bundle can't be decoded locally, for example as it uses an
+// algorithm which isn't supported by the decoder - these are passed through to the API as-is
+var errUnsupportedPkcs12 = errors.New("the PKCS#12 bundle could not be decoded")
+
+// Normalise validates the certificate bundle `contents` - which is either a PEM bundle or a base64 encoded
+// PKCS#12 (PFX) or PEM bundle - and returns it encoded as `contentType`, in the same encoding as the input.
+// When `contentType` is empty the format of the input is retained.
+//
+// PEM bundles are always re-encoded, so that the leaf certificate comes first followed by its chain and a PKCS#8
+// private key. PKCS#12 bundles are only re-encoded when being converted to a PEM bundle.
+func Normalise(contents, password, contentType string) (string, error) {
+	contentType = strings.ToLower(contentType)
+	if contentType != "" && contentType != ContentTypePkcs12 && contentType != ContentTypePem {
+		return "", fmt.Errorf("unsupported content type %q, expected %q or %q", contentType, ContentTypePkcs12, ContentTypePem)
+	}
+
+	bundle, base64Encoded, err := decodeContents(contents, password)
+	if err != nil {
+		if errors.Is(err, errUnsupportedPkcs12) && contentType != ContentTypePem {
+			log.Printf("[WARN] %+v - skipping local validation", err)
+			return contents, nil
+		}
+		return "", err
+	}
+
+	if contentType == "" {
+		contentType = bundle.ContentType
+	}
+
+	var output []byte
+	switch {
+	case contentType == ContentTypePkcs12 && bundle.ContentType == ContentTypePkcs12:
+		// PKCS#12 bundles are passed through as-is, rather than being re-encrypted
+		return contents, nil
+	case contentType == ContentTypePkcs12:
+		if output, err = bundle.EncodePkcs12(password); err != nil {
+			return "", err
+		}
+		// PKCS#12 bundles are binary and must always be base64 encoded
+		base64Encoded = true
+	default:
+		if output, err = bundle.EncodePem(); err != nil {
+			return "", err
+		}
+	}
+
+	if base64Encoded {
+		return base64.StdEncoding.EncodeToString(output), nil
+	}
+	return string(output), nil
+}
+
+// NormaliseToPkcs12 validates the base64 encoded PKCS#12 (PFX) or PEM certificate bundle `contents` and returns it
+// as a PKCS#12 bundle. PEM bundles are converted to a PKCS#12 bundle protected with `password`, PKCS#12 bundles are
+// returned as-is.
+//
+// This is intended for services which only accept a PFX, allowing a PEM bundle to be specified instead - validating
+// the bundle locally also surfaces clearer errors than these APIs return for an invalid certificate.
+func NormaliseToPkcs12(contents, password string) ([]byte, error) {
+	normalised, err := Normalise(contents, password, ContentTypePkcs12)
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(normalised)
+}
+
+// decodeContents decodes the certificate bundle `contents` - which is either a PEM bundle or a base64 encoded
+// PKCS#12 (PFX) or PEM bundle - returning whether the input was base64 encoded
+func decodeContents(contents, password string) (*Bundle, bool, error) {
+	base64Encoded := !isPem([]byte(contents))
+	raw := []byte(contents)
+	if base64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(contents)
+		if err != nil {
+			return nil, base64Encoded, fmt.Errorf("decoding the certificate bundle: expected a PEM bundle or a base64 encoded PKCS#12 or PEM bundle: %+v", err)
+		}
+		raw = decoded
+	}
+
+	bundle, err := Decode(raw, password)
+	return bundle, base64Encoded, err
+}
+
+// Decode decodes and validates a PKCS#12 (PFX) or PEM certificate bundle, ordering the certificates so that the
+// leaf certificate matching the private key comes first, followed by its chain of issuing certificates.
+//
+// Certificates which have expired, will expire soon or are not yet valid aren't rejected - callers can use
+// ExpiryWarnings (or WithExpiryWarnings for Resources) to surface these.
+func Decode(input []byte, password string) (*Bundle, error) {
+	var key interface{}
+	var certificates []*x509.Certificate
+	var contentType string
+
+	if isPem(input) {
+		var err error
+		if key, certificates, err = decodePem(input); err != nil {
+			return nil, err
+		}
+		contentType = ContentTypePem
+	} else {
+		pfxKey, certificate, caCertificates, err := pkcs12.DecodeChain(input, password)
+		if err != nil {
+			if errors.Is(err, pkcs12.ErrIncorrectPassword) || errors.Is(err, pkcs12.ErrDecryption) {
+				return nil, fmt.Errorf("decoding the PKCS#12 bundle: the `password` is incorrect")
+			}
+			return nil, fmt.Errorf("%w: %+v", errUnsupportedPkcs12, err)
+		}
+		key = pfxKey
+		certificates = append([]*x509.Certificate{certificate}, caCertificates...)
+		contentType = ContentTypePkcs12
+	}
+
+	signer, err := validatePrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := orderCertificates(signer, certificates)
+	if err != nil {
+		return nil, err
+	}
+	bundle.ContentType = contentType
+
+	return bundle, nil
+}
+
+// ExpiryWarnings returns a warning for each certificate in the Bundle which has expired, will expire soon or is not
+// yet valid at the time `now`
+func (b Bundle) ExpiryWarnings(now time.Time) []string {
+	warnings := make([]string, 0)
+
+	for i, certificate := range append([]*x509.Certificate{b.Certificate}, b.Chain...) {
+		description := fmt.Sprintf("the certificate %q", certificate.Subject.String())
+		if i > 0 {
+			description = fmt.Sprintf("the issuing certificate %q", certificate.Subject.String())
+		}
+
+		switch {
+		case !certificate.NotAfter.After(now):
+			warnings = append(warnings, fmt.Sprintf("%s expired on %s", description, certificate.NotAfter.Format(time.RFC3339)))
+		case certificate.NotAfter.Sub(now) < expiryWarningThreshold:
+			warnings = append(warnings, fmt.Sprintf("%s expires soon, on %s", description, certificate.NotAfter.Format(time.RFC3339)))
+		case certificate.NotBefore.After(now):
+			warnings = append(warnings, fmt.Sprintf("%s is not valid until %s", description, certificate.NotBefore.Format(time.RFC3339)))
+		}
+	}
+
+	return warnings
+}
+
+// EncodePem encodes the Bundle as a PEM bundle containing the leaf certificate, the chain and the PKCS#8 private key
+func (b Bundle) EncodePem() ([]byte, error) {
+	var output bytes.Buffer
+
+	for _, certificate := range append([]*x509.Certificate{b.Certificate}, b.Chain...) {
+		if err := pem.Encode(&output, &pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw}); err != nil {
+			return nil, fmt.Errorf("encoding the PEM bundle: %+v", err)
+		}
+	}
+
+	key, err := x509.MarshalPKCS8PrivateKey(b.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("encoding the private key as PKCS#8: %+v", err)
+	}
+	if err := pem.Encode(&output, &pem.Block{Type: "PRIVATE KEY", Bytes: key}); err != nil {
+		return nil, fmt.Errorf("encoding the PEM bundle: %+v", err)
+	}
+
+	return output.Bytes(), nil
+}
+
+// EncodePkcs12 encodes the Bundle as a PKCS#12 (PFX) bundle protected with `password`
+func (b Bundle) EncodePkcs12(password string) ([]byte, error) {
+	// the legacy (3DES) encoding is used since it's supported by all of the Azure services which import certificates
+	output, err := pkcs12.LegacyDES.Encode(b.PrivateKey, b.Certificate, b.Chain, password)
+	if err != nil {
+		return nil, fmt.Errorf("encoding the PKCS#12 bundle: %+v", err)
+	}
+
+	return output, nil
+}
+
+func isPem(input []byte) bool {
+	return bytes.Contains(input, []byte("-----BEGIN "))
+}
+
+func decodePem(input []byte) (interface{}, []*x509.Certificate, error) {
+	var key interface{}
+	certificates := make([]*x509.Certificate, 0)
+
+	for block, rest := pem.Decode(input); block != nil; block, rest = pem.Decode(rest) {
+		if _, encrypted := block.Headers["Proc-Type"]; encrypted || block.Type == "ENCRYPTED PRIVATE KEY" {
+			return nil, nil, fmt.Errorf("decoding the PEM bundle: encrypted private keys are not supported, the private key must be decrypted or the bundle converted to PKCS#12")
+		}
+
+		switch block.Type {
+		case "CERTIFICATE":
+			certificate, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, nil, fmt.Errorf("decoding the PEM bundle: parsing certificate %d: %+v", len(certificates)+1, err)
+			}
+			certificates = append(certificates, certificate)
+
+		case "PRIVATE KEY", "RSA PRIVATE KEY", "EC PRIVATE KEY":
+			if key != nil {
+				return nil, nil, fmt.Errorf("decoding the PEM bundle: expected a single private key but found multiple")
+			}
+
+			var err error
+			switch block.Type {
+			case "PRIVATE KEY":
+				key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+			case "RSA PRIVATE KEY":
+				key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+			case "EC PRIVATE KEY":
+				key, err = x509.ParseECPrivateKey(block.Bytes)
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("decoding the PEM bundle: parsing the %s: %+v", strings.ToLower(block.Type), err)
+			}
+
+		case "EC PARAMETERS":
+			// written alongside `EC PRIVATE KEY` blocks by OpenSSL and not needed
+
+		default:
+			return nil, nil, fmt.Errorf("decoding the PEM bundle: unsupported PEM block %q", block.Type)
+		}
+	}
+
+	if key == nil {
+		return nil, nil, fmt.Errorf("decoding the PEM bundle: no private key was found")
+	}
+	if len(certificates) == 0 {
+		return nil, nil, fmt.Errorf("decoding the PEM bundle: no certificates were found")
+	}
+
+	return key, certificates, nil
+}
+
+func validatePrivateKey(input interface{}) (crypto.Signer, error) {
+	switch key := input.(type) {
+	case *rsa.PrivateKey:
+		if bits := key.N.BitLen(); bits < 2048 {
+			return nil, fmt.Errorf("unsupported RSA key size %d, the private key must be at least 2048 bits", bits)
+		}
+		return key, nil
+
+	case *ecdsa.PrivateKey:
+		switch key.Curve {
+		case elliptic.P256(), elliptic.P384(), elliptic.P521():
+			return key, nil
+		}
+		return nil, fmt.Errorf("unsupported elliptic curve %q, the private key must use the P-256, P-384 or P-521 curve", key.Curve.Params().Name)
+
+	case ed25519.PrivateKey:
+		return nil, fmt.Errorf("unsupported key type Ed25519, the private key must be an RSA or an ECDSA key")
+	}
+
+	return nil, fmt.Errorf("unsupported key type %T, the private key must be an RSA or an ECDSA key", input)
+}
+
+// orderCertificates finds the leaf certificate matching `key` and builds the chain of issuing certificates from the
+// remaining certificates. Certificates which aren't part of the chain are retained at the end of the chain.
+func orderCertificates(key crypto.Signer, certificates []*x509.Certificate) (*Bundle, error) {
+	type publicKey interface {
+		Equal(crypto.PublicKey) bool
+	}
+
+	var leaf *x509.Certificate
+	remaining := make([]*x509.Certificate, 0)
+	for _, certificate := range certificates {
+		if leaf == nil && key.Public().(publicKey).Equal(certificate.PublicKey) {
+			leaf = certificate
+			continue
+		}
+		remaining = append(remaining, certificate)
+	}
+
+	if leaf == nil {
+		return nil, fmt.Errorf("the private key doesn't match any of the %d certificate(s) in the bundle", len(certificates))
+	}
+
+	chain := make([]*x509.Certificate, 0)
+	for current := leaf; len(remaining) > 0; {
+		index := -1
+		for i, candidate := range remaining {
+			if bytes.Equal(current.RawIssuer, candidate.RawSubject) && current.CheckSignatureFrom(candidate) == nil {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			break
+		}
+
+		current = remaining[index]
+		chain = append(chain, current)
+		remaining = append(remaining[:index], remaining[index+1:]...)
+	}
+
+	for _, certificate := range remaining {
+		log.Printf("[WARN] the certificate %q isn't part of the chain for %q", certificate.Subject.String(), leaf.Subject.String())
+		chain = append(chain, certificate)
+	}
+
+	return &Bundle{
+		PrivateKey:  key,
+		Certificate: leaf,
+		Chain:       chain,
+	}, nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package certificatebundle
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// WithExpiryWarnings wraps the Create and Update functions of the Resource so that, once the certificate bundle in
+// the field `contentsKey` (protected with the password in the field `passwordKey`) has been imported, warnings are
+// returned as diagnostics for any certificates in the bundle which have expired, will expire soon or are not yet valid.
+//
+// Since the Resource is otherwise unchanged, warnings are only returned when the certificate bundle has changed.
+func WithExpiryWarnings(resource *pluginsdk.Resource, contentsKey, passwordKey string) *pluginsdk.Resource {
+	if v := resource.Create; v != nil { //nolint:staticcheck
+		resource.Create = nil //nolint:staticcheck
+		resource.CreateContext = withExpiryWarningsFunc(v, contentsKey, passwordKey)
+	}
+	if v := resource.Update; v != nil { //nolint:staticcheck
+		resource.Update = nil //nolint:staticcheck
+		resource.UpdateContext = withExpiryWarningsFunc(v, contentsKey, passwordKey)
+	}
+	return resource
+}
+
+func withExpiryWarningsFunc(f func(d *pluginsdk.ResourceData, meta interface{}) error, contentsKey, passwordKey string) func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+		if err := f(d, meta); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if !d.HasChange(contentsKey) {
+			return nil
+		}
+
+		return expiryWarningDiagnostics(expiryWarnings(d.Get(contentsKey).(string), d.Get(passwordKey).(string), time.Now()))
+	}
+}
+
+// expiryWarnings returns the ExpiryWarnings for the certificate bundle `contents`, which is in any of the formats
+// accepted by Normalise
+func expiryWarnings(contents, password string, now time.Time) []string {
+	if contents == "" {
+		return nil
+	}
+
+	bundle, _, err := decodeContents(contents, password)
+	if err != nil {
+		// the bundle has already been validated by the Resource, or can't be decoded locally and was passed through
+		return nil
+	}
+
+	return bundle.ExpiryWarnings(now)
+}
+
+func expiryWarningDiagnostics(warnings []string) diag.Diagnostics {
+	output := make(diag.Diagnostics, 0)
+	for _, warning := range warnings {
+		output = append(output, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Certificate Expiry",
+			Detail:   warning,
+		})
+	}
+	return output
+}
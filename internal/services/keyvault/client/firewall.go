@@ -0,0 +1,391 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/netip"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-02-01/vaults"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+)
+
+// keyVaultResourceName is the name used by the `azurerm_key_vault` resource when locking the Key Vault, which we
+// also need to lock on when updating the Network ACLs to avoid conflicting with changes made by that resource.
+const keyVaultResourceName = "azurerm_key_vault"
+
+var (
+	// when the Key Vault's firewall rejects a request the Data Plane API returns a 403 with the message:
+	// > Client address is not authorized and caller is not a trusted service.
+	// > Client address: 1.2.3.4
+	// > Caller: appid=...;oid=...
+	// > Vault: example-vault;location=westeurope
+	// alongside an InnerError with the code `ForbiddenByFirewall`
+	forbiddenByFirewallClientAddressRegex = regexp.MustCompile(`Client address: ([0-9a-fA-F.:]+)`)
+
+	// temporaryNetworkAccessLock guards temporaryNetworkAccess, and is only held whilst updating the map - not
+	// whilst updating the Network ACLs of the Key Vault
+	temporaryNetworkAccessLock = &sync.Mutex{}
+	temporaryNetworkAccess     = map[string]*temporaryNetworkAccessRule{}
+)
+
+const (
+	// temporaryNetworkAccessPropagationTimeout is the maximum length of time we'll wait for a temporary
+	// IP Rule to be applied to the Key Vault's firewall before giving up
+	temporaryNetworkAccessPropagationTimeout = 5 * time.Minute
+	temporaryNetworkAccessPollInterval       = 10 * time.Second
+)
+
+// ForbiddenByFirewallError is returned when a request to the Key Vault Data Plane API was rejected
+// by the Network ACLs (firewall) configured on the Key Vault.
+type ForbiddenByFirewallError struct {
+	// ClientAddress is the IP Address which the request was rejected from
+	ClientAddress string
+
+	err error
+}
+
+func (e ForbiddenByFirewallError) Error() string {
+	return e.err.Error()
+}
+
+func (e ForbiddenByFirewallError) Unwrap() error {
+	return e.err
+}
+
+// ParseForbiddenByFirewallError returns the details of the rejected request when the specified error was returned
+// because the Key Vault's firewall rejected the request, or nil if the error was caused by something else.
+//
+// NOTE: since these errors are frequently wrapped using `%s` (rather than `%w`) this intentionally checks
+// the error message, which contains both the InnerError and the Message returned from the API.
+func ParseForbiddenByFirewallError(err error) *ForbiddenByFirewallError {
+	if err == nil {
+		return nil
+	}
+
+	message := err.Error()
+	if !strings.Contains(message, "ForbiddenByFirewall") {
+		return nil
+	}
+
+	result := ForbiddenByFirewallError{
+		err: err,
+	}
+	if match := forbiddenByFirewallClientAddressRegex.FindStringSubmatch(message); len(match) == 2 {
+		result.ClientAddress = strings.TrimSuffix(match[1], ".")
+	}
+	return &result
+}
+
+// WithNetworkAccess runs the specified operation against the Data Plane API of the specified Key Vault, if this
+// is rejected by the Key Vault's firewall then the error is enriched with the IP Address which was rejected and
+// the Network ACLs configured on the Key Vault.
+//
+// When `temporarilyAllowClientIP` is enabled the rejected IP Address is added to the Key Vault's Network ACLs
+// for the duration of the operation (retrying it once the rule has been applied) and removed afterwards. Since the
+// Network ACLs only support IPv4 Addresses, requests rejected from an IPv6 Address are never allowed temporarily.
+func (c *Client) WithNetworkAccess(ctx context.Context, keyVaultId commonids.KeyVaultId, temporarilyAllowClientIP bool, operation func() error) error {
+	err := operation()
+	firewallErr := ParseForbiddenByFirewallError(err)
+	if firewallErr == nil {
+		return err
+	}
+
+	if !temporarilyAllowClientIP {
+		return c.describeForbiddenByFirewallError(ctx, keyVaultId, *firewallErr)
+	}
+	if !isIPv4Address(firewallErr.ClientAddress) {
+		log.Printf("[DEBUG] Unable to temporarily allow access to %s from the IP Address %q since only IPv4 Addresses can be added to the Network ACLs", keyVaultId, firewallErr.ClientAddress)
+		return c.describeForbiddenByFirewallError(ctx, keyVaultId, *firewallErr)
+	}
+
+	if publicNetworkAccessDisabled, detailsErr := c.publicNetworkAccessIsDisabled(ctx, keyVaultId); detailsErr != nil || publicNetworkAccessDisabled {
+		// an IP Rule has no effect when Public Network Access is disabled
+		return c.describeForbiddenByFirewallError(ctx, keyVaultId, *firewallErr)
+	}
+
+	log.Printf("[DEBUG] Temporarily allowing access to %s from the IP Address %q..", keyVaultId, firewallErr.ClientAddress)
+	if err := c.addTemporaryNetworkAccess(ctx, keyVaultId, firewallErr.ClientAddress); err != nil {
+		return fmt.Errorf("temporarily allowing access to %s from the IP Address %q: %+v", keyVaultId, firewallErr.ClientAddress, err)
+	}
+	defer func() {
+		// the context may have been cancelled/timed out, however we still want to remove the IP Rule
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		log.Printf("[DEBUG] Removing the temporary access to %s from the IP Address %q..", keyVaultId, firewallErr.ClientAddress)
+		if err := c.removeTemporaryNetworkAccess(cleanupCtx, keyVaultId, firewallErr.ClientAddress); err != nil {
+			log.Printf("[WARN] unable to remove the temporary IP Rule for %q from the Network ACLs of %s - this will need to be removed manually: %+v", firewallErr.ClientAddress, keyVaultId, err)
+		}
+	}()
+
+	// changes to the Network ACLs take a short while to be applied, so we retry until the request is no longer rejected
+	deadline := time.Now().Add(temporaryNetworkAccessPropagationTimeout)
+	if v, ok := ctx.Deadline(); ok && v.Before(deadline) {
+		deadline = v
+	}
+	for {
+		err = operation()
+		firewallErr := ParseForbiddenByFirewallError(err)
+		if firewallErr == nil {
+			return err
+		}
+
+		if time.Now().Add(temporaryNetworkAccessPollInterval).After(deadline) {
+			return c.describeForbiddenByFirewallError(ctx, keyVaultId, *firewallErr)
+		}
+
+		log.Printf("[DEBUG] Waiting for the temporary IP Rule for %q to be applied to %s..", firewallErr.ClientAddress, keyVaultId)
+		select {
+		case <-ctx.Done():
+			return c.describeForbiddenByFirewallError(ctx, keyVaultId, *firewallErr)
+		case <-time.After(temporaryNetworkAccessPollInterval):
+		}
+	}
+}
+
+func (c *Client) describeForbiddenByFirewallError(ctx context.Context, keyVaultId commonids.KeyVaultId, input ForbiddenByFirewallError) error {
+	clientAddress := input.ClientAddress
+	if clientAddress == "" {
+		clientAddress = "(unknown)"
+	}
+
+	message := fmt.Sprintf("the request to %s from the IP Address %q was rejected by the Key Vault's firewall (`network_acls`)", keyVaultId, clientAddress)
+
+	resp, err := c.VaultsClient.Get(ctx, keyVaultId)
+	if err != nil || resp.Model == nil {
+		log.Printf("[DEBUG] unable to retrieve %s to determine the Network ACLs which apply: %+v", keyVaultId, err)
+		return fmt.Errorf("%s - to fix this either add this IP Address to the `ip_rules` within the `network_acls` block, or enable the `temporarily_allow_client_ip_in_network_acls` feature within the `key_vault` features block: %+v", message, input.err)
+	}
+
+	props := resp.Model.Properties
+	if strings.EqualFold(pointer.From(props.PublicNetworkAccess), "Disabled") {
+		return fmt.Errorf("%s since Public Network Access is disabled for this Key Vault - requests need to be made through a Private Endpoint: %+v", message, input.err)
+	}
+
+	defaultAction := string(vaults.NetworkRuleActionAllow)
+	bypass := ""
+	ipRules := make([]string, 0)
+	virtualNetworkRules := 0
+	if acls := props.NetworkAcls; acls != nil {
+		if acls.DefaultAction != nil {
+			defaultAction = string(*acls.DefaultAction)
+		}
+		if acls.Bypass != nil {
+			bypass = string(*acls.Bypass)
+		}
+		if acls.IPRules != nil {
+			for _, rule := range *acls.IPRules {
+				ipRules = append(ipRules, rule.Value)
+			}
+		}
+		if acls.VirtualNetworkRules != nil {
+			virtualNetworkRules = len(*acls.VirtualNetworkRules)
+		}
+	}
+
+	return fmt.Errorf("%s - the Network ACL has a Default Action of %q and a Bypass of %q, allowing the IP Rules [%s] and %d Virtual Network Rule(s). To fix this either add this IP Address to the `ip_rules` within the `network_acls` block, or enable the `temporarily_allow_client_ip_in_network_acls` feature within the `key_vault` features block: %+v", message, defaultAction, bypass, strings.Join(ipRules, ", "), virtualNetworkRules, input.err)
+}
+
+func (c *Client) publicNetworkAccessIsDisabled(ctx context.Context, keyVaultId commonids.KeyVaultId) (bool, error) {
+	resp, err := c.VaultsClient.Get(ctx, keyVaultId)
+	if err != nil {
+		return false, fmt.Errorf("retrieving %s: %+v", keyVaultId, err)
+	}
+	if resp.Model == nil {
+		return false, fmt.Errorf("retrieving %s: `model` was nil", keyVaultId)
+	}
+
+	return strings.EqualFold(pointer.From(resp.Model.Properties.PublicNetworkAccess), "Disabled"), nil
+}
+
+type temporaryNetworkAccessRule struct {
+	// references is the number of operations currently relying on this IP Rule
+	references int
+
+	// added specifies whether the IP Rule was added by us (and as such should be removed)
+	added bool
+
+	// ready is closed once the IP Rule has been added to the Network ACLs, at which point err is populated
+	// if this failed
+	ready chan struct{}
+	err   error
+}
+
+func (c *Client) addTemporaryNetworkAccess(ctx context.Context, keyVaultId commonids.KeyVaultId, clientAddress string) error {
+	// since multiple items within the same Key Vault can be provisioned concurrently we only want to add the
+	// IP Rule once and remove it once the last operation relying on it has completed
+	key := temporaryNetworkAccessKey(keyVaultId, clientAddress)
+	temporaryNetworkAccessLock.Lock()
+	if rule, ok := temporaryNetworkAccess[key]; ok {
+		rule.references++
+		temporaryNetworkAccessLock.Unlock()
+
+		<-rule.ready
+		return rule.err
+	}
+	rule := &temporaryNetworkAccessRule{
+		references: 1,
+		ready:      make(chan struct{}),
+	}
+	temporaryNetworkAccess[key] = rule
+	temporaryNetworkAccessLock.Unlock()
+
+	added, err := c.addIPRule(ctx, keyVaultId, clientAddress)
+
+	temporaryNetworkAccessLock.Lock()
+	if err != nil {
+		// the operations waiting on this IP Rule fail too, so there's nothing to remove afterwards
+		delete(temporaryNetworkAccess, key)
+	}
+	// the IP Rule may have been handed over to us by an operation removing it, see removeIPRule
+	rule.added = rule.added || added
+	rule.err = err
+	temporaryNetworkAccessLock.Unlock()
+	close(rule.ready)
+
+	return err
+}
+
+// addIPRule adds the IP Rule for the client address to the Network ACLs of the Key Vault, returning whether
+// it was added (or whether it already existed)
+func (c *Client) addIPRule(ctx context.Context, keyVaultId commonids.KeyVaultId, clientAddress string) (bool, error) {
+	locks.ByName(keyVaultId.VaultName, keyVaultResourceName)
+	defer locks.UnlockByName(keyVaultId.VaultName, keyVaultResourceName)
+
+	resp, err := c.VaultsClient.Get(ctx, keyVaultId)
+	if err != nil {
+		return false, fmt.Errorf("retrieving %s: %+v", keyVaultId, err)
+	}
+	if resp.Model == nil {
+		return false, fmt.Errorf("retrieving %s: `model` was nil", keyVaultId)
+	}
+
+	acls := resp.Model.Properties.NetworkAcls
+	if acls == nil {
+		acls = &vaults.NetworkRuleSet{}
+	}
+	ipRules := make([]vaults.IPRule, 0)
+	if acls.IPRules != nil {
+		ipRules = *acls.IPRules
+	}
+
+	for _, rule := range ipRules {
+		if ipRuleMatchesAddress(rule.Value, clientAddress) {
+			// the rule already exists (and is presumably still being applied) so there's nothing to add, and we
+			// mustn't remove it afterwards
+			return false, nil
+		}
+	}
+
+	ipRules = append(ipRules, vaults.IPRule{
+		Value: clientAddress,
+	})
+	acls.IPRules = &ipRules
+
+	payload := vaults.VaultPatchParameters{
+		Properties: &vaults.VaultPatchProperties{
+			NetworkAcls: acls,
+		},
+	}
+	if _, err := c.VaultsClient.Update(ctx, keyVaultId, payload); err != nil {
+		return false, fmt.Errorf("updating the Network ACLs for %s: %+v", keyVaultId, err)
+	}
+
+	return true, nil
+}
+
+func (c *Client) removeTemporaryNetworkAccess(ctx context.Context, keyVaultId commonids.KeyVaultId, clientAddress string) error {
+	key := temporaryNetworkAccessKey(keyVaultId, clientAddress)
+	temporaryNetworkAccessLock.Lock()
+	rule, ok := temporaryNetworkAccess[key]
+	if !ok {
+		temporaryNetworkAccessLock.Unlock()
+		return nil
+	}
+	rule.references--
+	if rule.references > 0 {
+		temporaryNetworkAccessLock.Unlock()
+		return nil
+	}
+	delete(temporaryNetworkAccess, key)
+	temporaryNetworkAccessLock.Unlock()
+
+	if !rule.added {
+		return nil
+	}
+
+	return c.removeIPRule(ctx, keyVaultId, clientAddress)
+}
+
+func (c *Client) removeIPRule(ctx context.Context, keyVaultId commonids.KeyVaultId, clientAddress string) error {
+	locks.ByName(keyVaultId.VaultName, keyVaultResourceName)
+	defer locks.UnlockByName(keyVaultId.VaultName, keyVaultResourceName)
+
+	// another operation may have started relying on this IP Rule whilst we were waiting for the lock, in which
+	// case that operation becomes responsible for removing it
+	temporaryNetworkAccessLock.Lock()
+	if next, ok := temporaryNetworkAccess[temporaryNetworkAccessKey(keyVaultId, clientAddress)]; ok {
+		next.added = true
+		temporaryNetworkAccessLock.Unlock()
+		return nil
+	}
+	temporaryNetworkAccessLock.Unlock()
+
+	resp, err := c.VaultsClient.Get(ctx, keyVaultId)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", keyVaultId, err)
+	}
+	if resp.Model == nil || resp.Model.Properties.NetworkAcls == nil || resp.Model.Properties.NetworkAcls.IPRules == nil {
+		return nil
+	}
+
+	acls := resp.Model.Properties.NetworkAcls
+	ipRules := make([]vaults.IPRule, 0)
+	for _, rule := range *acls.IPRules {
+		if ipRuleMatchesAddress(rule.Value, clientAddress) {
+			continue
+		}
+		ipRules = append(ipRules, rule)
+	}
+	if len(ipRules) == len(*acls.IPRules) {
+		return nil
+	}
+	acls.IPRules = &ipRules
+
+	payload := vaults.VaultPatchParameters{
+		Properties: &vaults.VaultPatchProperties{
+			NetworkAcls: acls,
+		},
+	}
+	if _, err := c.VaultsClient.Update(ctx, keyVaultId, payload); err != nil {
+		return fmt.Errorf("updating the Network ACLs for %s: %+v", keyVaultId, err)
+	}
+
+	return nil
+}
+
+// isIPv4Address returns whether the client address is an IPv4 Address, which (unlike IPv6 Addresses) can be
+// added to the `ip_rules` of the Network ACLs
+func isIPv4Address(clientAddress string) bool {
+	addr, err := netip.ParseAddr(clientAddress)
+	return err == nil && addr.Is4()
+}
+
+func ipRuleMatchesAddress(rule, clientAddress string) bool {
+	// the API returns single IP Addresses using CIDR notation (e.g. `1.2.3.4/32`)
+	return strings.EqualFold(strings.TrimSuffix(rule, "/32"), clientAddress)
+}
+
+func temporaryNetworkAccessKey(keyVaultId commonids.KeyVaultId, clientAddress string) string {
+	return fmt.Sprintf("%s|%s", strings.ToLower(keyVaultId.ID()), clientAddress)
+}
@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+const forbiddenByFirewallMessage = `keyvault.BaseClient#GetSecret: Failure responding to request: StatusCode=403 -- Original Error: autorest/azure: Service returned an error. Status=403 Code="Forbidden" Message="Client address is not authorized and caller is not a trusted service.\r\nClient address: %s\r\nCaller: appid=00000000-0000-0000-0000-000000000000;oid=00000000-0000-0000-0000-000000000000\r\nVault: example-vault;location=westeurope" InnerError={"code":"ForbiddenByFirewall"}`
+
+func TestParseForbiddenByFirewallError(t *testing.T) {
+	testData := []struct {
+		Name          string
+		Input         error
+		Expected      bool
+		ClientAddress string
+	}{
+		{
+			Name:     "no error",
+			Input:    nil,
+			Expected: false,
+		},
+		{
+			Name:     "unrelated error",
+			Input:    errors.New(`Status=403 Code="Forbidden" Message="The user, group or application does not have secrets get permission"`),
+			Expected: false,
+		},
+		{
+			Name:          "IPv4 address",
+			Input:         fmt.Errorf(forbiddenByFirewallMessage, "1.2.3.4"),
+			Expected:      true,
+			ClientAddress: "1.2.3.4",
+		},
+		{
+			Name:          "IPv4 address ending the sentence",
+			Input:         fmt.Errorf(forbiddenByFirewallMessage, "1.2.3.4."),
+			Expected:      true,
+			ClientAddress: "1.2.3.4",
+		},
+		{
+			Name:          "IPv6 address",
+			Input:         fmt.Errorf(forbiddenByFirewallMessage, "2001:db8::1"),
+			Expected:      true,
+			ClientAddress: "2001:db8::1",
+		},
+		{
+			Name:          "wrapped using %s",
+			Input:         fmt.Errorf("retrieving Secret: %s", fmt.Errorf(forbiddenByFirewallMessage, "1.2.3.4")),
+			Expected:      true,
+			ClientAddress: "1.2.3.4",
+		},
+		{
+			Name:          "without a client address",
+			Input:         errors.New(`Status=403 Code="Forbidden" Message="Client address is not authorized and caller is not a trusted service." InnerError={"code":"ForbiddenByFirewall"}`),
+			Expected:      true,
+			ClientAddress: "",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := ParseForbiddenByFirewallError(v.Input)
+		if (actual != nil) != v.Expected {
+			t.Fatalf("expected a ForbiddenByFirewallError to be %t but got %+v", v.Expected, actual)
+		}
+		if actual == nil {
+			continue
+		}
+
+		if actual.ClientAddress != v.ClientAddress {
+			t.Fatalf("expected the Client Address %q but got %q", v.ClientAddress, actual.ClientAddress)
+		}
+		if !errors.Is(actual, v.Input) {
+			t.Fatalf("expected the ForbiddenByFirewallError to wrap the original error")
+		}
+	}
+}
+
+func TestForbiddenByFirewallClientAddressRegex(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected string
+	}{
+		{
+			Input:    "Client address: 1.2.3.4\r\nCaller: appid=...",
+			Expected: "1.2.3.4",
+		},
+		{
+			Input:    `Client address: 10.0.0.1"`,
+			Expected: "10.0.0.1",
+		},
+		{
+			Input:    "Client address: 2001:DB8::ff\nCaller",
+			Expected: "2001:DB8::ff",
+		},
+		{
+			Input:    "Client address is not authorized",
+			Expected: "",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual := ""
+		if match := forbiddenByFirewallClientAddressRegex.FindStringSubmatch(v.Input); len(match) == 2 {
+			actual = match[1]
+		}
+		if actual != v.Expected {
+			t.Fatalf("expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestIPRuleMatchesAddress(t *testing.T) {
+	testData := []struct {
+		Rule          string
+		ClientAddress string
+		Expected      bool
+	}{
+		{
+			Rule:          "1.2.3.4",
+			ClientAddress: "1.2.3.4",
+			Expected:      true,
+		},
+		{
+			Rule:          "1.2.3.4/32",
+			ClientAddress: "1.2.3.4",
+			Expected:      true,
+		},
+		{
+			Rule:          "1.2.3.40/32",
+			ClientAddress: "1.2.3.4",
+			Expected:      false,
+		},
+		{
+			Rule:          "1.2.3.0/24",
+			ClientAddress: "1.2.3.4",
+			Expected:      false,
+		},
+		{
+			Rule:          "5.6.7.8",
+			ClientAddress: "1.2.3.4",
+			Expected:      false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q against %q", v.Rule, v.ClientAddress)
+
+		if actual := ipRuleMatchesAddress(v.Rule, v.ClientAddress); actual != v.Expected {
+			t.Fatalf("expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
+
+func TestIsIPv4Address(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected bool
+	}{
+		{
+			Input:    "1.2.3.4",
+			Expected: true,
+		},
+		{
+			Input:    "2001:db8::1",
+			Expected: false,
+		},
+		{
+			Input:    "::ffff:1.2.3.4",
+			Expected: false,
+		},
+		{
+			Input:    "",
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		if actual := isIPv4Address(v.Input); actual != v.Expected {
+			t.Fatalf("expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
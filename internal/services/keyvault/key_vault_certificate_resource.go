@@ -23,6 +23,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/certificatebundle"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
 	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
@@ -35,7 +36,7 @@ import (
 )
 
 func resourceKeyVaultCertificate() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
+	return certificatebundle.WithExpiryWarnings(&pluginsdk.Resource{
 		// TODO: support Updating additional properties once we have more information about what can be updated
 		Create: resourceKeyVaultCertificateCreate,
 		Read:   resourceKeyVaultCertificateRead,
@@ -407,7 +408,7 @@ func resourceKeyVaultCertificate() *pluginsdk.Resource {
 
 			"tags": tags.Schema(),
 		},
-	}
+	}, "certificate.0.contents", "certificate.0.password")
 }
 
 func createCertificate(d *pluginsdk.ResourceData, meta interface{}) (keyvault.CertificateBundle, error) {
@@ -470,7 +471,6 @@ func createCertificate(d *pluginsdk.ResourceData, meta interface{}) (keyvault.Ce
 
 func resourceKeyVaultCertificateCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	keyVaultsClient := meta.(*clients.Client).KeyVault
-	client := meta.(*clients.Client).KeyVault.ManagementClient
 	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -485,6 +485,15 @@ func resourceKeyVaultCertificateCreate(d *pluginsdk.ResourceData, meta interface
 		return fmt.Errorf("looking up Base URI for Certificate %q in %s: %+v", name, *keyVaultId, err)
 	}
 
+	temporarilyAllowClientIP := meta.(*clients.Client).Features.KeyVault.TemporarilyAllowClientIPInNetworkAcls
+	return keyVaultsClient.WithNetworkAccess(ctx, *keyVaultId, temporarilyAllowClientIP, func() error {
+		return resourceKeyVaultCertificateCreateWithinKeyVault(ctx, d, meta, name, keyVaultBaseUrl)
+	})
+}
+
+func resourceKeyVaultCertificateCreateWithinKeyVault(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, name string, keyVaultBaseUrl *string) error {
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+
 	existing, err := client.GetCertificate(ctx, *keyVaultBaseUrl, name, "")
 	if err != nil {
 		if !utils.ResponseWasNotFound(existing.Response) {
@@ -505,7 +514,10 @@ func resourceKeyVaultCertificateCreate(d *pluginsdk.ResourceData, meta interface
 	var newCert keyvault.CertificateBundle
 	if v, ok := d.GetOk("certificate"); ok {
 		// Import
-		certificate := expandKeyVaultCertificate(v)
+		certificate, err := expandKeyVaultCertificate(v, d.Get("certificate_policy.0.secret_properties.0.content_type").(string))
+		if err != nil {
+			return err
+		}
 		importParameters := keyvault.CertificateImportParameters{
 			Base64EncodedCertificate: utils.String(certificate.CertificateData),
 			Password:                 utils.String(certificate.CertificatePassword),
@@ -581,7 +593,7 @@ func recoverDeletedCertificate(ctx context.Context, d *pluginsdk.ResourceData, m
 }
 
 func resourceKeyVaultCertificateUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).KeyVault.ManagementClient
+	keyVaultsClient := meta.(*clients.Client).KeyVault
 	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -597,10 +609,22 @@ func resourceKeyVaultCertificateUpdate(d *schema.ResourceData, meta interface{})
 
 	meta.(*clients.Client).KeyVault.AddToCache(*keyVaultId, id.KeyVaultBaseUrl)
 
+	temporarilyAllowClientIP := meta.(*clients.Client).Features.KeyVault.TemporarilyAllowClientIPInNetworkAcls
+	return keyVaultsClient.WithNetworkAccess(ctx, *keyVaultId, temporarilyAllowClientIP, func() error {
+		return resourceKeyVaultCertificateUpdateWithinKeyVault(ctx, d, meta, *id)
+	})
+}
+
+func resourceKeyVaultCertificateUpdateWithinKeyVault(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, id parse.NestedItemId) error {
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+
 	if d.HasChange("certificate") {
 		if v, ok := d.GetOk("certificate"); ok {
 			// Import new version of certificate
-			certificate := expandKeyVaultCertificate(v)
+			certificate, err := expandKeyVaultCertificate(v, d.Get("certificate_policy.0.secret_properties.0.content_type").(string))
+			if err != nil {
+				return err
+			}
 			importParameters := keyvault.CertificateImportParameters{
 				Base64EncodedCertificate: utils.String(certificate.CertificateData),
 				Password:                 utils.String(certificate.CertificatePassword),
@@ -668,7 +692,7 @@ func resourceKeyVaultCertificateUpdate(d *schema.ResourceData, meta interface{})
 			}
 		}
 
-		if _, err = client.UpdateCertificate(ctx, id.KeyVaultBaseUrl, id.Name, "", patch); err != nil {
+		if _, err := client.UpdateCertificate(ctx, id.KeyVaultBaseUrl, id.Name, "", patch); err != nil {
 			return err
 		}
 	}
@@ -705,7 +729,6 @@ func keyVaultCertificateCreationRefreshFunc(ctx context.Context, client *keyvaul
 
 func resourceKeyVaultCertificateRead(d *pluginsdk.ResourceData, meta interface{}) error {
 	keyVaultsClient := meta.(*clients.Client).KeyVault
-	client := meta.(*clients.Client).KeyVault.ManagementClient
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
 	defer cancel()
@@ -741,6 +764,15 @@ func resourceKeyVaultCertificateRead(d *pluginsdk.ResourceData, meta interface{}
 		return nil
 	}
 
+	temporarilyAllowClientIP := meta.(*clients.Client).Features.KeyVault.TemporarilyAllowClientIPInNetworkAcls
+	return keyVaultsClient.WithNetworkAccess(ctx, *keyVaultId, temporarilyAllowClientIP, func() error {
+		return resourceKeyVaultCertificateReadWithinKeyVault(ctx, d, meta, *id, keyVaultId)
+	})
+}
+
+func resourceKeyVaultCertificateReadWithinKeyVault(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, id parse.NestedItemId, keyVaultId *commonids.KeyVaultId) error {
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+
 	cert, err := client.GetCertificate(ctx, id.KeyVaultBaseUrl, id.Name, "")
 	if err != nil {
 		if utils.ResponseWasNotFound(cert.Response) {
@@ -853,11 +885,10 @@ func resourceKeyVaultCertificateDelete(d *pluginsdk.ResourceData, meta interface
 		keyVaultUri: id.KeyVaultBaseUrl,
 		name:        id.Name,
 	}
-	if err := deleteAndOptionallyPurge(ctx, description, shouldPurge, deleter); err != nil {
-		return err
-	}
-
-	return nil
+	temporarilyAllowClientIP := meta.(*clients.Client).Features.KeyVault.TemporarilyAllowClientIPInNetworkAcls
+	return keyVaultsClient.WithNetworkAccess(ctx, *keyVaultId, temporarilyAllowClientIP, func() error {
+		return deleteAndOptionallyPurge(ctx, description, shouldPurge, deleter)
+	})
 }
 
 var _ deleteAndPurgeNestedItem = deleteAndPurgeCertificate{}
@@ -1191,12 +1222,20 @@ type KeyVaultCertificateImportParameters struct {
 	CertificatePassword string
 }
 
-func expandKeyVaultCertificate(v interface{}) KeyVaultCertificateImportParameters {
+func expandKeyVaultCertificate(v interface{}, contentType string) (*KeyVaultCertificateImportParameters, error) {
 	certs := v.([]interface{})
 	cert := certs[0].(map[string]interface{})
+	password := cert["password"].(string)
 
-	return KeyVaultCertificateImportParameters{
-		CertificateData:     cert["contents"].(string),
-		CertificatePassword: cert["password"].(string),
+	// validate the certificate bundle locally to surface clearer errors than Key Vault, converting it to the
+	// content type of the certificate policy where needed
+	contents, err := certificatebundle.Normalise(cert["contents"].(string), password, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("validating `certificate.0.contents`: %+v", err)
 	}
+
+	return &KeyVaultCertificateImportParameters{
+		CertificateData:     contents,
+		CertificatePassword: password,
+	}, nil
 }
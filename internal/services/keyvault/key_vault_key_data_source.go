@@ -9,9 +9,11 @@ import (
 	"crypto/rsa"
 	"encoding/base64"
 	"fmt"
+	"log"
 	"math/big"
 	"time"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
@@ -42,6 +44,12 @@ func dataSourceKeyVaultKey() *pluginsdk.Resource {
 
 			"key_vault_id": commonschema.ResourceIDReferenceRequired(&commonids.KeyVaultId{}),
 
+			"include_versions": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"key_type": {
 				Type:     pluginsdk.TypeString,
 				Computed: true,
@@ -115,6 +123,8 @@ func dataSourceKeyVaultKey() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"versions": keyVaultNestedItemVersionsSchema(),
+
 			"tags": tags.SchemaDataSource(),
 		},
 	}
@@ -137,12 +147,35 @@ func dataSourceKeyVaultKeyRead(d *pluginsdk.ResourceData, meta interface{}) erro
 		return fmt.Errorf("looking up Key %q vault url from id %q: %+v", name, keyVaultId, err)
 	}
 
-	resp, err := client.GetKey(ctx, *keyVaultBaseUri, name, "")
-	if err != nil {
-		if utils.ResponseWasNotFound(resp.Response) {
-			return fmt.Errorf("Key %q was not found in Key Vault at URI %q", name, *keyVaultBaseUri)
+	var resp keyvault.KeyBundle
+	var versions []keyVaultNestedItemVersion
+	includeVersions := d.Get("include_versions").(bool)
+	temporarilyAllowClientIP := meta.(*clients.Client).Features.KeyVault.TemporarilyAllowClientIPInNetworkAcls
+	err = keyVaultsClient.WithNetworkAccess(ctx, *keyVaultId, temporarilyAllowClientIP, func() error {
+		var err error
+		resp, err = client.GetKey(ctx, *keyVaultBaseUri, name, "")
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Key %q was not found in Key Vault at URI %q", name, *keyVaultBaseUri)
+			}
+
+			return err
 		}
 
+		if !includeVersions {
+			return nil
+		}
+
+		var versionsResp autorest.Response
+		versions, versionsResp, err = listKeyVaultKeyVersions(ctx, client, *keyVaultBaseUri, name)
+		if err != nil && keyVaultNestedItemVersionsNotPermitted(versionsResp, err) {
+			// listing the versions requires the `List` permission, which isn't needed to retrieve the Key itself
+			log.Printf("[DEBUG] Unable to list the versions of Key %q (Key Vault %q) since the client lacks the `List` permission: %+v", name, *keyVaultBaseUri, err)
+			return nil
+		}
+		return err
+	})
+	if err != nil {
 		return err
 	}
 
@@ -222,6 +255,10 @@ func dataSourceKeyVaultKeyRead(d *pluginsdk.ResourceData, meta interface{}) erro
 
 	d.Set("version", parsedId.Version)
 
+	if err := d.Set("versions", flattenKeyVaultNestedItemVersions(versions)); err != nil {
+		return fmt.Errorf("setting `versions`: %+v", err)
+	}
+
 	d.Set("resource_id", parse.NewKeyID(keyVaultId.SubscriptionId, keyVaultId.ResourceGroupName, keyVaultId.VaultName, parsedId.Name, parsedId.Version).ID())
 	d.Set("resource_versionless_id", parse.NewKeyVersionlessID(keyVaultId.SubscriptionId, keyVaultId.ResourceGroupName, keyVaultId.VaultName, parsedId.Name).ID())
 
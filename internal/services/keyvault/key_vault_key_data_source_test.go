@@ -27,6 +27,8 @@ func TestAccDataSourceKeyVaultKey_complete(t *testing.T) {
 				check.That(data.ResourceName).Key("tags.hello").HasValue("world"),
 				check.That(data.ResourceName).Key("resource_id").MatchesRegex(regexp.MustCompile(`^/subscriptions/[\w-]+/resourceGroups/[\w-]+/providers/Microsoft.KeyVault/vaults/[\w-]+/keys/[\w-]+/versions/[\w-]+$`)),
 				check.That(data.ResourceName).Key("resource_versionless_id").MatchesRegex(regexp.MustCompile(`^/subscriptions/[\w-]+/resourceGroups/[\w-]+/providers/Microsoft.KeyVault/vaults/[\w-]+/keys/[\w-]+$`)),
+				check.That(data.ResourceName).Key("versions.#").HasValue("1"),
+				check.That(data.ResourceName).Key("versions.0.version").IsSet(),
 			),
 		},
 	})
@@ -37,8 +39,9 @@ func (KeyVaultKeyDataSource) complete(data acceptance.TestData) string {
 %s
 
 data "azurerm_key_vault_key" "test" {
-  name         = azurerm_key_vault_key.test.name
-  key_vault_id = azurerm_key_vault.test.id
+  name             = azurerm_key_vault_key.test.name
+  key_vault_id     = azurerm_key_vault.test.id
+  include_versions = true
 }
 `, KeyVaultKeyResource{}.complete(data))
 }
@@ -5,27 +5,19 @@ package keyvault
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rsa"
-	"crypto/x509"
 	"encoding/base64"
-	"encoding/pem"
 	"fmt"
 	"log"
-	"math/big"
-	"strings"
 	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/date"
-	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
-	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/keys"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
 	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
@@ -34,7 +26,6 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
-	"golang.org/x/crypto/ssh"
 )
 
 func resourceKeyVaultKey() *pluginsdk.Resource {
@@ -141,67 +132,7 @@ func resourceKeyVaultKey() *pluginsdk.Resource {
 				ValidateFunc: validation.IsRFC3339Time,
 			},
 
-			"rotation_policy": {
-				Type:     pluginsdk.TypeList,
-				Optional: true,
-				MaxItems: 1,
-				Elem: &pluginsdk.Resource{
-					Schema: map[string]*pluginsdk.Schema{
-						"expire_after": {
-							Type:         pluginsdk.TypeString,
-							Optional:     true,
-							ValidateFunc: validate.ISO8601DurationBetween("P28D", "P100Y"),
-							AtLeastOneOf: []string{
-								"rotation_policy.0.expire_after",
-								"rotation_policy.0.automatic",
-							},
-							RequiredWith: []string{
-								"rotation_policy.0.expire_after",
-								"rotation_policy.0.notify_before_expiry",
-							},
-						},
-
-						// <= expiry_time - 7, >=7
-						"notify_before_expiry": {
-							Type:         pluginsdk.TypeString,
-							Optional:     true,
-							ValidateFunc: validate.ISO8601DurationBetween("P7D", "P36493D"),
-							RequiredWith: []string{
-								"rotation_policy.0.expire_after",
-								"rotation_policy.0.notify_before_expiry",
-							},
-						},
-
-						"automatic": {
-							Type:     pluginsdk.TypeList,
-							Optional: true,
-							MaxItems: 1,
-							Elem: &pluginsdk.Resource{
-								Schema: map[string]*pluginsdk.Schema{
-									"time_after_creation": {
-										Type:         pluginsdk.TypeString,
-										Optional:     true,
-										ValidateFunc: validate.ISO8601Duration,
-										AtLeastOneOf: []string{
-											"rotation_policy.0.automatic.0.time_after_creation",
-											"rotation_policy.0.automatic.0.time_before_expiry",
-										},
-									},
-									"time_before_expiry": {
-										Type:         pluginsdk.TypeString,
-										Optional:     true,
-										ValidateFunc: validate.ISO8601Duration,
-										AtLeastOneOf: []string{
-											"rotation_policy.0.automatic.0.time_after_creation",
-											"rotation_policy.0.automatic.0.time_before_expiry",
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
+			"rotation_policy": keys.RotationPolicySchema(),
 
 			// Computed
 			"version": {
@@ -287,7 +218,6 @@ func resourceKeyVaultKey() *pluginsdk.Resource {
 
 func resourceKeyVaultKeyCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	keyVaultsClient := meta.(*clients.Client).KeyVault
-	client := meta.(*clients.Client).KeyVault.ManagementClient
 	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -303,6 +233,15 @@ func resourceKeyVaultKeyCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 		return fmt.Errorf("looking up Key %q vault url from id %q: %+v", name, *keyVaultId, err)
 	}
 
+	temporarilyAllowClientIP := meta.(*clients.Client).Features.KeyVault.TemporarilyAllowClientIPInNetworkAcls
+	return keyVaultsClient.WithNetworkAccess(ctx, *keyVaultId, temporarilyAllowClientIP, func() error {
+		return resourceKeyVaultKeyCreateWithinKeyVault(ctx, d, meta, name, keyVaultId, keyVaultBaseUri)
+	})
+}
+
+func resourceKeyVaultKeyCreateWithinKeyVault(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, name string, keyVaultId *commonids.KeyVaultId, keyVaultBaseUri *string) error {
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+
 	existing, err := client.GetKey(ctx, *keyVaultBaseUri, name, "")
 	if err != nil {
 		if !utils.ResponseWasNotFound(existing.Response) {
@@ -315,7 +254,7 @@ func resourceKeyVaultKeyCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 	}
 
 	keyType := d.Get("key_type").(string)
-	keyOptions := expandKeyVaultKeyOptions(d)
+	keyOptions := keys.ExpandKeyOptions(d.Get("key_opts").([]interface{}))
 	t := d.Get("tags").(map[string]interface{})
 
 	// TODO: support Importing Keys once this is fixed:
@@ -384,7 +323,7 @@ func resourceKeyVaultKeyCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 	}
 
 	if v, ok := d.GetOk("rotation_policy"); ok {
-		if respPolicy, err := client.UpdateKeyRotationPolicy(ctx, *keyVaultBaseUri, name, expandKeyVaultKeyRotationPolicy(v.([]interface{}))); err != nil {
+		if respPolicy, err := client.UpdateKeyRotationPolicy(ctx, *keyVaultBaseUri, name, keys.ExpandRotationPolicy(v.([]interface{}))); err != nil {
 			if utils.ResponseWasForbidden(respPolicy.Response) {
 				return fmt.Errorf("current client lacks permissions to create Key Rotation Policy for Key %q (%q, Vault url: %q), please update this as described here: %s : %v", name, *keyVaultId, *keyVaultBaseUri, "https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/resources/key_vault_key#example-usage", err)
 			}
@@ -438,7 +377,7 @@ func resourceKeyVaultKeyUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		return nil
 	}
 
-	keyOptions := expandKeyVaultKeyOptions(d)
+	keyOptions := keys.ExpandKeyOptions(d.Get("key_opts").([]interface{}))
 	t := d.Get("tags").(map[string]interface{})
 
 	parameters := keyvault.KeyUpdateParameters{
@@ -461,17 +400,25 @@ func resourceKeyVaultKeyUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		parameters.KeyAttributes.Expires = &expirationUnixTime
 	}
 
-	if _, err = client.UpdateKey(ctx, id.KeyVaultBaseUrl, id.Name, "", parameters); err != nil {
-		return err
-	}
+	temporarilyAllowClientIP := meta.(*clients.Client).Features.KeyVault.TemporarilyAllowClientIPInNetworkAcls
+	err = keyVaultsClient.WithNetworkAccess(ctx, *keyVaultId, temporarilyAllowClientIP, func() error {
+		if _, err := client.UpdateKey(ctx, id.KeyVaultBaseUrl, id.Name, "", parameters); err != nil {
+			return err
+		}
 
-	if d.HasChange("rotation_policy"); ok {
-		if respPolicy, err := client.UpdateKeyRotationPolicy(ctx, id.KeyVaultBaseUrl, id.Name, expandKeyVaultKeyRotationPolicy(d.Get("rotation_policy").([]interface{}))); err != nil {
-			if utils.ResponseWasForbidden(respPolicy.Response) {
-				return fmt.Errorf("current client lacks permissions to update Key Rotation Policy for Key %q (%q, Vault url: %q), please update this as described here: %s : %v", id.Name, *keyVaultId, id.KeyVaultBaseUrl, "https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/resources/key_vault_key#example-usage", err)
+		if d.HasChange("rotation_policy"); ok {
+			if respPolicy, err := client.UpdateKeyRotationPolicy(ctx, id.KeyVaultBaseUrl, id.Name, keys.ExpandRotationPolicy(d.Get("rotation_policy").([]interface{}))); err != nil {
+				if utils.ResponseWasForbidden(respPolicy.Response) {
+					return fmt.Errorf("current client lacks permissions to update Key Rotation Policy for Key %q (%q, Vault url: %q), please update this as described here: %s : %v", id.Name, *keyVaultId, id.KeyVaultBaseUrl, "https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/resources/key_vault_key#example-usage", err)
+				}
+				return fmt.Errorf("creating Key Rotation Policy: %+v", err)
 			}
-			return fmt.Errorf("creating Key Rotation Policy: %+v", err)
 		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	return resourceKeyVaultKeyRead(d, meta)
@@ -479,7 +426,6 @@ func resourceKeyVaultKeyUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 
 func resourceKeyVaultKeyRead(d *pluginsdk.ResourceData, meta interface{}) error {
 	keyVaultsClient := meta.(*clients.Client).KeyVault
-	client := meta.(*clients.Client).KeyVault.ManagementClient
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
 	defer cancel()
@@ -514,6 +460,17 @@ func resourceKeyVaultKeyRead(d *pluginsdk.ResourceData, meta interface{}) error
 		return nil
 	}
 
+	// both the Key and its Rotation Policy are retrieved from the Data Plane API, so both need to be within
+	// the (optional) temporary network access
+	temporarilyAllowClientIP := meta.(*clients.Client).Features.KeyVault.TemporarilyAllowClientIPInNetworkAcls
+	return keyVaultsClient.WithNetworkAccess(ctx, *keyVaultId, temporarilyAllowClientIP, func() error {
+		return resourceKeyVaultKeyReadWithinKeyVault(ctx, d, meta, *id, keyVaultId)
+	})
+}
+
+func resourceKeyVaultKeyReadWithinKeyVault(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, id parse.NestedItemId, keyVaultId *commonids.KeyVaultId) error {
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+
 	resp, err := client.GetKey(ctx, id.KeyVaultBaseUrl, id.Name, "")
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
@@ -530,7 +487,7 @@ func resourceKeyVaultKeyRead(d *pluginsdk.ResourceData, meta interface{}) error
 	if key := resp.Key; key != nil {
 		d.Set("key_type", string(key.Kty))
 
-		options := flattenKeyVaultKeyOptions(key.KeyOps)
+		options := keys.FlattenKeyOptions(key.KeyOps)
 		if err := d.Set("key_opts", options); err != nil {
 			return err
 		}
@@ -564,51 +521,14 @@ func resourceKeyVaultKeyRead(d *pluginsdk.ResourceData, meta interface{}) error
 	d.Set("version", id.Version)
 	d.Set("versionless_id", id.VersionlessID())
 	if key := resp.Key; key != nil {
-		if key.Kty == keyvault.JSONWebKeyTypeRSA || key.Kty == keyvault.JSONWebKeyTypeRSAHSM {
-			nBytes, err := base64.RawURLEncoding.DecodeString(*key.N)
-			if err != nil {
-				return fmt.Errorf("failed to decode N: %+v", err)
-			}
-			eBytes, err := base64.RawURLEncoding.DecodeString(*key.E)
-			if err != nil {
-				return fmt.Errorf("failed to decode E: %+v", err)
-			}
-			publicKey := &rsa.PublicKey{
-				N: big.NewInt(0).SetBytes(nBytes),
-				E: int(big.NewInt(0).SetBytes(eBytes).Uint64()),
-			}
-			err = readPublicKey(d, publicKey)
-			if err != nil {
+		publicKey, err := keys.PublicKey(*key)
+		if err != nil {
+			return err
+		}
+		if publicKey != nil {
+			if err := readPublicKey(d, publicKey); err != nil {
 				return fmt.Errorf("failed to read public key: %+v", err)
 			}
-		} else if key.Kty == keyvault.JSONWebKeyTypeEC || key.Kty == keyvault.JSONWebKeyTypeECHSM {
-			// do ec keys
-			xBytes, err := base64.RawURLEncoding.DecodeString(*key.X)
-			if err != nil {
-				return fmt.Errorf("failed to decode X: %+v", err)
-			}
-			yBytes, err := base64.RawURLEncoding.DecodeString(*key.Y)
-			if err != nil {
-				return fmt.Errorf("failed to decode Y: %+v", err)
-			}
-			publicKey := &ecdsa.PublicKey{
-				X: big.NewInt(0).SetBytes(xBytes),
-				Y: big.NewInt(0).SetBytes(yBytes),
-			}
-			switch key.Crv {
-			case keyvault.JSONWebKeyCurveNameP256:
-				publicKey.Curve = elliptic.P256()
-			case keyvault.JSONWebKeyCurveNameP384:
-				publicKey.Curve = elliptic.P384()
-			case keyvault.JSONWebKeyCurveNameP521:
-				publicKey.Curve = elliptic.P521()
-			}
-			if publicKey.Curve != nil {
-				err = readPublicKey(d, publicKey)
-				if err != nil {
-					return fmt.Errorf("failed to read public key: %+v", err)
-				}
-			}
 		}
 	}
 
@@ -628,7 +548,7 @@ func resourceKeyVaultKeyRead(d *pluginsdk.ResourceData, meta interface{}) error
 		}
 	}
 
-	rotationPolicy := flattenKeyVaultKeyRotationPolicy(respPolicy)
+	rotationPolicy := keys.FlattenRotationPolicy(respPolicy)
 	if err := d.Set("rotation_policy", rotationPolicy); err != nil {
 		return fmt.Errorf("setting Key Vault Key Rotation Policy: %+v", err)
 	}
@@ -683,11 +603,10 @@ func resourceKeyVaultKeyDelete(d *pluginsdk.ResourceData, meta interface{}) erro
 		keyVaultUri: id.KeyVaultBaseUrl,
 		name:        id.Name,
 	}
-	if err := deleteAndOptionallyPurge(ctx, description, shouldPurge, deleter); err != nil {
-		return err
-	}
-
-	return nil
+	temporarilyAllowClientIP := meta.(*clients.Client).Features.KeyVault.TemporarilyAllowClientIPInNetworkAcls
+	return keyVaultsClient.WithNetworkAccess(ctx, *keyVaultId, temporarilyAllowClientIP, func() error {
+		return deleteAndOptionallyPurge(ctx, description, shouldPurge, deleter)
+	})
 }
 
 var _ deleteAndPurgeNestedItem = deleteAndPurgeKey{}
@@ -717,142 +636,13 @@ func (d deleteAndPurgeKey) NestedItemHasBeenPurged(ctx context.Context) (autores
 	return resp.Response, err
 }
 
-func expandKeyVaultKeyOptions(d *pluginsdk.ResourceData) *[]keyvault.JSONWebKeyOperation {
-	options := d.Get("key_opts").([]interface{})
-	results := make([]keyvault.JSONWebKeyOperation, 0, len(options))
-
-	for _, option := range options {
-		results = append(results, keyvault.JSONWebKeyOperation(option.(string)))
-	}
-
-	return &results
-}
-
-func expandKeyVaultKeyRotationPolicy(v []interface{}) keyvault.KeyRotationPolicy {
-	if len(v) == 0 {
-		return keyvault.KeyRotationPolicy{LifetimeActions: &[]keyvault.LifetimeActions{}}
-	}
-
-	policy := v[0].(map[string]interface{})
-
-	var expiryTime *string = nil // needs to be set to nil if not set
-	if rawExpiryTime := policy["expire_after"]; rawExpiryTime != nil && rawExpiryTime.(string) != "" {
-		expiryTime = utils.String(rawExpiryTime.(string))
-	}
-
-	lifetimeActions := make([]keyvault.LifetimeActions, 0)
-	if rawNotificationTime := policy["notify_before_expiry"]; rawNotificationTime != nil && rawNotificationTime.(string) != "" {
-		lifetimeActionNotify := keyvault.LifetimeActions{
-			Trigger: &keyvault.LifetimeActionsTrigger{
-				TimeBeforeExpiry: utils.String(rawNotificationTime.(string)), // for Type: keyvault.Notify always TimeBeforeExpiry
-			},
-			Action: &keyvault.LifetimeActionsType{
-				Type: keyvault.ActionTypeNotify,
-			},
-		}
-		lifetimeActions = append(lifetimeActions, lifetimeActionNotify)
-	}
-
-	if autoRotationList := policy["automatic"].([]interface{}); len(autoRotationList) == 1 && autoRotationList[0] != nil {
-		lifetimeActionRotate := keyvault.LifetimeActions{
-			Action: &keyvault.LifetimeActionsType{
-				Type: keyvault.ActionTypeRotate,
-			},
-			Trigger: &keyvault.LifetimeActionsTrigger{},
-		}
-		autoRotationRaw := autoRotationList[0].(map[string]interface{})
-
-		if v := autoRotationRaw["time_after_creation"]; v != nil && v.(string) != "" {
-			timeAfterCreate := v.(string)
-			lifetimeActionRotate.Trigger.TimeAfterCreate = &timeAfterCreate
-		}
-
-		if v := autoRotationRaw["time_before_expiry"]; v != nil && v.(string) != "" {
-			timeBeforeExpiry := v.(string)
-			lifetimeActionRotate.Trigger.TimeBeforeExpiry = &timeBeforeExpiry
-		}
-
-		lifetimeActions = append(lifetimeActions, lifetimeActionRotate)
-	}
-
-	return keyvault.KeyRotationPolicy{
-		LifetimeActions: &lifetimeActions,
-		Attributes: &keyvault.KeyRotationPolicyAttributes{
-			ExpiryTime: expiryTime,
-		},
-	}
-}
-
-func flattenKeyVaultKeyOptions(input *[]string) []interface{} {
-	results := make([]interface{}, 0, len(*input))
-
-	for _, option := range *input {
-		results = append(results, option)
-	}
-
-	return results
-}
-
-func flattenKeyVaultKeyRotationPolicy(input keyvault.KeyRotationPolicy) []interface{} {
-	if input.LifetimeActions == nil && input.Attributes == nil {
-		return []interface{}{}
-	}
-
-	policy := make(map[string]interface{})
-	if input.Attributes != nil && input.Attributes.ExpiryTime != nil && *input.Attributes.ExpiryTime != "" {
-		policy["expire_after"] = *input.Attributes.ExpiryTime
-	}
-
-	if input.LifetimeActions != nil {
-		for _, ltAction := range *input.LifetimeActions {
-			action := ltAction.Action
-			trigger := ltAction.Trigger
-
-			if action != nil && trigger != nil && action.Type != "" && strings.EqualFold(string(action.Type), string(keyvault.ActionTypeNotify)) && trigger.TimeBeforeExpiry != nil && *trigger.TimeBeforeExpiry != "" {
-				// Somehow a default is set after creation for notify_before_expiry
-				// Submitting this set value in the next run will not work though..
-				if policy["expire_after"] != nil {
-					policy["notify_before_expiry"] = *trigger.TimeBeforeExpiry
-				}
-			}
-
-			if action != nil && trigger != nil && action.Type != "" && strings.EqualFold(string(action.Type), string(keyvault.ActionTypeRotate)) {
-				autoRotation := make(map[string]interface{}, 0)
-				autoRotation["time_after_creation"] = pointer.From(trigger.TimeAfterCreate)
-				autoRotation["time_before_expiry"] = pointer.From(trigger.TimeBeforeExpiry)
-				policy["automatic"] = []map[string]interface{}{autoRotation}
-			}
-		}
-	}
-
-	if len(policy) == 0 {
-		return []interface{}{}
-	}
-
-	return []interface{}{policy}
-}
-
-// Credit to Hashicorp modified from https://github.com/hashicorp/terraform-provider-tls/blob/v3.1.0/internal/provider/util.go#L79-L105
 func readPublicKey(d *pluginsdk.ResourceData, pubKey interface{}) error {
-	pubKeyBytes, err := x509.MarshalPKIXPublicKey(pubKey)
+	publicKeyPem, publicKeyOpenSSH, err := keys.EncodePublicKey(pubKey)
 	if err != nil {
-		return fmt.Errorf("failed to marshal public key error: %s", err)
-	}
-	pubKeyPemBlock := &pem.Block{
-		Type:  "PUBLIC KEY",
-		Bytes: pubKeyBytes,
+		return err
 	}
 
-	d.Set("public_key_pem", string(pem.EncodeToMemory(pubKeyPemBlock)))
-
-	sshPubKey, err := ssh.NewPublicKey(pubKey)
-	if err == nil {
-		// Not all EC types can be SSH keys, so we'll produce this only
-		// if an appropriate type was selected.
-		sshPubKeyBytes := ssh.MarshalAuthorizedKey(sshPubKey)
-		d.Set("public_key_openssh", string(sshPubKeyBytes))
-	} else {
-		d.Set("public_key_openssh", "")
-	}
+	d.Set("public_key_pem", publicKeyPem)
+	d.Set("public_key_openssh", publicKeyOpenSSH)
 	return nil
 }
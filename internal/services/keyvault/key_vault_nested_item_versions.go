@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/date"
+	keyVaultClient "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+type keyVaultNestedItemVersion struct {
+	id        string
+	version   string
+	enabled   bool
+	created   *time.Time
+	notBefore *time.Time
+	expires   *time.Time
+}
+
+func (v keyVaultNestedItemVersion) hasExpired(now time.Time) bool {
+	return v.expires != nil && !v.expires.After(now)
+}
+
+func keyVaultNestedItemVersionsSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Computed: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"id": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"version": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"enabled": {
+					Type:     pluginsdk.TypeBool,
+					Computed: true,
+				},
+
+				"created_date": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"not_before_date": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"expiration_date": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// listKeyVaultSecretVersions returns all versions of the specified Secret, ordered from the newest to the oldest - the
+// response for the first page is returned so that callers can check whether the client lacks the `List` permission
+func listKeyVaultSecretVersions(ctx context.Context, client *keyvault.BaseClient, keyVaultBaseUri, name string) ([]keyVaultNestedItemVersion, autorest.Response, error) {
+	iterator, err := client.GetSecretVersionsComplete(ctx, keyVaultBaseUri, name, utils.Int32(25))
+	if err != nil {
+		return nil, iterator.Response().Response, fmt.Errorf("listing versions of Secret %q (Key Vault %q): %+v", name, keyVaultBaseUri, err)
+	}
+
+	versions := make([]keyVaultNestedItemVersion, 0)
+	for iterator.NotDone() {
+		item := iterator.Value()
+		if item.ID != nil {
+			version, err := newKeyVaultNestedItemVersion(*item.ID)
+			if err != nil {
+				return nil, iterator.Response().Response, err
+			}
+			if attributes := item.Attributes; attributes != nil {
+				version.enabled = utils.NormaliseNilableBool(attributes.Enabled)
+				version.created = keyVaultNestedItemVersionTime(attributes.Created)
+				version.notBefore = keyVaultNestedItemVersionTime(attributes.NotBefore)
+				version.expires = keyVaultNestedItemVersionTime(attributes.Expires)
+			}
+			versions = append(versions, *version)
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return nil, iterator.Response().Response, fmt.Errorf("listing versions of Secret %q (Key Vault %q): %+v", name, keyVaultBaseUri, err)
+		}
+	}
+
+	sortKeyVaultNestedItemVersions(versions)
+	return versions, iterator.Response().Response, nil
+}
+
+// listKeyVaultKeyVersions returns all versions of the specified Key, ordered from the newest to the oldest - the
+// response for the first page is returned so that callers can check whether the client lacks the `List` permission
+func listKeyVaultKeyVersions(ctx context.Context, client *keyvault.BaseClient, keyVaultBaseUri, name string) ([]keyVaultNestedItemVersion, autorest.Response, error) {
+	iterator, err := client.GetKeyVersionsComplete(ctx, keyVaultBaseUri, name, utils.Int32(25))
+	if err != nil {
+		return nil, iterator.Response().Response, fmt.Errorf("listing versions of Key %q (Key Vault %q): %+v", name, keyVaultBaseUri, err)
+	}
+
+	versions := make([]keyVaultNestedItemVersion, 0)
+	for iterator.NotDone() {
+		item := iterator.Value()
+		if item.Kid != nil {
+			version, err := newKeyVaultNestedItemVersion(*item.Kid)
+			if err != nil {
+				return nil, iterator.Response().Response, err
+			}
+			if attributes := item.Attributes; attributes != nil {
+				version.enabled = utils.NormaliseNilableBool(attributes.Enabled)
+				version.created = keyVaultNestedItemVersionTime(attributes.Created)
+				version.notBefore = keyVaultNestedItemVersionTime(attributes.NotBefore)
+				version.expires = keyVaultNestedItemVersionTime(attributes.Expires)
+			}
+			versions = append(versions, *version)
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return nil, iterator.Response().Response, fmt.Errorf("listing versions of Key %q (Key Vault %q): %+v", name, keyVaultBaseUri, err)
+		}
+	}
+
+	sortKeyVaultNestedItemVersions(versions)
+	return versions, iterator.Response().Response, nil
+}
+
+// keyVaultNestedItemVersionsNotPermitted returns whether listing the versions was rejected since the client lacks the
+// `List` permission - requests rejected by the Key Vault's firewall are also a 403 but aren't a permissions issue
+func keyVaultNestedItemVersionsNotPermitted(resp autorest.Response, err error) bool {
+	return utils.ResponseWasForbidden(resp) && keyVaultClient.ParseForbiddenByFirewallError(err) == nil
+}
+
+func newKeyVaultNestedItemVersion(input string) (*keyVaultNestedItemVersion, error) {
+	id, err := parse.ParseNestedItemID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keyVaultNestedItemVersion{
+		id:      id.ID(),
+		version: id.Version,
+	}, nil
+}
+
+func keyVaultNestedItemVersionTime(input *date.UnixTime) *time.Time {
+	if input == nil {
+		return nil
+	}
+	t := time.Time(*input)
+	return &t
+}
+
+func sortKeyVaultNestedItemVersions(input []keyVaultNestedItemVersion) {
+	sort.SliceStable(input, func(i, j int) bool {
+		// versions without a creation date (which shouldn't happen) are sorted last
+		if input[i].created == nil || input[j].created == nil {
+			return input[i].created != nil
+		}
+		return input[i].created.After(*input[j].created)
+	})
+}
+
+func flattenKeyVaultNestedItemVersions(input []keyVaultNestedItemVersion) []interface{} {
+	output := make([]interface{}, 0)
+	for _, v := range input {
+		output = append(output, map[string]interface{}{
+			"id":              v.id,
+			"version":         v.version,
+			"enabled":         v.enabled,
+			"created_date":    flattenKeyVaultNestedItemVersionTime(v.created),
+			"not_before_date": flattenKeyVaultNestedItemVersionTime(v.notBefore),
+			"expiration_date": flattenKeyVaultNestedItemVersionTime(v.expires),
+		})
+	}
+	return output
+}
+
+func flattenKeyVaultNestedItemVersionTime(input *time.Time) string {
+	if input == nil {
+		return ""
+	}
+	return input.Format(time.RFC3339)
+}
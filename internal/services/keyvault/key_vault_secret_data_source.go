@@ -5,8 +5,10 @@ package keyvault
 
 import (
 	"fmt"
+	"log"
 	"time"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
@@ -16,6 +18,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
 )
 
 func dataSourceKeyVaultSecret() *pluginsdk.Resource {
@@ -36,6 +39,12 @@ func dataSourceKeyVaultSecret() *pluginsdk.Resource {
 
 			"key_vault_id": commonschema.ResourceIDReferenceRequired(&commonids.KeyVaultId{}),
 
+			"include_versions": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"value": {
 				Type:      pluginsdk.TypeString,
 				Computed:  true,
@@ -77,6 +86,8 @@ func dataSourceKeyVaultSecret() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"versions": keyVaultNestedItemVersionsSchema(),
+
 			"tags": tags.SchemaDataSource(),
 		},
 	}
@@ -100,12 +111,35 @@ func dataSourceKeyVaultSecretRead(d *pluginsdk.ResourceData, meta interface{}) e
 		return fmt.Errorf("looking up Secret %q vault url from id %q: %+v", name, *keyVaultId, err)
 	}
 
-	resp, err := client.GetSecret(ctx, *keyVaultBaseUri, name, version)
-	if err != nil {
-		if utils.ResponseWasNotFound(resp.Response) {
-			return fmt.Errorf("KeyVault Secret %q (KeyVault URI %q) does not exist", name, *keyVaultBaseUri)
+	var resp keyvault.SecretBundle
+	var versions []keyVaultNestedItemVersion
+	includeVersions := d.Get("include_versions").(bool)
+	temporarilyAllowClientIP := meta.(*clients.Client).Features.KeyVault.TemporarilyAllowClientIPInNetworkAcls
+	err = keyVaultsClient.WithNetworkAccess(ctx, *keyVaultId, temporarilyAllowClientIP, func() error {
+		var err error
+		resp, err = client.GetSecret(ctx, *keyVaultBaseUri, name, version)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("KeyVault Secret %q (KeyVault URI %q) does not exist", name, *keyVaultBaseUri)
+			}
+			return fmt.Errorf("making Read request on Azure KeyVault Secret %s: %+v", name, err)
+		}
+
+		if !includeVersions {
+			return nil
 		}
-		return fmt.Errorf("making Read request on Azure KeyVault Secret %s: %+v", name, err)
+
+		var versionsResp autorest.Response
+		versions, versionsResp, err = listKeyVaultSecretVersions(ctx, client, *keyVaultBaseUri, name)
+		if err != nil && keyVaultNestedItemVersionsNotPermitted(versionsResp, err) {
+			// listing the versions requires the `List` permission, which isn't needed to retrieve the Secret itself
+			log.Printf("[DEBUG] Unable to list the versions of Secret %q (Key Vault %q) since the client lacks the `List` permission: %+v", name, *keyVaultBaseUri, err)
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return err
 	}
 
 	// the version may have changed, so parse the updated id
@@ -131,6 +165,10 @@ func dataSourceKeyVaultSecretRead(d *pluginsdk.ResourceData, meta interface{}) e
 	}
 	d.Set("versionless_id", respID.VersionlessID())
 
+	if err := d.Set("versions", flattenKeyVaultNestedItemVersions(versions)); err != nil {
+		return fmt.Errorf("setting `versions`: %+v", err)
+	}
+
 	d.Set("resource_id", parse.NewSecretID(keyVaultId.SubscriptionId, keyVaultId.ResourceGroupName, keyVaultId.VaultName, respID.Name, respID.Version).ID())
 	d.Set("resource_versionless_id", parse.NewSecretVersionlessID(keyVaultId.SubscriptionId, keyVaultId.ResourceGroupName, keyVaultId.VaultName, respID.Name).ID())
 
@@ -26,6 +26,7 @@ func TestAccDataSourceKeyVaultSecret_basic(t *testing.T) {
 				check.That(data.ResourceName).Key("tags.%").HasValue("0"),
 				check.That(data.ResourceName).Key("resource_id").MatchesRegex(regexp.MustCompile(`^/subscriptions/[\w-]+/resourceGroups/[\w-]+/providers/Microsoft.KeyVault/vaults/[\w-]+/secrets/[\w-]+/versions/[\w-]+$`)),
 				check.That(data.ResourceName).Key("resource_versionless_id").MatchesRegex(regexp.MustCompile(`^/subscriptions/[\w-]+/resourceGroups/[\w-]+/providers/Microsoft.KeyVault/vaults/[\w-]+/secrets/[\w-]+$`)),
+				check.That(data.ResourceName).Key("versions.#").HasValue("0"),
 			),
 		},
 	})
@@ -45,6 +46,8 @@ func TestAccDataSourceKeyVaultSecret_complete(t *testing.T) {
 				check.That(data.ResourceName).Key("versionless_id").HasValue(fmt.Sprintf("https://acctestkv-%s.vault.azure.net/secrets/secret-%s", data.RandomString, data.RandomString)),
 				check.That(data.ResourceName).Key("not_before_date").HasValue("2019-01-01T01:02:03Z"),
 				check.That(data.ResourceName).Key("expiration_date").HasValue("2020-01-01T01:02:03Z"),
+				check.That(data.ResourceName).Key("versions.#").HasValue("1"),
+				check.That(data.ResourceName).Key("versions.0.enabled").HasValue("true"),
 			),
 		},
 	})
@@ -93,8 +96,9 @@ func (KeyVaultSecretDataSource) complete(data acceptance.TestData) string {
 %s
 
 data "azurerm_key_vault_secret" "test" {
-  name         = azurerm_key_vault_secret.test.name
-  key_vault_id = azurerm_key_vault.test.id
+  name             = azurerm_key_vault_secret.test.name
+  key_vault_id     = azurerm_key_vault.test.id
+  include_versions = true
 }
 `, KeyVaultSecretResource{}.complete(data))
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
 	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
@@ -23,6 +24,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/rickb777/date/period"
 	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
 )
 
@@ -73,9 +75,37 @@ func resourceKeyVaultSecret() *pluginsdk.Resource {
 			},
 
 			"expiration_date": {
-				Type:         pluginsdk.TypeString,
-				Optional:     true,
-				ValidateFunc: validation.IsRFC3339Time,
+				Type:          pluginsdk.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.IsRFC3339Time,
+				ConflictsWith: []string{"rotation_policy"},
+			},
+
+			"rotation_policy": {
+				Type:          pluginsdk.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"expiration_date"},
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"expire_after": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validate.ISO8601DurationBetween("P1D", "P100Y"),
+						},
+
+						"rotate_before_expiry": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.ISO8601Duration,
+						},
+					},
+				},
+			},
+
+			"rotation_date": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
 			},
 
 			"version": {
@@ -100,12 +130,21 @@ func resourceKeyVaultSecret() *pluginsdk.Resource {
 
 			"tags": tags.SchemaWithMax(15),
 		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(func(ctx context.Context, d *pluginsdk.ResourceDiff, v interface{}) error {
+			// when a Rotation Policy is specified the expiration date (and so the rotation date) is calculated for each new version of the Secret
+			if _, ok := d.GetOk("rotation_policy"); ok && (d.HasChange("value") || d.HasChange("rotation_policy")) {
+				if err := d.SetNewComputed("rotation_date"); err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
 	}
 }
 
 func resourceKeyVaultSecretCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	keyVaultsClient := meta.(*clients.Client).KeyVault
-	client := meta.(*clients.Client).KeyVault.ManagementClient
 	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -122,6 +161,15 @@ func resourceKeyVaultSecretCreate(d *pluginsdk.ResourceData, meta interface{}) e
 		return fmt.Errorf("looking up Secret %q vault url from id %q: %+v", name, *keyVaultId, err)
 	}
 
+	temporarilyAllowClientIP := meta.(*clients.Client).Features.KeyVault.TemporarilyAllowClientIPInNetworkAcls
+	return keyVaultsClient.WithNetworkAccess(ctx, *keyVaultId, temporarilyAllowClientIP, func() error {
+		return resourceKeyVaultSecretCreateWithinKeyVault(ctx, d, meta, name, keyVaultBaseUrl)
+	})
+}
+
+func resourceKeyVaultSecretCreateWithinKeyVault(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, name string, keyVaultBaseUrl *string) error {
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+
 	existing, err := client.GetSecret(ctx, *keyVaultBaseUrl, name, "")
 	if err != nil {
 		if !utils.ResponseWasNotFound(existing.Response) {
@@ -156,6 +204,15 @@ func resourceKeyVaultSecretCreate(d *pluginsdk.ResourceData, meta interface{}) e
 		parameters.SecretAttributes.Expires = &expirationUnixTime
 	}
 
+	if v, ok := d.GetOk("rotation_policy"); ok {
+		expirationDate, err := keyVaultSecretRotationPolicyExpirationDate(v.([]interface{}), time.Now())
+		if err != nil {
+			return err
+		}
+		expirationUnixTime := date.UnixTime(*expirationDate)
+		parameters.SecretAttributes.Expires = &expirationUnixTime
+	}
+
 	if resp, err := client.SetSecret(ctx, *keyVaultBaseUrl, name, parameters); err != nil {
 		// In the case that the Secret already exists in a Soft Deleted / Recoverable state we check if `recover_soft_deleted_key_vaults` is set
 		// and attempt recovery where appropriate
@@ -215,7 +272,6 @@ func resourceKeyVaultSecretCreate(d *pluginsdk.ResourceData, meta interface{}) e
 
 func resourceKeyVaultSecretUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 	keyVaultsClient := meta.(*clients.Client).KeyVault
-	client := meta.(*clients.Client).KeyVault.ManagementClient
 	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 	log.Print("[INFO] preparing arguments for AzureRM KeyVault Secret update.")
@@ -242,6 +298,15 @@ func resourceKeyVaultSecretUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		return nil
 	}
 
+	temporarilyAllowClientIP := meta.(*clients.Client).Features.KeyVault.TemporarilyAllowClientIPInNetworkAcls
+	return keyVaultsClient.WithNetworkAccess(ctx, *keyVaultId, temporarilyAllowClientIP, func() error {
+		return resourceKeyVaultSecretUpdateWithinKeyVault(ctx, d, meta, *id)
+	})
+}
+
+func resourceKeyVaultSecretUpdateWithinKeyVault(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, id parse.NestedItemId) error {
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+
 	value := d.Get("value").(string)
 	contentType := d.Get("content_type").(string)
 	t := d.Get("tags").(map[string]interface{})
@@ -260,7 +325,23 @@ func resourceKeyVaultSecretUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		secretAttributes.Expires = &expirationUnixTime
 	}
 
-	if d.HasChange("value") {
+	if v, ok := d.GetOk("rotation_policy"); ok && (d.HasChange("value") || d.HasChange("rotation_policy")) {
+		// the expiration date of the new version (or the current version, when the Rotation Policy changes) is
+		// calculated from when it's written
+		expirationDate, err := keyVaultSecretRotationPolicyExpirationDate(v.([]interface{}), time.Now())
+		if err != nil {
+			return err
+		}
+		expirationUnixTime := date.UnixTime(*expirationDate)
+		secretAttributes.Expires = &expirationUnixTime
+	}
+
+	// the expiration date can't be removed from an existing version of the secret, as such a new version is created without one
+	_, hasExpirationDate := d.GetOk("expiration_date")
+	_, hasRotationPolicy := d.GetOk("rotation_policy")
+	removeExpirationDate := !hasExpirationDate && !hasRotationPolicy && (d.HasChange("expiration_date") || d.HasChange("rotation_policy"))
+
+	if d.HasChange("value") || removeExpirationDate {
 		// for changing the value of the secret we need to create a new version
 		parameters := keyvault.SecretSetParameters{
 			Value:            utils.String(value),
@@ -269,7 +350,7 @@ func resourceKeyVaultSecretUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 			SecretAttributes: secretAttributes,
 		}
 
-		if _, err = client.SetSecret(ctx, id.KeyVaultBaseUrl, id.Name, parameters); err != nil {
+		if _, err := client.SetSecret(ctx, id.KeyVaultBaseUrl, id.Name, parameters); err != nil {
 			return err
 		}
 	} else {
@@ -279,7 +360,7 @@ func resourceKeyVaultSecretUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 			SecretAttributes: secretAttributes,
 		}
 
-		if _, err = client.UpdateSecret(ctx, id.KeyVaultBaseUrl, id.Name, "", parameters); err != nil {
+		if _, err := client.UpdateSecret(ctx, id.KeyVaultBaseUrl, id.Name, "", parameters); err != nil {
 			return err
 		}
 	}
@@ -339,7 +420,12 @@ func resourceKeyVaultSecretRead(d *pluginsdk.ResourceData, meta interface{}) err
 	}
 
 	// we always want to get the latest version
-	resp, err := client.GetSecret(ctx, id.KeyVaultBaseUrl, id.Name, "")
+	var resp keyvault.SecretBundle
+	temporarilyAllowClientIP := meta.(*clients.Client).Features.KeyVault.TemporarilyAllowClientIPInNetworkAcls
+	err = keyVaultsClient.WithNetworkAccess(ctx, *keyVaultId, temporarilyAllowClientIP, func() (err error) {
+		resp, err = client.GetSecret(ctx, id.KeyVaultBaseUrl, id.Name, "")
+		return err
+	})
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
 			log.Printf("[DEBUG] Secret %q was not found in Key Vault at URI %q - removing from state", id.Name, id.KeyVaultBaseUrl)
@@ -366,10 +452,24 @@ func resourceKeyVaultSecretRead(d *pluginsdk.ResourceData, meta interface{}) err
 			d.Set("not_before_date", time.Time(*v).Format(time.RFC3339))
 		}
 
-		if v := attributes.Expires; v != nil {
-			d.Set("expiration_date", time.Time(*v).Format(time.RFC3339))
+	}
+
+	// when a Rotation Policy is specified the expiration date is derived from it, rather than being configured
+	expirationDate := ""
+	rotationDate := ""
+	if attributes := resp.Attributes; attributes != nil && attributes.Expires != nil {
+		if v, ok := d.GetOk("rotation_policy"); ok {
+			rotation, err := keyVaultSecretRotationDate(v.([]interface{}), time.Time(*attributes.Expires))
+			if err != nil {
+				return err
+			}
+			rotationDate = rotation.Format(time.RFC3339)
+		} else {
+			expirationDate = time.Time(*attributes.Expires).Format(time.RFC3339)
 		}
 	}
+	d.Set("expiration_date", expirationDate)
+	d.Set("rotation_date", rotationDate)
 
 	d.Set("resource_id", parse.NewSecretID(keyVaultId.SubscriptionId, keyVaultId.ResourceGroupName, keyVaultId.VaultName, id.Name, id.Version).ID())
 	d.Set("resource_versionless_id", parse.NewSecretVersionlessID(keyVaultId.SubscriptionId, keyVaultId.ResourceGroupName, keyVaultId.VaultName, id.Name).ID())
@@ -424,11 +524,10 @@ func resourceKeyVaultSecretDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		keyVaultUri: id.KeyVaultBaseUrl,
 		name:        id.Name,
 	}
-	if err := deleteAndOptionallyPurge(ctx, description, shouldPurge, deleter); err != nil {
-		return err
-	}
-
-	return nil
+	temporarilyAllowClientIP := meta.(*clients.Client).Features.KeyVault.TemporarilyAllowClientIPInNetworkAcls
+	return keyVaultsClient.WithNetworkAccess(ctx, *keyVaultId, temporarilyAllowClientIP, func() error {
+		return deleteAndOptionallyPurge(ctx, description, shouldPurge, deleter)
+	})
 }
 
 var _ deleteAndPurgeNestedItem = deleteAndPurgeSecret{}
@@ -457,3 +556,39 @@ func (d deleteAndPurgeSecret) NestedItemHasBeenPurged(ctx context.Context) (auto
 	resp, err := d.client.GetDeletedSecret(ctx, d.keyVaultUri, d.name)
 	return resp.Response, err
 }
+
+// keyVaultSecretRotationPolicyExpirationDate returns the expiration date for a version of the Secret which is written at `now`
+func keyVaultSecretRotationPolicyExpirationDate(input []interface{}, now time.Time) (*time.Time, error) {
+	if len(input) == 0 || input[0] == nil {
+		return nil, fmt.Errorf("internal-error: `rotation_policy` was empty")
+	}
+	policy := input[0].(map[string]interface{})
+
+	expireAfter, err := period.Parse(policy["expire_after"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("parsing `expire_after`: %+v", err)
+	}
+
+	expirationDate, _ := expireAfter.AddTo(now.UTC().Truncate(time.Second))
+	return &expirationDate, nil
+}
+
+// keyVaultSecretRotationDate returns the date at which the Secret should be rotated, which is `rotate_before_expiry`
+// before the current version expires - or when it expires, if that's not specified
+func keyVaultSecretRotationDate(input []interface{}, expirationDate time.Time) (*time.Time, error) {
+	if len(input) == 0 || input[0] == nil {
+		return nil, fmt.Errorf("internal-error: `rotation_policy` was empty")
+	}
+	policy := input[0].(map[string]interface{})
+
+	rotationDate := expirationDate
+	if v := policy["rotate_before_expiry"].(string); v != "" {
+		rotateBeforeExpiry, err := period.Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("parsing `rotate_before_expiry`: %+v", err)
+		}
+		rotationDate, _ = rotateBeforeExpiry.Negate().AddTo(expirationDate)
+	}
+
+	return &rotationDate, nil
+}
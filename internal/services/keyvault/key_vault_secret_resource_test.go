@@ -127,6 +127,40 @@ func TestAccKeyVaultSecret_update(t *testing.T) {
 	})
 }
 
+func TestAccKeyVaultSecret_rotationPolicy(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret", "test")
+	r := KeyVaultSecretResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.rotationPolicy(data, "rick-and-morty"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("rotation_date").IsSet(),
+			),
+		},
+		data.ImportStep("expiration_date", "rotation_policy", "rotation_date"),
+		{
+			Config: r.rotationPolicy(data, "szechuan"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("value").HasValue("szechuan"),
+				check.That(data.ResourceName).Key("rotation_date").IsSet(),
+			),
+		},
+		data.ImportStep("expiration_date", "rotation_policy", "rotation_date"),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("expiration_date").IsEmpty(),
+				check.That(data.ResourceName).Key("rotation_date").IsEmpty(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccKeyVaultSecret_updatingValueChangedExternally(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret", "test")
 	r := KeyVaultSecretResource{}
@@ -328,6 +362,27 @@ resource "azurerm_key_vault_secret" "test" {
 `, r.template(data), data.RandomString)
 }
 
+func (r KeyVaultSecretResource) rotationPolicy(data acceptance.TestData, value string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_key_vault_secret" "test" {
+  name         = "secret-%s"
+  value        = "%s"
+  key_vault_id = azurerm_key_vault.test.id
+
+  rotation_policy {
+    expire_after         = "P90D"
+    rotate_before_expiry = "P30D"
+  }
+}
+`, r.template(data), data.RandomString, value)
+}
+
 func (r KeyVaultSecretResource) updateTags(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+)
+
+func dataSourceKeyVaultSecretVersions() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceKeyVaultSecretVersionsRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: keyVaultValidate.NestedItemName,
+			},
+
+			"key_vault_id": commonschema.ResourceIDReferenceRequired(&commonids.KeyVaultId{}),
+
+			"include_disabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"include_expired": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"versionless_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"versions": keyVaultNestedItemVersionsSchema(),
+		},
+	}
+}
+
+func dataSourceKeyVaultSecretVersionsRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	name := d.Get("name").(string)
+	keyVaultId, err := commonids.ParseKeyVaultID(d.Get("key_vault_id").(string))
+	if err != nil {
+		return err
+	}
+
+	keyVaultBaseUri, err := keyVaultsClient.BaseUriForKeyVault(ctx, *keyVaultId)
+	if err != nil {
+		return fmt.Errorf("looking up Secret %q vault url from id %q: %+v", name, *keyVaultId, err)
+	}
+
+	var versions []keyVaultNestedItemVersion
+	temporarilyAllowClientIP := meta.(*clients.Client).Features.KeyVault.TemporarilyAllowClientIPInNetworkAcls
+	err = keyVaultsClient.WithNetworkAccess(ctx, *keyVaultId, temporarilyAllowClientIP, func() (err error) {
+		versions, _, err = listKeyVaultSecretVersions(ctx, client, *keyVaultBaseUri, name)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		return fmt.Errorf("KeyVault Secret %q (KeyVault URI %q) does not exist", name, *keyVaultBaseUri)
+	}
+
+	includeDisabled := d.Get("include_disabled").(bool)
+	includeExpired := d.Get("include_expired").(bool)
+	now := time.Now()
+
+	filtered := make([]keyVaultNestedItemVersion, 0)
+	for _, v := range versions {
+		if !v.enabled && !includeDisabled {
+			continue
+		}
+		if v.hasExpired(now) && !includeExpired {
+			continue
+		}
+		filtered = append(filtered, v)
+	}
+
+	id, err := parse.NewNestedItemID(*keyVaultBaseUri, parse.NestedItemTypeSecret, name, "")
+	if err != nil {
+		return err
+	}
+
+	d.SetId(id.VersionlessID())
+	d.Set("name", name)
+	d.Set("key_vault_id", keyVaultId.ID())
+	d.Set("versionless_id", id.VersionlessID())
+
+	if err := d.Set("versions", flattenKeyVaultNestedItemVersions(filtered)); err != nil {
+		return fmt.Errorf("setting `versions`: %+v", err)
+	}
+
+	return nil
+}
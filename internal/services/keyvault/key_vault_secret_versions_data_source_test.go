@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type KeyVaultSecretVersionsDataSource struct{}
+
+func TestAccDataSourceKeyVaultSecretVersions_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_key_vault_secret_versions", "test")
+	r := KeyVaultSecretVersionsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("versions.#").HasValue("1"),
+				check.That(data.ResourceName).Key("versions.0.enabled").HasValue("true"),
+				check.That(data.ResourceName).Key("versionless_id").HasValue(fmt.Sprintf("https://acctestkv-%s.vault.azure.net/secrets/secret-%s", data.RandomString, data.RandomString)),
+			),
+		},
+		{
+			Config: r.basicUpdated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("versions.#").HasValue("2"),
+				acceptance.TestCheckResourceAttrPair(data.ResourceName, "versions.0.version", "azurerm_key_vault_secret.test", "version"),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceKeyVaultSecretVersions_expired(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_key_vault_secret_versions", "test")
+	r := KeyVaultSecretVersionsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.expired(data, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("versions.#").HasValue("0"),
+			),
+		},
+		{
+			Config: r.expired(data, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("versions.#").HasValue("1"),
+				check.That(data.ResourceName).Key("versions.0.expiration_date").HasValue("2020-01-01T01:02:03Z"),
+			),
+		},
+	})
+}
+
+func (KeyVaultSecretVersionsDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_key_vault_secret_versions" "test" {
+  name         = azurerm_key_vault_secret.test.name
+  key_vault_id = azurerm_key_vault.test.id
+  depends_on   = [azurerm_key_vault_secret.test]
+}
+`, KeyVaultSecretResource{}.basic(data))
+}
+
+func (KeyVaultSecretVersionsDataSource) basicUpdated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_key_vault_secret_versions" "test" {
+  name         = azurerm_key_vault_secret.test.name
+  key_vault_id = azurerm_key_vault.test.id
+  depends_on   = [azurerm_key_vault_secret.test]
+}
+`, KeyVaultSecretResource{}.basicUpdated(data))
+}
+
+func (KeyVaultSecretVersionsDataSource) expired(data acceptance.TestData, includeExpired bool) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_key_vault_secret_versions" "test" {
+  name            = azurerm_key_vault_secret.test.name
+  key_vault_id    = azurerm_key_vault.test.id
+  include_expired = %t
+  depends_on      = [azurerm_key_vault_secret.test]
+}
+`, KeyVaultSecretResource{}.complete(data), includeExpired)
+}
@@ -0,0 +1,278 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+	"golang.org/x/crypto/ssh"
+)
+
+// NOTE: the Keys within a Key Vault and a Managed HSM share the same Data Plane API, as such the
+// schema and expand/flatten functions in this package are used by both the Key Vault Key and the
+// Managed HSM Key resources.
+
+func RotationPolicySchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"expire_after": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validate.ISO8601DurationBetween("P28D", "P100Y"),
+					AtLeastOneOf: []string{
+						"rotation_policy.0.expire_after",
+						"rotation_policy.0.automatic",
+					},
+					RequiredWith: []string{
+						"rotation_policy.0.expire_after",
+						"rotation_policy.0.notify_before_expiry",
+					},
+				},
+
+				// <= expiry_time - 7, >=7
+				"notify_before_expiry": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validate.ISO8601DurationBetween("P7D", "P36493D"),
+					RequiredWith: []string{
+						"rotation_policy.0.expire_after",
+						"rotation_policy.0.notify_before_expiry",
+					},
+				},
+
+				"automatic": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"time_after_creation": {
+								Type:         pluginsdk.TypeString,
+								Optional:     true,
+								ValidateFunc: validate.ISO8601Duration,
+								AtLeastOneOf: []string{
+									"rotation_policy.0.automatic.0.time_after_creation",
+									"rotation_policy.0.automatic.0.time_before_expiry",
+								},
+							},
+							"time_before_expiry": {
+								Type:         pluginsdk.TypeString,
+								Optional:     true,
+								ValidateFunc: validate.ISO8601Duration,
+								AtLeastOneOf: []string{
+									"rotation_policy.0.automatic.0.time_after_creation",
+									"rotation_policy.0.automatic.0.time_before_expiry",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func ExpandKeyOptions(input []interface{}) *[]keyvault.JSONWebKeyOperation {
+	results := make([]keyvault.JSONWebKeyOperation, 0, len(input))
+
+	for _, option := range input {
+		results = append(results, keyvault.JSONWebKeyOperation(option.(string)))
+	}
+
+	return &results
+}
+
+func FlattenKeyOptions(input *[]string) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, option := range *input {
+		results = append(results, option)
+	}
+
+	return results
+}
+
+func ExpandRotationPolicy(v []interface{}) keyvault.KeyRotationPolicy {
+	if len(v) == 0 {
+		return keyvault.KeyRotationPolicy{LifetimeActions: &[]keyvault.LifetimeActions{}}
+	}
+
+	policy := v[0].(map[string]interface{})
+
+	var expiryTime *string = nil // needs to be set to nil if not set
+	if rawExpiryTime := policy["expire_after"]; rawExpiryTime != nil && rawExpiryTime.(string) != "" {
+		expiryTime = utils.String(rawExpiryTime.(string))
+	}
+
+	lifetimeActions := make([]keyvault.LifetimeActions, 0)
+	if rawNotificationTime := policy["notify_before_expiry"]; rawNotificationTime != nil && rawNotificationTime.(string) != "" {
+		lifetimeActionNotify := keyvault.LifetimeActions{
+			Trigger: &keyvault.LifetimeActionsTrigger{
+				TimeBeforeExpiry: utils.String(rawNotificationTime.(string)), // for Type: keyvault.Notify always TimeBeforeExpiry
+			},
+			Action: &keyvault.LifetimeActionsType{
+				Type: keyvault.ActionTypeNotify,
+			},
+		}
+		lifetimeActions = append(lifetimeActions, lifetimeActionNotify)
+	}
+
+	if autoRotationList := policy["automatic"].([]interface{}); len(autoRotationList) == 1 && autoRotationList[0] != nil {
+		lifetimeActionRotate := keyvault.LifetimeActions{
+			Action: &keyvault.LifetimeActionsType{
+				Type: keyvault.ActionTypeRotate,
+			},
+			Trigger: &keyvault.LifetimeActionsTrigger{},
+		}
+		autoRotationRaw := autoRotationList[0].(map[string]interface{})
+
+		if v := autoRotationRaw["time_after_creation"]; v != nil && v.(string) != "" {
+			timeAfterCreate := v.(string)
+			lifetimeActionRotate.Trigger.TimeAfterCreate = &timeAfterCreate
+		}
+
+		if v := autoRotationRaw["time_before_expiry"]; v != nil && v.(string) != "" {
+			timeBeforeExpiry := v.(string)
+			lifetimeActionRotate.Trigger.TimeBeforeExpiry = &timeBeforeExpiry
+		}
+
+		lifetimeActions = append(lifetimeActions, lifetimeActionRotate)
+	}
+
+	return keyvault.KeyRotationPolicy{
+		LifetimeActions: &lifetimeActions,
+		Attributes: &keyvault.KeyRotationPolicyAttributes{
+			ExpiryTime: expiryTime,
+		},
+	}
+}
+
+func FlattenRotationPolicy(input keyvault.KeyRotationPolicy) []interface{} {
+	if input.LifetimeActions == nil && input.Attributes == nil {
+		return []interface{}{}
+	}
+
+	policy := make(map[string]interface{})
+	if input.Attributes != nil && input.Attributes.ExpiryTime != nil && *input.Attributes.ExpiryTime != "" {
+		policy["expire_after"] = *input.Attributes.ExpiryTime
+	}
+
+	if input.LifetimeActions != nil {
+		for _, ltAction := range *input.LifetimeActions {
+			action := ltAction.Action
+			trigger := ltAction.Trigger
+
+			if action != nil && trigger != nil && action.Type != "" && strings.EqualFold(string(action.Type), string(keyvault.ActionTypeNotify)) && trigger.TimeBeforeExpiry != nil && *trigger.TimeBeforeExpiry != "" {
+				// Somehow a default is set after creation for notify_before_expiry
+				// Submitting this set value in the next run will not work though..
+				if policy["expire_after"] != nil {
+					policy["notify_before_expiry"] = *trigger.TimeBeforeExpiry
+				}
+			}
+
+			if action != nil && trigger != nil && action.Type != "" && strings.EqualFold(string(action.Type), string(keyvault.ActionTypeRotate)) {
+				autoRotation := make(map[string]interface{}, 0)
+				autoRotation["time_after_creation"] = pointer.From(trigger.TimeAfterCreate)
+				autoRotation["time_before_expiry"] = pointer.From(trigger.TimeBeforeExpiry)
+				policy["automatic"] = []map[string]interface{}{autoRotation}
+			}
+		}
+	}
+
+	if len(policy) == 0 {
+		return []interface{}{}
+	}
+
+	return []interface{}{policy}
+}
+
+// PublicKey returns the public key for the specified RSA or EC key, or nil when the type of key (or curve) isn't supported
+func PublicKey(key keyvault.JSONWebKey) (interface{}, error) {
+	switch key.Kty {
+	case keyvault.JSONWebKeyTypeRSA, keyvault.JSONWebKeyTypeRSAHSM:
+		nBytes, err := base64.RawURLEncoding.DecodeString(pointer.From(key.N))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode N: %+v", err)
+		}
+		eBytes, err := base64.RawURLEncoding.DecodeString(pointer.From(key.E))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode E: %+v", err)
+		}
+		return &rsa.PublicKey{
+			N: big.NewInt(0).SetBytes(nBytes),
+			E: int(big.NewInt(0).SetBytes(eBytes).Uint64()),
+		}, nil
+
+	case keyvault.JSONWebKeyTypeEC, keyvault.JSONWebKeyTypeECHSM:
+		xBytes, err := base64.RawURLEncoding.DecodeString(pointer.From(key.X))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode X: %+v", err)
+		}
+		yBytes, err := base64.RawURLEncoding.DecodeString(pointer.From(key.Y))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode Y: %+v", err)
+		}
+		publicKey := &ecdsa.PublicKey{
+			X: big.NewInt(0).SetBytes(xBytes),
+			Y: big.NewInt(0).SetBytes(yBytes),
+		}
+		switch key.Crv {
+		case keyvault.JSONWebKeyCurveNameP256:
+			publicKey.Curve = elliptic.P256()
+		case keyvault.JSONWebKeyCurveNameP384:
+			publicKey.Curve = elliptic.P384()
+		case keyvault.JSONWebKeyCurveNameP521:
+			publicKey.Curve = elliptic.P521()
+		default:
+			return nil, nil
+		}
+		return publicKey, nil
+	}
+
+	return nil, nil
+}
+
+// EncodePublicKey returns the PEM and (where supported) the OpenSSH encoding of the public key
+//
+// Credit to Hashicorp modified from https://github.com/hashicorp/terraform-provider-tls/blob/v3.1.0/internal/provider/util.go#L79-L105
+func EncodePublicKey(pubKey interface{}) (publicKeyPem string, publicKeyOpenSSH string, err error) {
+	pubKeyBytes, err := x509.MarshalPKIXPublicKey(pubKey)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal public key error: %s", err)
+	}
+	pubKeyPemBlock := &pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: pubKeyBytes,
+	}
+	publicKeyPem = string(pem.EncodeToMemory(pubKeyPemBlock))
+
+	sshPubKey, err := ssh.NewPublicKey(pubKey)
+	if err == nil {
+		// Not all EC types can be SSH keys, so we'll produce this only
+		// if an appropriate type was selected.
+		publicKeyOpenSSH = string(ssh.MarshalAuthorizedKey(sshPubKey))
+	}
+
+	return publicKeyPem, publicKeyOpenSSH, nil
+}
@@ -40,6 +40,7 @@ func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 		"azurerm_key_vault_certificate_issuer": dataSourceKeyVaultCertificateIssuer(),
 		"azurerm_key_vault_key":                dataSourceKeyVaultKey(),
 		"azurerm_key_vault_secret":             dataSourceKeyVaultSecret(),
+		"azurerm_key_vault_secret_versions":    dataSourceKeyVaultSecretVersions(),
 		"azurerm_key_vault_secrets":            dataSourceKeyVaultSecrets(),
 		"azurerm_key_vault":                    dataSourceKeyVault(),
 		"azurerm_key_vault_certificates":       dataSourceKeyVaultCertificates(),
@@ -4,12 +4,12 @@
 package migration
 
 import (
-	"context"
 	"fmt"
-	"log"
 
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachines"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/maintenance/2023-04-01/configurationassignments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -40,24 +40,22 @@ func (AssignmentVirtualMachineV0ToV1) Schema() map[string]*pluginsdk.Schema {
 	}
 }
 
-func (AssignmentVirtualMachineV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
-	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
-		oldIdRaw := rawState["id"].(string)
-		oldId, err := configurationassignments.ParseScopedConfigurationAssignmentIDInsensitively(oldIdRaw)
-		if err != nil {
-			return nil, fmt.Errorf("parsing old id %q: %+v", oldIdRaw, err)
-		}
-
-		virtualMachineId, err := virtualmachines.ParseVirtualMachineIDInsensitively(oldId.Scope)
-		if err != nil {
-			return nil, fmt.Errorf("parsing %q as a virtual machine id: %+v", oldId.Scope, err)
-		}
-
-		newId := configurationassignments.NewScopedConfigurationAssignmentID(virtualMachineId.ID(), oldId.ConfigurationAssignmentName)
-		newIdRaw := newId.ID()
-		log.Printf("[DEBUG] Updating ID from %q to %q", oldIdRaw, newIdRaw)
-		rawState["id"] = newIdRaw
-
-		return rawState, nil
-	}
+func (s AssignmentVirtualMachineV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
+	// the casing of the Virtual Machine ID used as the Scope also needs to be updated
+	return sdk.NewIDStateUpgrade(s.Schema(), &configurationassignments.ScopedConfigurationAssignmentId{}).
+		WithLegacyIDParser(func(input string) (resourceids.ResourceId, error) {
+			oldId, err := configurationassignments.ParseScopedConfigurationAssignmentIDInsensitively(input)
+			if err != nil {
+				return nil, fmt.Errorf("parsing old id %q: %+v", input, err)
+			}
+
+			virtualMachineId, err := virtualmachines.ParseVirtualMachineIDInsensitively(oldId.Scope)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %q as a virtual machine id: %+v", oldId.Scope, err)
+			}
+
+			newId := configurationassignments.NewScopedConfigurationAssignmentID(virtualMachineId.ID(), oldId.ConfigurationAssignmentName)
+			return &newId, nil
+		}).
+		UpgradeFunc()
 }
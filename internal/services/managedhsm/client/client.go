@@ -24,6 +24,7 @@ type Client struct {
 
 	// Data Plane
 	DataPlaneClient                *dataplane.BaseClient
+	DataPlaneManagedHSMClient      *dataplane.BaseClient
 	DataPlaneRoleAssignmentsClient *dataplane.RoleAssignmentsClient
 	DataPlaneRoleDefinitionsClient *dataplane.RoleDefinitionsClient
 	DataPlaneSecurityDomainsClient *dataplane.HSMSecurityDomainClient
@@ -39,6 +40,10 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	managementClient := dataplane.New()
 	o.ConfigureClient(&managementClient.Client, o.KeyVaultAuthorizer)
 
+	// whilst Keys and Settings within a Managed HSM use the same client as a Key Vault, they require the Managed HSM authorizer
+	managedHsmDataPlaneClient := dataplane.New()
+	o.ConfigureClient(&managedHsmDataPlaneClient.Client, o.ManagedHSMAuthorizer)
+
 	securityDomainClient := dataplane.NewHSMSecurityDomainClient()
 	o.ConfigureClient(&securityDomainClient.Client, o.ManagedHSMAuthorizer)
 
@@ -54,6 +59,7 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 
 		// Data Plane
 		DataPlaneClient:                &managementClient,
+		DataPlaneManagedHSMClient:      &managedHsmDataPlaneClient,
 		DataPlaneSecurityDomainsClient: &securityDomainClient,
 		DataPlaneRoleDefinitionsClient: &roleDefinitionsClient,
 		DataPlaneRoleAssignmentsClient: &roleAssignmentsClient,
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-07-01/managedhsms"
+)
+
+var (
+	// managedHSMsCache maps the (lower-cased) name of a Managed HSM to its details, names are unique across Azure
+	// since they're used for the Data Plane URI
+	managedHSMsCache = map[string]managedHSMDetails{}
+	managedHSMsLock  = &sync.RWMutex{}
+)
+
+type managedHSMDetails struct {
+	managedHSMId     managedhsms.ManagedHSMId
+	dataPlaneBaseUri string
+}
+
+func (c *Client) AddToCache(managedHSMId managedhsms.ManagedHSMId, dataPlaneUri string) {
+	managedHSMsLock.Lock()
+	managedHSMsCache[strings.ToLower(managedHSMId.ManagedHSMName)] = managedHSMDetails{
+		managedHSMId:     managedHSMId,
+		dataPlaneBaseUri: dataPlaneUri,
+	}
+	managedHSMsLock.Unlock()
+}
+
+// BaseUriForManagedHSM returns the Data Plane URI for the specified Managed HSM
+func (c *Client) BaseUriForManagedHSM(ctx context.Context, managedHSMId managedhsms.ManagedHSMId) (*string, error) {
+	managedHSMsLock.RLock()
+	v, ok := managedHSMsCache[strings.ToLower(managedHSMId.ManagedHSMName)]
+	managedHSMsLock.RUnlock()
+	if ok {
+		return &v.dataPlaneBaseUri, nil
+	}
+
+	resp, err := c.ManagedHsmClient.Get(ctx, managedHSMId)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, fmt.Errorf("%s was not found", managedHSMId)
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", managedHSMId, err)
+	}
+
+	hsmUri := ""
+	if model := resp.Model; model != nil && model.Properties != nil && model.Properties.HsmUri != nil {
+		hsmUri = *model.Properties.HsmUri
+	}
+	if hsmUri == "" {
+		return nil, fmt.Errorf("retrieving %s: `properties.hsmUri` was nil", managedHSMId)
+	}
+
+	c.AddToCache(managedHSMId, hsmUri)
+	return &hsmUri, nil
+}
+
+// ManagedHSMIDFromBaseUrl returns the Resource Manager ID of the Managed HSM with the specified Data Plane URI, or
+// nil when it wasn't found within the Subscription
+func (c *Client) ManagedHSMIDFromBaseUrl(ctx context.Context, subscriptionId commonids.SubscriptionId, managedHSMBaseUrl string) (*managedhsms.ManagedHSMId, error) {
+	name, err := parseNameFromBaseUrl(managedHSMBaseUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	managedHSMsLock.RLock()
+	v, ok := managedHSMsCache[name]
+	managedHSMsLock.RUnlock()
+	if ok {
+		return &v.managedHSMId, nil
+	}
+
+	// as with Key Vaults, all the Managed HSMs within the Subscription are listed to populate the cache
+	results, err := c.ManagedHsmClient.ListBySubscriptionComplete(ctx, subscriptionId, managedhsms.DefaultListBySubscriptionOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("listing the Managed HSMs within %s: %+v", subscriptionId, err)
+	}
+	for _, item := range results.Items {
+		if item.Id == nil || item.Properties == nil || item.Properties.HsmUri == nil {
+			continue
+		}
+
+		managedHSMId, err := managedhsms.ParseManagedHSMIDInsensitively(*item.Id)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %q as a Managed HSM ID: %+v", *item.Id, err)
+		}
+		c.AddToCache(*managedHSMId, *item.Properties.HsmUri)
+	}
+
+	managedHSMsLock.RLock()
+	v, ok = managedHSMsCache[name]
+	managedHSMsLock.RUnlock()
+	if ok {
+		return &v.managedHSMId, nil
+	}
+
+	// Resources and Data Sources need to handle this separately
+	return nil, nil
+}
+
+func parseNameFromBaseUrl(input string) (string, error) {
+	uri, err := url.Parse(input)
+	if err != nil {
+		return "", fmt.Errorf("parsing %q as a URI: %+v", input, err)
+	}
+
+	// https://the-hsm.managedhsm.azure.net:443/
+	segments := strings.Split(uri.Host, ".")
+	if len(segments) < 2 || segments[0] == "" {
+		return "", fmt.Errorf("expected a Managed HSM URI in the format `https://{name}.managedhsm.azure.net` but got %q", input)
+	}
+
+	return strings.ToLower(segments[0]), nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package custompollers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+	dataplane "github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+var _ pollers.PollerType = &hsmUploadPoller{}
+
+func NewHSMUploadPoller(client *dataplane.HSMSecurityDomainClient, baseUrl string) pollers.PollerType {
+	return &hsmUploadPoller{
+		client:  client,
+		baseUrl: baseUrl,
+	}
+}
+
+type hsmUploadPoller struct {
+	client  *dataplane.HSMSecurityDomainClient
+	baseUrl string
+}
+
+func (p *hsmUploadPoller) Poll(ctx context.Context) (*pollers.PollResult, error) {
+	res, err := p.client.UploadPending(ctx, p.baseUrl)
+	if err != nil {
+		return nil, fmt.Errorf("waiting for Security Domain to upload failed within %s: %+v", p.baseUrl, err)
+	}
+
+	switch res.Status {
+	case dataplane.OperationStatusSuccess:
+		return &pollers.PollResult{
+			Status:       pollers.PollingStatusSucceeded,
+			PollInterval: 10 * time.Second,
+		}, nil
+
+	case dataplane.OperationStatusFailed:
+		return nil, fmt.Errorf("uploading the Security Domain to %s failed: %s", p.baseUrl, pointer.From(res.StatusDetails))
+	}
+
+	// Processing
+	return &pollers.PollResult{
+		Status:       pollers.PollingStatusInProgress,
+		PollInterval: 10 * time.Second,
+	}, nil
+}
@@ -0,0 +1,549 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package managedhsm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-07-01/managedhsms"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/keys"
+	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+func resourceKeyVaultManagedHardwareSecurityModuleKey() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceKeyVaultManagedHardwareSecurityModuleKeyCreate,
+		Read:   resourceKeyVaultManagedHardwareSecurityModuleKeyRead,
+		Update: resourceKeyVaultManagedHardwareSecurityModuleKeyUpdate,
+		Delete: resourceKeyVaultManagedHardwareSecurityModuleKeyDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.ManagedHSMKeyID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: keyVaultValidate.NestedItemName,
+			},
+
+			"managed_hsm_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: managedhsms.ValidateManagedHSMID,
+			},
+
+			"key_type": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+				ForceNew: true,
+				// Managed HSMs only support HSM-protected keys
+				ValidateFunc: validation.StringInSlice([]string{
+					string(keyvault.JSONWebKeyTypeECHSM),
+					string(keyvault.JSONWebKeyTypeOctHSM),
+					string(keyvault.JSONWebKeyTypeRSAHSM),
+				}, false),
+			},
+
+			"key_size": {
+				Type:          pluginsdk.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"curve"},
+			},
+
+			"key_opts": {
+				Type:     pluginsdk.TypeList,
+				Required: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(keyvault.JSONWebKeyOperationDecrypt),
+						string(keyvault.JSONWebKeyOperationEncrypt),
+						string(keyvault.JSONWebKeyOperationImport),
+						string(keyvault.JSONWebKeyOperationSign),
+						string(keyvault.JSONWebKeyOperationUnwrapKey),
+						string(keyvault.JSONWebKeyOperationVerify),
+						string(keyvault.JSONWebKeyOperationWrapKey),
+					}, false),
+				},
+			},
+
+			"curve": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(keyvault.JSONWebKeyCurveNameP256),
+					string(keyvault.JSONWebKeyCurveNameP256K),
+					string(keyvault.JSONWebKeyCurveNameP384),
+					string(keyvault.JSONWebKeyCurveNameP521),
+				}, false),
+				ConflictsWith: []string{"key_size"},
+			},
+
+			"not_before_date": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+
+			"expiration_date": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+
+			"rotation_policy": keys.RotationPolicySchema(),
+
+			"tags": tags.Schema(),
+
+			// Computed
+			"version": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"versioned_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"n": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"e": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"x": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"y": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"public_key_pem": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"public_key_openssh": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+		},
+
+		CustomizeDiff: pluginsdk.CustomDiffWithAll(
+			pluginsdk.CustomizeDiffShim(func(ctx context.Context, d *pluginsdk.ResourceDiff, v interface{}) error {
+				keyType := d.Get("key_type").(string)
+				if keyType == string(keyvault.JSONWebKeyTypeECHSM) {
+					return nil
+				}
+
+				// the key size is required for RSA and Symmetric (oct) keys
+				if _, ok := d.GetOk("key_size"); !ok {
+					return fmt.Errorf("`key_size` is required when `key_type` is %q", keyType)
+				}
+				return nil
+			}),
+		),
+	}
+}
+
+func resourceKeyVaultManagedHardwareSecurityModuleKeyCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).ManagedHSMs.DataPlaneManagedHSMClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	managedHSMId, err := managedhsms.ParseManagedHSMID(d.Get("managed_hsm_id").(string))
+	if err != nil {
+		return err
+	}
+
+	baseUri, err := meta.(*clients.Client).ManagedHSMs.BaseUriForManagedHSM(ctx, *managedHSMId)
+	if err != nil {
+		return fmt.Errorf("determining the Data Plane URI for %s: %+v", managedHSMId, err)
+	}
+
+	id, err := parse.NewManagedHSMKeyID(*baseUri, d.Get("name").(string), "")
+	if err != nil {
+		return err
+	}
+
+	locks.ByName(id.VaultBaseUrl, "azurerm_key_vault_managed_hardware_security_module")
+	defer locks.UnlockByName(id.VaultBaseUrl, "azurerm_key_vault_managed_hardware_security_module")
+
+	existing, err := client.GetKey(ctx, id.VaultBaseUrl, id.Name, "")
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+		}
+	}
+	if !utils.ResponseWasNotFound(existing.Response) {
+		return tf.ImportAsExistsError("azurerm_key_vault_managed_hardware_security_module_key", id.VersionlessID())
+	}
+
+	parameters := keyvault.KeyCreateParameters{
+		Kty:    keyvault.JSONWebKeyType(d.Get("key_type").(string)),
+		KeyOps: keys.ExpandKeyOptions(d.Get("key_opts").([]interface{})),
+		KeyAttributes: &keyvault.KeyAttributes{
+			Enabled: utils.Bool(true),
+		},
+		Tags: tags.Expand(d.Get("tags").(map[string]interface{})),
+	}
+
+	if parameters.Kty == keyvault.JSONWebKeyTypeECHSM {
+		parameters.Curve = keyvault.JSONWebKeyCurveName(d.Get("curve").(string))
+	} else {
+		parameters.KeySize = utils.Int32(int32(d.Get("key_size").(int)))
+	}
+
+	if v, ok := d.GetOk("not_before_date"); ok {
+		notBeforeDate, _ := time.Parse(time.RFC3339, v.(string)) // validated by schema
+		notBeforeUnixTime := date.UnixTime(notBeforeDate)
+		parameters.KeyAttributes.NotBefore = &notBeforeUnixTime
+	}
+
+	if v, ok := d.GetOk("expiration_date"); ok {
+		expirationDate, _ := time.Parse(time.RFC3339, v.(string)) // validated by schema
+		expirationUnixTime := date.UnixTime(expirationDate)
+		parameters.KeyAttributes.Expires = &expirationUnixTime
+	}
+
+	if resp, err := client.CreateKey(ctx, id.VaultBaseUrl, id.Name, parameters); err != nil {
+		if !meta.(*clients.Client).Features.KeyVault.RecoverSoftDeletedKeys || !utils.ResponseWasConflict(resp.Response) {
+			return fmt.Errorf("creating %s: %+v", id, err)
+		}
+
+		log.Printf("[DEBUG] Recovering soft-deleted %s", id)
+		if _, err := client.RecoverDeletedKey(ctx, id.VaultBaseUrl, id.Name); err != nil {
+			return fmt.Errorf("recovering soft-deleted %s: %+v", id, err)
+		}
+		if err := waitForManagedHSMKeyToBeRecovered(ctx, client, *id); err != nil {
+			return err
+		}
+
+		// after we recovered the existing key we still have to apply our changes
+		if err := updateManagedHSMKey(ctx, client, *id, d); err != nil {
+			return err
+		}
+	}
+
+	if v, ok := d.GetOk("rotation_policy"); ok {
+		if _, err := client.UpdateKeyRotationPolicy(ctx, id.VaultBaseUrl, id.Name, keys.ExpandRotationPolicy(v.([]interface{}))); err != nil {
+			return fmt.Errorf("creating Key Rotation Policy for %s: %+v", id, err)
+		}
+	}
+
+	d.SetId(id.VersionlessID())
+
+	return resourceKeyVaultManagedHardwareSecurityModuleKeyRead(d, meta)
+}
+
+func resourceKeyVaultManagedHardwareSecurityModuleKeyUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).ManagedHSMs.DataPlaneManagedHSMClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedHSMKeyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	locks.ByName(id.VaultBaseUrl, "azurerm_key_vault_managed_hardware_security_module")
+	defer locks.UnlockByName(id.VaultBaseUrl, "azurerm_key_vault_managed_hardware_security_module")
+
+	if d.HasChanges("key_opts", "not_before_date", "expiration_date", "tags") {
+		if err := updateManagedHSMKey(ctx, client, *id, d); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("rotation_policy") {
+		if _, err := client.UpdateKeyRotationPolicy(ctx, id.VaultBaseUrl, id.Name, keys.ExpandRotationPolicy(d.Get("rotation_policy").([]interface{}))); err != nil {
+			return fmt.Errorf("updating Key Rotation Policy for %s: %+v", id, err)
+		}
+	}
+
+	return resourceKeyVaultManagedHardwareSecurityModuleKeyRead(d, meta)
+}
+
+func resourceKeyVaultManagedHardwareSecurityModuleKeyRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).ManagedHSMs.DataPlaneManagedHSMClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedHSMKeyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	managedHSMId, err := meta.(*clients.Client).ManagedHSMs.ManagedHSMIDFromBaseUrl(ctx, commonids.NewSubscriptionID(subscriptionId), id.VaultBaseUrl)
+	if err != nil {
+		return fmt.Errorf("determining the Managed HSM ID for %s: %+v", id, err)
+	}
+	if managedHSMId == nil {
+		log.Printf("[DEBUG] Unable to determine the Managed HSM ID for %s - removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	resp, err := client.GetKey(ctx, id.VaultBaseUrl, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] %s was not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("managed_hsm_id", managedHSMId.ID())
+
+	if key := resp.Key; key != nil {
+		// the Managed HSM always returns the HSM-protected key type
+		d.Set("key_type", string(key.Kty))
+		if err := d.Set("key_opts", keys.FlattenKeyOptions(key.KeyOps)); err != nil {
+			return fmt.Errorf("setting `key_opts`: %+v", err)
+		}
+
+		d.Set("n", key.N)
+		d.Set("e", key.E)
+		d.Set("x", key.X)
+		d.Set("y", key.Y)
+		d.Set("curve", string(key.Crv))
+
+		publicKey, err := keys.PublicKey(*key)
+		if err != nil {
+			return err
+		}
+		publicKeyPem, publicKeyOpenSSH := "", ""
+		if publicKey != nil {
+			if publicKeyPem, publicKeyOpenSSH, err = keys.EncodePublicKey(publicKey); err != nil {
+				return err
+			}
+		}
+		d.Set("public_key_pem", publicKeyPem)
+		d.Set("public_key_openssh", publicKeyOpenSSH)
+
+		if key.Kid != nil {
+			versionedId, err := parse.ManagedHSMKeyID(*key.Kid)
+			if err != nil {
+				return fmt.Errorf("parsing the versioned ID for %s: %+v", id, err)
+			}
+			d.Set("version", versionedId.Version)
+			d.Set("versioned_id", versionedId.ID())
+		}
+	}
+
+	notBeforeDate, expirationDate := "", ""
+	if attributes := resp.Attributes; attributes != nil {
+		if v := attributes.NotBefore; v != nil {
+			notBeforeDate = time.Time(*v).Format(time.RFC3339)
+		}
+
+		if v := attributes.Expires; v != nil {
+			expirationDate = time.Time(*v).Format(time.RFC3339)
+		}
+	}
+	d.Set("not_before_date", notBeforeDate)
+	d.Set("expiration_date", expirationDate)
+
+	rotationPolicy := make([]interface{}, 0)
+	respPolicy, err := client.GetKeyRotationPolicy(ctx, id.VaultBaseUrl, id.Name)
+	if err != nil {
+		if !utils.ResponseWasNotFound(respPolicy.Response) {
+			return fmt.Errorf("retrieving Key Rotation Policy for %s: %+v", id, err)
+		}
+	} else {
+		rotationPolicy = keys.FlattenRotationPolicy(respPolicy)
+	}
+	if err := d.Set("rotation_policy", rotationPolicy); err != nil {
+		return fmt.Errorf("setting `rotation_policy`: %+v", err)
+	}
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
+
+func resourceKeyVaultManagedHardwareSecurityModuleKeyDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).ManagedHSMs.DataPlaneManagedHSMClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedHSMKeyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	locks.ByName(id.VaultBaseUrl, "azurerm_key_vault_managed_hardware_security_module")
+	defer locks.UnlockByName(id.VaultBaseUrl, "azurerm_key_vault_managed_hardware_security_module")
+
+	if resp, err := client.DeleteKey(ctx, id.VaultBaseUrl, id.Name); err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return nil
+		}
+		return fmt.Errorf("deleting %s: %+v", id, err)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return fmt.Errorf("internal-error: context had no deadline")
+	}
+
+	log.Printf("[DEBUG] Waiting for %s to finish deleting..", id)
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending: []string{"InProgress"},
+		Target:  []string{"NotFound"},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.GetKey(ctx, id.VaultBaseUrl, id.Name, "")
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return resp, "NotFound", nil
+				}
+				return nil, "Error", err
+			}
+			return resp, "InProgress", nil
+		},
+		ContinuousTargetOccurence: 3,
+		PollInterval:              5 * time.Second,
+		Timeout:                   time.Until(deadline),
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("waiting for %s to be deleted: %+v", id, err)
+	}
+
+	if !meta.(*clients.Client).Features.KeyVault.PurgeSoftDeletedKeysOnDestroy {
+		log.Printf("[DEBUG] Skipping purging of %s as opted-out..", id)
+		return nil
+	}
+
+	log.Printf("[DEBUG] Purging %s..", id)
+	err = pluginsdk.Retry(time.Until(deadline), func() *pluginsdk.RetryError {
+		if _, err := client.PurgeDeletedKey(ctx, id.VaultBaseUrl, id.Name); err != nil {
+			if strings.Contains(err.Error(), "is currently being deleted") {
+				return pluginsdk.RetryableError(fmt.Errorf("%s is currently being deleted, retrying", id))
+			}
+			return pluginsdk.NonRetryableError(fmt.Errorf("purging %s: %+v", id, err))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Waiting for %s to finish purging..", id)
+	stateConf.Refresh = func() (interface{}, string, error) {
+		resp, err := client.GetDeletedKey(ctx, id.VaultBaseUrl, id.Name)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return resp, "NotFound", nil
+			}
+			return nil, "Error", err
+		}
+		return resp, "InProgress", nil
+	}
+	stateConf.Timeout = time.Until(deadline)
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("waiting for %s to be purged: %+v", id, err)
+	}
+
+	return nil
+}
+
+func updateManagedHSMKey(ctx context.Context, client *keyvault.BaseClient, id parse.ManagedHSMKeyId, d *pluginsdk.ResourceData) error {
+	parameters := keyvault.KeyUpdateParameters{
+		KeyOps: keys.ExpandKeyOptions(d.Get("key_opts").([]interface{})),
+		KeyAttributes: &keyvault.KeyAttributes{
+			Enabled: utils.Bool(true),
+		},
+		Tags: tags.Expand(d.Get("tags").(map[string]interface{})),
+	}
+
+	if v, ok := d.GetOk("not_before_date"); ok {
+		notBeforeDate, _ := time.Parse(time.RFC3339, v.(string)) // validated by schema
+		notBeforeUnixTime := date.UnixTime(notBeforeDate)
+		parameters.KeyAttributes.NotBefore = &notBeforeUnixTime
+	}
+
+	if v, ok := d.GetOk("expiration_date"); ok {
+		expirationDate, _ := time.Parse(time.RFC3339, v.(string)) // validated by schema
+		expirationUnixTime := date.UnixTime(expirationDate)
+		parameters.KeyAttributes.Expires = &expirationUnixTime
+	}
+
+	if _, err := client.UpdateKey(ctx, id.VaultBaseUrl, id.Name, "", parameters); err != nil {
+		return fmt.Errorf("updating %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+func waitForManagedHSMKeyToBeRecovered(ctx context.Context, client *keyvault.BaseClient, id parse.ManagedHSMKeyId) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return fmt.Errorf("internal-error: context had no deadline")
+	}
+
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"available"},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.GetKey(ctx, id.VaultBaseUrl, id.Name, "")
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return resp, "pending", nil
+				}
+				return nil, "", err
+			}
+			return resp, "available", nil
+		},
+		Delay:                     30 * time.Second,
+		PollInterval:              10 * time.Second,
+		ContinuousTargetOccurence: 10,
+		Timeout:                   time.Until(deadline),
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("waiting for %s to be recovered: %+v", id, err)
+	}
+
+	return nil
+}
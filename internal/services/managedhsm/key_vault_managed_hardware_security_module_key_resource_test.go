@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package managedhsm_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type KeyVaultManagedHSMKeyResource struct{}
+
+// real test nested in TestAccKeyVaultManagedHardwareSecurityModule, only provide Exists logic here
+func (k KeyVaultManagedHSMKeyResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.ManagedHSMKeyID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.ManagedHSMs.DataPlaneManagedHSMClient.GetKey(ctx, id.VaultBaseUrl, id.Name, "")
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+	return utils.Bool(resp.Key != nil), nil
+}
+
+func (k KeyVaultManagedHSMKeyResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+
+
+%s
+
+resource "azurerm_key_vault_managed_hardware_security_module_key" "test" {
+  name           = "acctest-key-%d"
+  managed_hsm_id = azurerm_key_vault_managed_hardware_security_module.test.id
+  key_type       = "EC-HSM"
+  curve          = "P-256"
+  key_opts       = ["sign", "verify"]
+
+  depends_on = [azurerm_key_vault_managed_hardware_security_module_role_assignment.crypto_user]
+}
+`, k.template(data), data.RandomInteger)
+}
+
+func (k KeyVaultManagedHSMKeyResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+
+
+%s
+
+resource "azurerm_key_vault_managed_hardware_security_module_key" "test" {
+  name            = "acctest-key-%d"
+  managed_hsm_id  = azurerm_key_vault_managed_hardware_security_module.test.id
+  key_type        = "EC-HSM"
+  curve           = "P-256"
+  key_opts        = ["sign"]
+  not_before_date = "2021-12-01T00:00:00Z"
+  expiration_date = "2033-12-30T20:00:00Z"
+
+  rotation_policy {
+    automatic {
+      time_before_expiry = "P30D"
+    }
+
+    expire_after         = "P60D"
+    notify_before_expiry = "P29D"
+  }
+
+  tags = {
+    "hello" = "world"
+  }
+
+  depends_on = [azurerm_key_vault_managed_hardware_security_module_role_assignment.crypto_user]
+}
+`, k.template(data), data.RandomInteger)
+}
+
+func (k KeyVaultManagedHSMKeyResource) template(data acceptance.TestData) string {
+	hsm := KeyVaultManagedHardwareSecurityModuleResource{}.download(data, 3)
+	return fmt.Sprintf(`
+
+
+%s
+
+locals {
+  cryptoUserAssignmentName = "3c1b2f6a-9f6b-4b4a-9a0e-2b8c0a5d7e41"
+}
+
+data "azurerm_key_vault_managed_hardware_security_module_role_definition" "crypto_user" {
+  vault_base_url = azurerm_key_vault_managed_hardware_security_module.test.hsm_uri
+  name           = "21dbd100-6940-42c2-9190-5d6cb909625b"
+}
+
+resource "azurerm_key_vault_managed_hardware_security_module_role_assignment" "crypto_user" {
+  vault_base_url     = azurerm_key_vault_managed_hardware_security_module.test.hsm_uri
+  name               = local.cryptoUserAssignmentName
+  scope              = "/keys"
+  role_definition_id = data.azurerm_key_vault_managed_hardware_security_module_role_definition.crypto_user.resource_manager_id
+  principal_id       = data.azurerm_client_config.current.object_id
+}
+`, hsm)
+}
@@ -6,6 +6,8 @@ package managedhsm_test
 import (
 	"context"
 	"fmt"
+	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-07-01/managedhsms"
@@ -23,13 +25,16 @@ func TestAccKeyVaultManagedHardwareSecurityModule(t *testing.T) {
 	// Azure only being able provision against one instance at a time
 	acceptance.RunTestsInSequence(t, map[string]map[string]func(t *testing.T){
 		"resource": {
-			"data_source": testAccDataSourceKeyVaultManagedHardwareSecurityModule_basic,
-			"basic":       testAccKeyVaultManagedHardwareSecurityModule_basic,
-			"update":      testAccKeyVaultManagedHardwareSecurityModule_updateAndRequiresImport,
-			"complete":    testAccKeyVaultManagedHardwareSecurityModule_complete,
-			"download":    testAccKeyVaultManagedHardwareSecurityModule_download,
-			"role_define": testAccKeyVaultManagedHardwareSecurityModule_roleDefinition,
-			"role_assign": testAccKeyVaultManagedHardwareSecurityModule_roleAssignment,
+			"data_source":     testAccDataSourceKeyVaultManagedHardwareSecurityModule_basic,
+			"basic":           testAccKeyVaultManagedHardwareSecurityModule_basic,
+			"update":          testAccKeyVaultManagedHardwareSecurityModule_updateAndRequiresImport,
+			"complete":        testAccKeyVaultManagedHardwareSecurityModule_complete,
+			"download":        testAccKeyVaultManagedHardwareSecurityModule_download,
+			"role_define":     testAccKeyVaultManagedHardwareSecurityModule_roleDefinition,
+			"role_assign":     testAccKeyVaultManagedHardwareSecurityModule_roleAssignment,
+			"key":             testAccKeyVaultManagedHardwareSecurityModule_key,
+			"security_domain": testAccKeyVaultManagedHardwareSecurityModule_securityDomain,
+			"setting":         testAccKeyVaultManagedHardwareSecurityModule_setting,
 		},
 	})
 }
@@ -122,6 +127,86 @@ func testAccKeyVaultManagedHardwareSecurityModule_roleAssignment(t *testing.T) {
 	})
 }
 
+func testAccKeyVaultManagedHardwareSecurityModule_key(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_managed_hardware_security_module_key", "test")
+	r := KeyVaultManagedHSMKeyResource{}
+
+	data.ResourceSequentialTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("public_key_pem").Exists(),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func testAccKeyVaultManagedHardwareSecurityModule_securityDomain(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_managed_hardware_security_module_security_domain", "test")
+	r := KeyVaultManagedHSMSecurityDomainResource{}
+
+	data.ResourceSequentialTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("encrypted_data").Exists(),
+			),
+		},
+		{
+			ResourceName:  data.ResourceName,
+			ImportState:   true,
+			ImportStateId: fmt.Sprintf("/subscriptions/%s/resourceGroups/acctestRG-KV-%d/providers/Microsoft.KeyVault/managedHSMs/kvHsm%d", os.Getenv("ARM_SUBSCRIPTION_ID"), data.RandomInteger, data.RandomInteger),
+			ExpectError:   regexp.MustCompile("can't be imported"),
+		},
+		{
+			Config: r.upload(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That("azurerm_key_vault_managed_hardware_security_module_security_domain.restored").ExistsInAzure(r),
+			),
+		},
+	})
+}
+
+func testAccKeyVaultManagedHardwareSecurityModule_setting(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_managed_hardware_security_module_setting", "test")
+	r := KeyVaultManagedHSMSettingResource{}
+
+	data.ResourceSequentialTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("value").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func testAccKeyVaultManagedHardwareSecurityModule_updateAndRequiresImport(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_key_vault_managed_hardware_security_module", "test")
 	r := KeyVaultManagedHardwareSecurityModuleResource{}
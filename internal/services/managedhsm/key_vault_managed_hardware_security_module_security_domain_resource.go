@@ -0,0 +1,299 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package managedhsm
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-07-01/managedhsms"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/certificatebundle"
+	keyVaultParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	keyVaultValidation "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/custompollers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/securitydomain"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	kv74 "github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+type KeyVaultManagedHSMSecurityDomainModel struct {
+	ManagedHSMId           string   `tfschema:"managed_hsm_id"`
+	KeyVaultCertificateIds []string `tfschema:"key_vault_certificate_ids"`
+	Quorum                 int64    `tfschema:"quorum"`
+	EncryptedData          string   `tfschema:"encrypted_data"`
+}
+
+type KeyVaultManagedHSMSecurityDomainResource struct{}
+
+var (
+	_ sdk.Resource                   = KeyVaultManagedHSMSecurityDomainResource{}
+	_ sdk.ResourceWithCustomImporter = KeyVaultManagedHSMSecurityDomainResource{}
+)
+
+func (r KeyVaultManagedHSMSecurityDomainResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"managed_hsm_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: managedhsms.ValidateManagedHSMID,
+		},
+
+		"key_vault_certificate_ids": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			ForceNew: true,
+			MinItems: 2,
+			MaxItems: 10,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: keyVaultValidation.NestedItemId,
+			},
+		},
+
+		"quorum": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IntBetween(2, 10),
+			ExactlyOneOf: []string{"quorum", "encrypted_data"},
+		},
+
+		// when specified the Security Domain is uploaded to the Managed HSM, otherwise it's downloaded
+		"encrypted_data": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.StringIsJSON,
+			ExactlyOneOf: []string{"quorum", "encrypted_data"},
+		},
+	}
+}
+
+func (r KeyVaultManagedHSMSecurityDomainResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r KeyVaultManagedHSMSecurityDomainResource) ModelObject() interface{} {
+	return &KeyVaultManagedHSMSecurityDomainModel{}
+}
+
+func (r KeyVaultManagedHSMSecurityDomainResource) ResourceType() string {
+	return "azurerm_key_vault_managed_hardware_security_module_security_domain"
+}
+
+func (r KeyVaultManagedHSMSecurityDomainResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return managedhsms.ValidateManagedHSMID
+}
+
+func (r KeyVaultManagedHSMSecurityDomainResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 60 * time.Minute,
+		Func: func(ctx context.Context, meta sdk.ResourceMetaData) error {
+			client := meta.Client.ManagedHSMs.ManagedHsmClient
+
+			var model KeyVaultManagedHSMSecurityDomainModel
+			if err := meta.Decode(&model); err != nil {
+				return err
+			}
+
+			id, err := managedhsms.ParseManagedHSMID(model.ManagedHSMId)
+			if err != nil {
+				return err
+			}
+
+			locks.ByID(id.ID())
+			defer locks.UnlockByID(id.ID())
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if resp.Model == nil || resp.Model.Properties == nil || resp.Model.Properties.HsmUri == nil {
+				return fmt.Errorf("retrieving %s: `properties.hsmUri` was nil", id)
+			}
+			props := resp.Model.Properties
+
+			// the Security Domain can only be downloaded or uploaded once, when the Managed HSM is activated - as such
+			// this resource can't be used with a Managed HSM which has already been activated
+			if sd := props.SecurityDomainProperties; sd != nil && pointer.From(sd.ActivationStatus) == managedhsms.ActivationStatusActive {
+				return fmt.Errorf("%s has already been activated - the Security Domain can only be downloaded or uploaded once, so this resource can't be used with an existing (activated) Managed HSM", id)
+			}
+
+			if model.EncryptedData != "" {
+				if err := securityDomainUpload(ctx, meta.Client.ManagedHSMs, *props.HsmUri, model.KeyVaultCertificateIds, model.EncryptedData); err != nil {
+					return fmt.Errorf("uploading the Security Domain for %s: %+v", id, err)
+				}
+			} else {
+				if len(model.KeyVaultCertificateIds) < 3 {
+					return fmt.Errorf("at least 3 `key_vault_certificate_ids` must be specified to download the Security Domain for %s", id)
+				}
+
+				certificateIds := make([]interface{}, 0, len(model.KeyVaultCertificateIds))
+				for _, v := range model.KeyVaultCertificateIds {
+					certificateIds = append(certificateIds, v)
+				}
+
+				encryptedData, err := securityDomainDownload(ctx, meta.Client.ManagedHSMs, *props.HsmUri, certificateIds, int(model.Quorum))
+				if err != nil {
+					return fmt.Errorf("downloading the Security Domain for %s: %+v", id, err)
+				}
+				model.EncryptedData = encryptedData
+			}
+
+			meta.SetID(id)
+			return meta.Encode(&model)
+		},
+	}
+}
+
+func (r KeyVaultManagedHSMSecurityDomainResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, meta sdk.ResourceMetaData) error {
+			client := meta.Client.ManagedHSMs.ManagedHsmClient
+
+			id, err := managedhsms.ParseManagedHSMID(meta.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return meta.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			// a Managed HSM which is no longer activated (e.g. one which was recreated) needs activating again
+			if model := resp.Model; model != nil && model.Properties != nil {
+				if sd := model.Properties.SecurityDomainProperties; sd != nil && pointer.From(sd.ActivationStatus) == managedhsms.ActivationStatusNotActivated {
+					return meta.MarkAsGone(id)
+				}
+			}
+
+			var state KeyVaultManagedHSMSecurityDomainModel
+			if err := meta.Decode(&state); err != nil {
+				return err
+			}
+
+			// the certificates, quorum and encrypted data can't be retrieved from the API, so are kept as-is
+			state.ManagedHSMId = id.ID()
+
+			return meta.Encode(&state)
+		},
+	}
+}
+
+func (r KeyVaultManagedHSMSecurityDomainResource) CustomImporter() sdk.ResourceRunFunc {
+	return func(ctx context.Context, meta sdk.ResourceMetaData) error {
+		// the certificates and encrypted data can't be retrieved from the API once the Security Domain has been
+		// downloaded or uploaded, so importing this resource would leave it without the Security Domain
+		return fmt.Errorf("the Security Domain of a Managed HSM can't be imported, since the encrypted Security Domain can only be retrieved when it's downloaded")
+	}
+}
+
+func (r KeyVaultManagedHSMSecurityDomainResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, meta sdk.ResourceMetaData) error {
+			id, err := managedhsms.ParseManagedHSMID(meta.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			// a Managed HSM can't be deactivated, so there's nothing to do here other than removing this from the state
+			meta.Logger.Infof("the Security Domain for %s can't be removed - removing from state", id)
+			return nil
+		},
+	}
+}
+
+// securityDomainUpload uploads (restores) the Security Domain `encryptedData` to the Managed HSM, using the private keys
+// of the Key Vault Certificates to decrypt the required number of shares of the Security Domain
+func securityDomainUpload(ctx context.Context, cli *client.Client, vaultBaseUrl string, certificateIds []string, encryptedData string) error {
+	sdClient := cli.DataPlaneSecurityDomainsClient
+
+	privateKeys := make([]*rsa.PrivateKey, 0, len(certificateIds))
+	for _, certificateId := range certificateIds {
+		id, err := keyVaultParse.ParseNestedItemID(certificateId)
+		if err != nil {
+			return fmt.Errorf("parsing %q: %+v", certificateId, err)
+		}
+
+		// the private key of a Key Vault Certificate is available from the Secret of the same name, as such
+		// the Certificate must have been created with an exportable key
+		secret, err := cli.DataPlaneClient.GetSecret(ctx, id.KeyVaultBaseUrl, id.Name, id.Version)
+		if err != nil {
+			return fmt.Errorf("retrieving the private key of the Certificate %q: %+v", certificateId, err)
+		}
+		if secret.Value == nil {
+			return fmt.Errorf("retrieving the private key of the Certificate %q: `value` was nil", certificateId)
+		}
+
+		contents := []byte(*secret.Value)
+		if pointer.From(secret.ContentType) == certificatebundle.ContentTypePkcs12 {
+			if contents, err = base64.StdEncoding.DecodeString(*secret.Value); err != nil {
+				return fmt.Errorf("decoding the private key of the Certificate %q: %+v", certificateId, err)
+			}
+		}
+		bundle, err := certificatebundle.Decode(contents, "")
+		if err != nil {
+			return fmt.Errorf("decoding the private key of the Certificate %q: %+v", certificateId, err)
+		}
+		privateKey, ok := bundle.PrivateKey.(*rsa.PrivateKey)
+		if !ok {
+			return fmt.Errorf("expected the Certificate %q to have an RSA private key but got %T", certificateId, bundle.PrivateKey)
+		}
+		privateKeys = append(privateKeys, privateKey)
+	}
+
+	// retrieving the transfer key also puts the Managed HSM into recovery mode, ready for the Security Domain to be uploaded
+	transferKey, err := sdClient.TransferKeyMethod(ctx, vaultBaseUrl)
+	if err != nil {
+		return fmt.Errorf("retrieving the transfer key: %+v", err)
+	}
+	if transferKey.TransferKey == nil || transferKey.TransferKey.X5c == nil || len(*transferKey.TransferKey.X5c) == 0 {
+		return fmt.Errorf("retrieving the transfer key: `transfer_key.x5c` was nil")
+	}
+	rawCertificate, err := base64.StdEncoding.DecodeString((*transferKey.TransferKey.X5c)[0])
+	if err != nil {
+		return fmt.Errorf("decoding the transfer key: %+v", err)
+	}
+	transferCertificate, err := x509.ParseCertificate(rawCertificate)
+	if err != nil {
+		return fmt.Errorf("parsing the transfer key: %+v", err)
+	}
+
+	restoreBlob, err := securitydomain.RestoreBlob(encryptedData, privateKeys, transferCertificate)
+	if err != nil {
+		return err
+	}
+
+	if _, err := sdClient.Upload(ctx, vaultBaseUrl, kv74.SecurityDomainObject{Value: pointer.To(restoreBlob)}); err != nil {
+		return fmt.Errorf("uploading for %s: %+v", vaultBaseUrl, err)
+	}
+
+	pollerType := custompollers.NewHSMUploadPoller(sdClient, vaultBaseUrl)
+	poller := pollers.NewPoller(pollerType, 10*time.Second, pollers.DefaultNumberOfDroppedConnectionsToAllow)
+	if err := poller.PollUntilDone(ctx); err != nil {
+		return fmt.Errorf("waiting for the Security Domain to upload: %+v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package managedhsm_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-07-01/managedhsms"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type KeyVaultManagedHSMSecurityDomainResource struct{}
+
+// real test nested in TestAccKeyVaultManagedHardwareSecurityModule, only provide Exists logic here
+func (k KeyVaultManagedHSMSecurityDomainResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := managedhsms.ParseManagedHSMID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.ManagedHSMs.ManagedHsmClient.Get(ctx, *id)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+	if resp.Model == nil || resp.Model.Properties == nil || resp.Model.Properties.SecurityDomainProperties == nil {
+		return utils.Bool(false), nil
+	}
+	return utils.Bool(pointer.From(resp.Model.Properties.SecurityDomainProperties.ActivationStatus) == managedhsms.ActivationStatusActive), nil
+}
+
+func (k KeyVaultManagedHSMSecurityDomainResource) basic(data acceptance.TestData) string {
+	// the Managed HSM is provisioned without being activated, so that it can be activated by this resource
+	hsm := KeyVaultManagedHardwareSecurityModuleResource{}.download(data, 0)
+	return fmt.Sprintf(`
+
+
+%s
+
+resource "azurerm_key_vault_certificate" "security_domain" {
+  count        = 3
+  name         = "acchsmsdcert${count.index}"
+  key_vault_id = azurerm_key_vault.test.id
+  certificate_policy {
+    issuer_parameters {
+      name = "Self"
+    }
+    key_properties {
+      exportable = true
+      key_size   = 2048
+      key_type   = "RSA"
+      reuse_key  = true
+    }
+    lifetime_action {
+      action {
+        action_type = "AutoRenew"
+      }
+      trigger {
+        days_before_expiry = 30
+      }
+    }
+    secret_properties {
+      content_type = "application/x-pkcs12"
+    }
+    x509_certificate_properties {
+      extended_key_usage = []
+      key_usage = [
+        "cRLSign",
+        "dataEncipherment",
+        "digitalSignature",
+        "keyAgreement",
+        "keyCertSign",
+        "keyEncipherment",
+      ]
+      subject            = "CN=hello-world"
+      validity_in_months = 12
+    }
+  }
+}
+
+resource "azurerm_key_vault_managed_hardware_security_module_security_domain" "test" {
+  managed_hsm_id            = azurerm_key_vault_managed_hardware_security_module.test.id
+  key_vault_certificate_ids = [for cert in azurerm_key_vault_certificate.security_domain : cert.id]
+  quorum                    = 2
+}
+`, hsm)
+}
+
+func (k KeyVaultManagedHSMSecurityDomainResource) upload(data acceptance.TestData) string {
+	// the Security Domain downloaded from the first Managed HSM is uploaded to a second Managed HSM
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault_managed_hardware_security_module" "restored" {
+  name                     = "kvHsmr%d"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  sku_name                 = "Standard_B1"
+  tenant_id                = data.azurerm_client_config.current.tenant_id
+  admin_object_ids         = [data.azurerm_client_config.current.object_id]
+  purge_protection_enabled = false
+}
+
+resource "azurerm_key_vault_managed_hardware_security_module_security_domain" "restored" {
+  managed_hsm_id            = azurerm_key_vault_managed_hardware_security_module.restored.id
+  key_vault_certificate_ids = [for cert in azurerm_key_vault_certificate.security_domain : cert.id]
+  encrypted_data            = azurerm_key_vault_managed_hardware_security_module_security_domain.test.encrypted_data
+}
+`, k.basic(data), data.RandomInteger)
+}
@@ -0,0 +1,222 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package managedhsm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-07-01/managedhsms"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+type KeyVaultManagedHSMSettingModel struct {
+	ManagedHSMId string `tfschema:"managed_hsm_id"`
+	Name         string `tfschema:"name"`
+	Value        string `tfschema:"value"`
+	Type         string `tfschema:"type"`
+}
+
+type KeyVaultManagedHSMSettingResource struct{}
+
+var (
+	_ sdk.Resource           = KeyVaultManagedHSMSettingResource{}
+	_ sdk.ResourceWithUpdate = KeyVaultManagedHSMSettingResource{}
+)
+
+func (r KeyVaultManagedHSMSettingResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"managed_hsm_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: managedhsms.ValidateManagedHSMID,
+		},
+
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			// the API returns an error for unknown settings, as such we intentionally don't validate the name here
+			// so that new settings can be used without a provider release
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"value": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+	}
+}
+
+func (r KeyVaultManagedHSMSettingResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"type": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r KeyVaultManagedHSMSettingResource) ModelObject() interface{} {
+	return &KeyVaultManagedHSMSettingModel{}
+}
+
+func (r KeyVaultManagedHSMSettingResource) ResourceType() string {
+	return "azurerm_key_vault_managed_hardware_security_module_setting"
+}
+
+func (r KeyVaultManagedHSMSettingResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.ManagedHSMSettingId
+}
+
+func (r KeyVaultManagedHSMSettingResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, meta sdk.ResourceMetaData) error {
+			client := meta.Client.ManagedHSMs.DataPlaneManagedHSMClient
+
+			var model KeyVaultManagedHSMSettingModel
+			if err := meta.Decode(&model); err != nil {
+				return err
+			}
+
+			managedHSMId, err := managedhsms.ParseManagedHSMID(model.ManagedHSMId)
+			if err != nil {
+				return err
+			}
+
+			baseUri, err := meta.Client.ManagedHSMs.BaseUriForManagedHSM(ctx, *managedHSMId)
+			if err != nil {
+				return fmt.Errorf("determining the Data Plane URI for %s: %+v", managedHSMId, err)
+			}
+
+			id, err := parse.NewManagedHSMSettingID(*baseUri, model.Name)
+			if err != nil {
+				return err
+			}
+
+			locks.ByName(id.VaultBaseUrl, "azurerm_key_vault_managed_hardware_security_module")
+			defer locks.UnlockByName(id.VaultBaseUrl, "azurerm_key_vault_managed_hardware_security_module")
+
+			// settings always exist within a Managed HSM (and can't be removed) so we only check the setting is supported
+			existing, err := client.GetSetting(ctx, id.VaultBaseUrl, id.Name)
+			if err != nil {
+				if utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("%s isn't supported by this Managed HSM", id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			param := keyvault.UpdateSettingRequest{
+				Value: pointer.To(model.Value),
+			}
+			if _, err := client.UpdateSetting(ctx, id.VaultBaseUrl, id.Name, param); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			meta.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r KeyVaultManagedHSMSettingResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, meta sdk.ResourceMetaData) error {
+			client := meta.Client.ManagedHSMs.DataPlaneManagedHSMClient
+
+			id, err := parse.ManagedHSMSettingID(meta.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			managedHSMId, err := meta.Client.ManagedHSMs.ManagedHSMIDFromBaseUrl(ctx, commonids.NewSubscriptionID(meta.Client.Account.SubscriptionId), id.VaultBaseUrl)
+			if err != nil {
+				return fmt.Errorf("determining the Managed HSM ID for %s: %+v", id, err)
+			}
+			if managedHSMId == nil {
+				return meta.MarkAsGone(id)
+			}
+
+			resp, err := client.GetSetting(ctx, id.VaultBaseUrl, id.Name)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return meta.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			model := KeyVaultManagedHSMSettingModel{
+				ManagedHSMId: managedHSMId.ID(),
+				Name:         id.Name,
+				Value:        pointer.From(resp.Value),
+				Type:         string(resp.Type),
+			}
+
+			return meta.Encode(&model)
+		},
+	}
+}
+
+func (r KeyVaultManagedHSMSettingResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, meta sdk.ResourceMetaData) error {
+			client := meta.Client.ManagedHSMs.DataPlaneManagedHSMClient
+
+			id, err := parse.ManagedHSMSettingID(meta.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model KeyVaultManagedHSMSettingModel
+			if err := meta.Decode(&model); err != nil {
+				return err
+			}
+
+			locks.ByName(id.VaultBaseUrl, "azurerm_key_vault_managed_hardware_security_module")
+			defer locks.UnlockByName(id.VaultBaseUrl, "azurerm_key_vault_managed_hardware_security_module")
+
+			if meta.ResourceData.HasChange("value") {
+				param := keyvault.UpdateSettingRequest{
+					Value: pointer.To(model.Value),
+				}
+				if _, err := client.UpdateSetting(ctx, id.VaultBaseUrl, id.Name, param); err != nil {
+					return fmt.Errorf("updating %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r KeyVaultManagedHSMSettingResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, meta sdk.ResourceMetaData) error {
+			id, err := parse.ManagedHSMSettingID(meta.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			// settings can't be removed from a Managed HSM, so the current value is retained
+			meta.Logger.Infof("%s can't be removed from the Managed HSM - removing from state", id)
+			return nil
+		},
+	}
+}
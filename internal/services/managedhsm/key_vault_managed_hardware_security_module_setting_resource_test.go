@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package managedhsm_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type KeyVaultManagedHSMSettingResource struct{}
+
+// real test nested in TestAccKeyVaultManagedHardwareSecurityModule, only provide Exists logic here
+func (k KeyVaultManagedHSMSettingResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.ManagedHSMSettingID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.ManagedHSMs.DataPlaneManagedHSMClient.GetSetting(ctx, id.VaultBaseUrl, id.Name)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+	return utils.Bool(resp.Value != nil), nil
+}
+
+func (k KeyVaultManagedHSMSettingResource) basic(data acceptance.TestData, enabled bool) string {
+	hsm := KeyVaultManagedHardwareSecurityModuleResource{}.download(data, 3)
+	return fmt.Sprintf(`
+
+
+%s
+
+resource "azurerm_key_vault_managed_hardware_security_module_setting" "test" {
+  managed_hsm_id = azurerm_key_vault_managed_hardware_security_module.test.id
+  name           = "AllowKeyManagementOperationsThroughARM"
+  value          = "%t"
+}
+`, hsm, enabled)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+var _ resourceids.Id = ManagedHSMKeyId{}
+
+type ManagedHSMKeyId struct {
+	VaultBaseUrl string
+	Name         string
+	Version      string
+}
+
+func NewManagedHSMKeyID(hsmBaseUrl, name, version string) (*ManagedHSMKeyId, error) {
+	keyVaultUrl, err := url.Parse(hsmBaseUrl)
+	if err != nil || hsmBaseUrl == "" {
+		return nil, fmt.Errorf("parsing managedHSM nested itemID %q: %+v", hsmBaseUrl, err)
+	}
+	// the Data Plane API returns the port number in some responses, which we strip for consistency
+	if hostParts := strings.Split(keyVaultUrl.Host, ":"); len(hostParts) > 1 {
+		keyVaultUrl.Host = hostParts[0]
+	}
+
+	return &ManagedHSMKeyId{
+		VaultBaseUrl: strings.TrimSuffix(keyVaultUrl.String(), "/") + "/",
+		Name:         name,
+		Version:      version,
+	}, nil
+}
+
+func (n ManagedHSMKeyId) ID() string {
+	// example: https://tharvey-hsm.managedhsm.azure.net/keys/bird/fdf067c93bbb4b22bff4d8b7a9a56217
+	segments := []string{
+		strings.TrimSuffix(n.VaultBaseUrl, "/"),
+		"keys",
+		n.Name,
+	}
+	if n.Version != "" {
+		segments = append(segments, n.Version)
+	}
+	return strings.Join(segments, "/")
+}
+
+func (n ManagedHSMKeyId) VersionlessID() string {
+	// example: https://tharvey-hsm.managedhsm.azure.net/keys/bird
+	segments := []string{
+		strings.TrimSuffix(n.VaultBaseUrl, "/"),
+		"keys",
+		n.Name,
+	}
+	return strings.Join(segments, "/")
+}
+
+func (n ManagedHSMKeyId) String() string {
+	return fmt.Sprintf("Managed HSM Key %q (Managed HSM %q / Version %q)", n.Name, n.VaultBaseUrl, n.Version)
+}
+
+// ManagedHSMKeyID parses a Managed HSM Key ID, optionally containing a version, into a ManagedHSMKeyId
+func ManagedHSMKeyID(input string) (*ManagedHSMKeyId, error) {
+	idURL, err := url.ParseRequestURI(input)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse Managed HSM Key Id: %s", err)
+	}
+
+	path := strings.TrimSuffix(strings.TrimPrefix(idURL.Path, "/"), "/")
+	components := strings.Split(path, "/")
+	if len(components) != 2 && len(components) != 3 {
+		return nil, fmt.Errorf("managed HSM Key ID should contain 2 or 3 segments, found %d segment(s) in %q", len(components), input)
+	}
+	if components[0] != "keys" {
+		return nil, fmt.Errorf("managed HSM Key ID should contain the segment `keys`, got %q in %q", components[0], input)
+	}
+	if components[1] == "" {
+		return nil, fmt.Errorf("managed HSM Key ID should contain a name, got an empty name in %q", input)
+	}
+
+	version := ""
+	if len(components) == 3 {
+		version = components[2]
+	}
+
+	return NewManagedHSMKeyID(fmt.Sprintf("%s://%s/", idURL.Scheme, idURL.Host), components[1], version)
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"testing"
+)
+
+func TestNewManagedHSMKeyID(t *testing.T) {
+	cases := []struct {
+		Scenario        string
+		keyVaultBaseUrl string
+		Name            string
+		Version         string
+		Expected        string
+		ExpectError     bool
+	}{
+		{
+			Scenario:        "empty values",
+			keyVaultBaseUrl: "",
+			Expected:        "",
+			ExpectError:     true,
+		},
+		{
+			Scenario:        "valid, no port",
+			keyVaultBaseUrl: "https://test.managedhsm.azure.net",
+			Name:            "test",
+			Version:         "fdf067c93bbb4b22bff4d8b7a9a56217",
+			Expected:        "https://test.managedhsm.azure.net/keys/test/fdf067c93bbb4b22bff4d8b7a9a56217",
+			ExpectError:     false,
+		},
+		{
+			Scenario:        "valid, with port",
+			keyVaultBaseUrl: "https://test.managedhsm.azure.net:443/",
+			Name:            "test",
+			Version:         "fdf067c93bbb4b22bff4d8b7a9a56217",
+			Expected:        "https://test.managedhsm.azure.net/keys/test/fdf067c93bbb4b22bff4d8b7a9a56217",
+			ExpectError:     false,
+		},
+		{
+			Scenario:        "valid, versionless",
+			keyVaultBaseUrl: "https://test.managedhsm.azure.net/",
+			Name:            "test",
+			Expected:        "https://test.managedhsm.azure.net/keys/test",
+			ExpectError:     false,
+		},
+	}
+	for _, tc := range cases {
+		id, err := NewManagedHSMKeyID(tc.keyVaultBaseUrl, tc.Name, tc.Version)
+		if err != nil {
+			if !tc.ExpectError {
+				t.Fatalf("Got error for New Resource ID '%s': %+v", tc.keyVaultBaseUrl, err)
+				return
+			}
+			continue
+		}
+		if tc.ExpectError {
+			t.Fatalf("Expected an error for %q but didn't get one", tc.Scenario)
+		}
+		if id.ID() != tc.Expected {
+			t.Fatalf("Expected id for %q to be %q, got %q", tc.Scenario, tc.Expected, id.ID())
+		}
+	}
+}
+
+func TestParseManagedHSMKeyID(t *testing.T) {
+	cases := []struct {
+		Input       string
+		Expected    ManagedHSMKeyId
+		ExpectError bool
+	}{
+		{
+			Input:       "",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-hsm.managedhsm.azure.net/keys",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-hsm.managedhsm.azure.net/secrets/test",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-hsm.managedhsm.azure.net/keys/test/fdf067c93bbb4b22bff4d8b7a9a56217/extra",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-hsm.managedhsm.azure.net/keys/test",
+			ExpectError: false,
+			Expected: ManagedHSMKeyId{
+				VaultBaseUrl: "https://my-hsm.managedhsm.azure.net/",
+				Name:         "test",
+			},
+		},
+		{
+			Input:       "https://my-hsm.managedhsm.azure.net/keys/test/fdf067c93bbb4b22bff4d8b7a9a56217",
+			ExpectError: false,
+			Expected: ManagedHSMKeyId{
+				VaultBaseUrl: "https://my-hsm.managedhsm.azure.net/",
+				Name:         "test",
+				Version:      "fdf067c93bbb4b22bff4d8b7a9a56217",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		keyId, err := ManagedHSMKeyID(tc.Input)
+		if err != nil {
+			if tc.ExpectError {
+				continue
+			}
+
+			t.Fatalf("Got error for ID '%s': %+v", tc.Input, err)
+		}
+		if tc.ExpectError {
+			t.Fatalf("Expected an error for ID '%s' but didn't get one", tc.Input)
+		}
+
+		if tc.Expected.VaultBaseUrl != keyId.VaultBaseUrl {
+			t.Fatalf("Expected 'VaultBaseUrl' to be '%s', got '%s' for ID '%s'", tc.Expected.VaultBaseUrl, keyId.VaultBaseUrl, tc.Input)
+		}
+
+		if tc.Expected.Name != keyId.Name {
+			t.Fatalf("Expected 'Name' to be '%s', got '%s' for ID '%s'", tc.Expected.Name, keyId.Name, tc.Input)
+		}
+
+		if tc.Expected.Version != keyId.Version {
+			t.Fatalf("Expected 'Version' to be '%s', got '%s' for ID '%s'", tc.Expected.Version, keyId.Version, tc.Input)
+		}
+
+		if tc.Input != keyId.ID() {
+			t.Fatalf("Expected 'ID()' to be '%s', got '%s'", tc.Input, keyId.ID())
+		}
+	}
+}
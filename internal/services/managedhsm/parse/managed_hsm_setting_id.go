@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+var _ resourceids.Id = ManagedHSMSettingId{}
+
+type ManagedHSMSettingId struct {
+	VaultBaseUrl string
+	Name         string
+}
+
+func NewManagedHSMSettingID(hsmBaseUrl, name string) (*ManagedHSMSettingId, error) {
+	keyVaultUrl, err := url.Parse(hsmBaseUrl)
+	if err != nil || hsmBaseUrl == "" {
+		return nil, fmt.Errorf("parsing managedHSM nested itemID %q: %+v", hsmBaseUrl, err)
+	}
+	// the Data Plane API returns the port number in some responses, which we strip for consistency
+	if hostParts := strings.Split(keyVaultUrl.Host, ":"); len(hostParts) > 1 {
+		keyVaultUrl.Host = hostParts[0]
+	}
+
+	return &ManagedHSMSettingId{
+		VaultBaseUrl: strings.TrimSuffix(keyVaultUrl.String(), "/") + "/",
+		Name:         name,
+	}, nil
+}
+
+func (n ManagedHSMSettingId) ID() string {
+	// example: https://tharvey-hsm.managedhsm.azure.net/settings/AllowKeyManagementOperationsThroughARM
+	segments := []string{
+		strings.TrimSuffix(n.VaultBaseUrl, "/"),
+		"settings",
+		n.Name,
+	}
+	return strings.Join(segments, "/")
+}
+
+func (n ManagedHSMSettingId) String() string {
+	return fmt.Sprintf("Managed HSM Setting %q (Managed HSM %q)", n.Name, n.VaultBaseUrl)
+}
+
+// ManagedHSMSettingID parses a Managed HSM Setting ID into a ManagedHSMSettingId
+func ManagedHSMSettingID(input string) (*ManagedHSMSettingId, error) {
+	idURL, err := url.ParseRequestURI(input)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse Managed HSM Setting Id: %s", err)
+	}
+
+	path := strings.TrimSuffix(strings.TrimPrefix(idURL.Path, "/"), "/")
+	components := strings.Split(path, "/")
+	if len(components) != 2 {
+		return nil, fmt.Errorf("managed HSM Setting ID should contain 2 segments, found %d segment(s) in %q", len(components), input)
+	}
+	if components[0] != "settings" {
+		return nil, fmt.Errorf("managed HSM Setting ID should contain the segment `settings`, got %q in %q", components[0], input)
+	}
+	if components[1] == "" {
+		return nil, fmt.Errorf("managed HSM Setting ID should contain a name, got an empty name in %q", input)
+	}
+
+	return NewManagedHSMSettingID(fmt.Sprintf("%s://%s/", idURL.Scheme, idURL.Host), components[1])
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"testing"
+)
+
+func TestParseManagedHSMSettingID(t *testing.T) {
+	cases := []struct {
+		Input       string
+		Expected    ManagedHSMSettingId
+		ExpectError bool
+	}{
+		{
+			Input:       "",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-hsm.managedhsm.azure.net/settings",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-hsm.managedhsm.azure.net/keys/AllowKeyManagementOperationsThroughARM",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-hsm.managedhsm.azure.net/settings/AllowKeyManagementOperationsThroughARM/extra",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-hsm.managedhsm.azure.net/settings/AllowKeyManagementOperationsThroughARM",
+			ExpectError: false,
+			Expected: ManagedHSMSettingId{
+				VaultBaseUrl: "https://my-hsm.managedhsm.azure.net/",
+				Name:         "AllowKeyManagementOperationsThroughARM",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		settingId, err := ManagedHSMSettingID(tc.Input)
+		if err != nil {
+			if tc.ExpectError {
+				continue
+			}
+
+			t.Fatalf("Got error for ID '%s': %+v", tc.Input, err)
+		}
+		if tc.ExpectError {
+			t.Fatalf("Expected an error for ID '%s' but didn't get one", tc.Input)
+		}
+
+		if tc.Expected.VaultBaseUrl != settingId.VaultBaseUrl {
+			t.Fatalf("Expected 'VaultBaseUrl' to be '%s', got '%s' for ID '%s'", tc.Expected.VaultBaseUrl, settingId.VaultBaseUrl, tc.Input)
+		}
+
+		if tc.Expected.Name != settingId.Name {
+			t.Fatalf("Expected 'Name' to be '%s', got '%s' for ID '%s'", tc.Expected.Name, settingId.Name, tc.Input)
+		}
+
+		if tc.Input != settingId.ID() {
+			t.Fatalf("Expected 'ID()' to be '%s', got '%s'", tc.Input, settingId.ID())
+		}
+	}
+}
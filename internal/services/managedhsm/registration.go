@@ -42,7 +42,8 @@ func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 // SupportedResources returns the supported Resources supported by this Service
 func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azurerm_key_vault_managed_hardware_security_module": resourceKeyVaultManagedHardwareSecurityModule(),
+		"azurerm_key_vault_managed_hardware_security_module":     resourceKeyVaultManagedHardwareSecurityModule(),
+		"azurerm_key_vault_managed_hardware_security_module_key": resourceKeyVaultManagedHardwareSecurityModuleKey(),
 	}
 }
 
@@ -56,5 +57,7 @@ func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
 		KeyVaultMHSMRoleDefinitionResource{},
 		KeyVaultManagedHSMRoleAssignmentResource{},
+		KeyVaultManagedHSMSecurityDomainResource{},
+		KeyVaultManagedHSMSettingResource{},
 	}
 }
@@ -0,0 +1,207 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package securitydomain
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+const (
+	jweAlgorithmDirect        = "dir"
+	jweAlgorithmRsaOaep       = "RSA-OAEP"
+	jweAlgorithmRsaOaep256    = "RSA-OAEP-256"
+	jweEncryptionA256CbcHs512 = "A256CBC-HS512"
+)
+
+type jweHeader struct {
+	Algorithm  string `json:"alg"`
+	Encryption string `json:"enc"`
+	KeyId      string `json:"kid,omitempty"`
+	X5tS256    string `json:"x5t#S256,omitempty"`
+}
+
+// contentKeyFunc returns the content encryption key for the JWE, given its header and encrypted key
+type contentKeyFunc func(header jweHeader, encryptedKey []byte) ([]byte, error)
+
+func directKey(key []byte) contentKeyFunc {
+	return func(header jweHeader, encryptedKey []byte) ([]byte, error) {
+		if header.Algorithm != jweAlgorithmDirect {
+			return nil, fmt.Errorf("expected the algorithm %q but got %q", jweAlgorithmDirect, header.Algorithm)
+		}
+		return key, nil
+	}
+}
+
+func privateKeyDecrypter(privateKey *rsa.PrivateKey) contentKeyFunc {
+	return func(header jweHeader, encryptedKey []byte) ([]byte, error) {
+		var h hash.Hash
+		switch header.Algorithm {
+		case jweAlgorithmRsaOaep:
+			h = sha1.New()
+		case jweAlgorithmRsaOaep256:
+			h = sha256.New()
+		default:
+			return nil, fmt.Errorf("unsupported key algorithm %q", header.Algorithm)
+		}
+		return rsa.DecryptOAEP(h, nil, privateKey, encryptedKey, nil)
+	}
+}
+
+// decryptJwe decrypts a JWE in the compact serialization format
+func decryptJwe(input string, contentKey contentKeyFunc) ([]byte, error) {
+	segments := strings.Split(input, ".")
+	if len(segments) != 5 {
+		return nil, fmt.Errorf("expected a compact JWE to contain 5 segments but got %d", len(segments))
+	}
+
+	decoded := make([][]byte, 5)
+	for i, segment := range segments {
+		v, err := base64.RawURLEncoding.DecodeString(segment)
+		if err != nil {
+			return nil, fmt.Errorf("decoding segment %d of the JWE: %+v", i, err)
+		}
+		decoded[i] = v
+	}
+
+	var header jweHeader
+	if err := json.Unmarshal(decoded[0], &header); err != nil {
+		return nil, fmt.Errorf("parsing the JWE header: %+v", err)
+	}
+	if header.Encryption != jweEncryptionA256CbcHs512 {
+		return nil, fmt.Errorf("unsupported content encryption algorithm %q", header.Encryption)
+	}
+
+	key, err := contentKey(header, decoded[1])
+	if err != nil {
+		return nil, err
+	}
+
+	// the additional authenticated data is the encoded header
+	return decryptA256CbcHs512(key, decoded[2], decoded[3], []byte(segments[0]), decoded[4])
+}
+
+// encryptJweUsingPublicKey encrypts the plaintext using a random content encryption key, which is encrypted using
+// RSA-OAEP-256 with the public key of the certificate with the specified thumbprint
+func encryptJweUsingPublicKey(publicKey *rsa.PublicKey, thumbprint string, plaintext []byte) (string, error) {
+	key := make([]byte, 64)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	encryptedKey, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, publicKey, key, nil)
+	if err != nil {
+		return "", err
+	}
+
+	header := jweHeader{
+		Algorithm:  jweAlgorithmRsaOaep256,
+		Encryption: jweEncryptionA256CbcHs512,
+		X5tS256:    thumbprint,
+	}
+	return encryptJwe(header, key, encryptedKey, plaintext)
+}
+
+func encryptJwe(header jweHeader, key, encryptedKey, plaintext []byte) (string, error) {
+	headerJson, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	encodedHeader := base64.RawURLEncoding.EncodeToString(headerJson)
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return "", err
+	}
+
+	ciphertext, tag, err := encryptA256CbcHs512(key, iv, plaintext, []byte(encodedHeader))
+	if err != nil {
+		return "", err
+	}
+
+	segments := []string{
+		encodedHeader,
+		base64.RawURLEncoding.EncodeToString(encryptedKey),
+		base64.RawURLEncoding.EncodeToString(iv),
+		base64.RawURLEncoding.EncodeToString(ciphertext),
+		base64.RawURLEncoding.EncodeToString(tag),
+	}
+	return strings.Join(segments, "."), nil
+}
+
+// encryptA256CbcHs512 implements AES_256_CBC_HMAC_SHA_512 as defined in RFC7518 section 5.2.5
+func encryptA256CbcHs512(key, iv, plaintext, aad []byte) (ciphertext []byte, tag []byte, err error) {
+	if len(key) != 64 {
+		return nil, nil, fmt.Errorf("expected a 64 byte key but got %d bytes", len(key))
+	}
+	macKey, encKey := key[:32], key[32:]
+
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	padding := aes.BlockSize - len(plaintext)%aes.BlockSize
+	padded := make([]byte, len(plaintext), len(plaintext)+padding)
+	copy(padded, plaintext)
+	for i := 0; i < padding; i++ {
+		padded = append(padded, byte(padding))
+	}
+
+	ciphertext = make([]byte, len(padded))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, padded)
+
+	return ciphertext, authenticationTag(macKey, aad, iv, ciphertext), nil
+}
+
+func decryptA256CbcHs512(key, iv, ciphertext, aad, tag []byte) ([]byte, error) {
+	if len(key) != 64 {
+		return nil, fmt.Errorf("expected a 64 byte key but got %d bytes", len(key))
+	}
+	macKey, encKey := key[:32], key[32:]
+
+	if subtle.ConstantTimeCompare(authenticationTag(macKey, aad, iv, ciphertext), tag) != 1 {
+		return nil, fmt.Errorf("the authentication tag of the JWE was invalid")
+	}
+
+	if len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 || len(iv) != aes.BlockSize {
+		return nil, fmt.Errorf("the ciphertext of the JWE was invalid")
+	}
+
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+
+	padding := int(plaintext[len(plaintext)-1])
+	if padding == 0 || padding > aes.BlockSize {
+		return nil, fmt.Errorf("the padding of the JWE was invalid")
+	}
+	return plaintext[:len(plaintext)-padding], nil
+}
+
+func authenticationTag(macKey, aad, iv, ciphertext []byte) []byte {
+	mac := hmac.New(sha512.New, macKey)
+	mac.Write(aad)
+	mac.Write(iv)
+	mac.Write(ciphertext)
+	_ = binary.Write(mac, binary.BigEndian, uint64(len(aad))*8)
+	return mac.Sum(nil)[:32]
+}
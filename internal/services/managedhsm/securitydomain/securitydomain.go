@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package securitydomain builds the restore blob used to upload a Security Domain to a Managed HSM.
+//
+// A downloaded Security Domain contains the data of the Managed HSM encrypted using a master key, which is split into
+// shares (using Shamir's Secret Sharing) with each share encrypted using the public key of one of the quorum of
+// certificates. Uploading the Security Domain requires decrypting at least the required number of shares using the
+// private keys of those certificates, recombining the master key and then wrapping it using the transfer key of the
+// Managed HSM which the Security Domain is being uploaded to.
+package securitydomain
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+const kdfSp800108 = "sp108_kdf"
+
+type securityDomain struct {
+	EncData    encryptedData `json:"EncData"`
+	SharedKeys sharedKeys    `json:"SharedKeys"`
+}
+
+type encryptedData struct {
+	Data []encryptedDatum `json:"data"`
+	Kdf  string           `json:"kdf"`
+}
+
+type encryptedDatum struct {
+	CompactJwe string `json:"compact_jwe"`
+	Tag        string `json:"tag"`
+}
+
+type sharedKeys struct {
+	EncShares []encryptedKey `json:"enc_shares"`
+	Required  int            `json:"required"`
+}
+
+type encryptedKey struct {
+	EncKey  string `json:"enc_key"`
+	X5tS256 string `json:"x5t_256"`
+}
+
+type restoreBlob struct {
+	EncData    encryptedData `json:"EncData"`
+	WrappedKey encryptedKey  `json:"WrappedKey"`
+}
+
+// RestoreBlob returns the restore blob for the downloaded Security Domain `input`, which is decrypted using the
+// `privateKeys` of (at least the required number of) the quorum of certificates and wrapped using the `transferKey`
+// of the Managed HSM which it's being uploaded to
+func RestoreBlob(input string, privateKeys []*rsa.PrivateKey, transferKey *x509.Certificate) (string, error) {
+	sd, err := parseSecurityDomain(input)
+	if err != nil {
+		return "", err
+	}
+
+	if sd.EncData.Kdf != kdfSp800108 {
+		return "", fmt.Errorf("the Security Domain uses an unsupported key derivation function %q", sd.EncData.Kdf)
+	}
+
+	shares, err := decryptShares(sd.SharedKeys, privateKeys)
+	if err != nil {
+		return "", err
+	}
+
+	masterKey, err := combineShares(shares)
+	if err != nil {
+		return "", fmt.Errorf("combining the shares of the Security Domain: %+v", err)
+	}
+
+	// whilst the encrypted data is uploaded as-is, decrypting it confirms the master key was recombined correctly
+	for _, datum := range sd.EncData.Data {
+		if _, err := decryptJwe(datum.CompactJwe, directKey(deriveKey(masterKey, datum.Tag, 512))); err != nil {
+			return "", fmt.Errorf("decrypting the Security Domain data %q: %+v", datum.Tag, err)
+		}
+	}
+
+	publicKey, ok := transferKey.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return "", fmt.Errorf("expected the transfer key to be an RSA key but got %T", transferKey.PublicKey)
+	}
+	thumbprint := Thumbprint(transferKey)
+	wrappedKey, err := encryptJweUsingPublicKey(publicKey, thumbprint, masterKey)
+	if err != nil {
+		return "", fmt.Errorf("wrapping the master key of the Security Domain: %+v", err)
+	}
+
+	blob := restoreBlob{
+		EncData: sd.EncData,
+		WrappedKey: encryptedKey{
+			EncKey:  wrappedKey,
+			X5tS256: thumbprint,
+		},
+	}
+	out, err := json.Marshal(blob)
+	if err != nil {
+		return "", fmt.Errorf("marshalling the restore blob: %+v", err)
+	}
+	return string(out), nil
+}
+
+// Thumbprint returns the base64url encoded SHA-256 thumbprint of the certificate
+func Thumbprint(certificate *x509.Certificate) string {
+	sum := sha256.Sum256(certificate.Raw)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func parseSecurityDomain(input string) (*securityDomain, error) {
+	// the Security Domain may also be wrapped in the `value` of the download response
+	var wrapper struct {
+		Value *string `json:"value"`
+	}
+	if err := json.Unmarshal([]byte(input), &wrapper); err == nil && wrapper.Value != nil {
+		input = *wrapper.Value
+	}
+
+	var sd securityDomain
+	if err := json.Unmarshal([]byte(input), &sd); err != nil {
+		return nil, fmt.Errorf("parsing the Security Domain: %+v", err)
+	}
+	if len(sd.EncData.Data) == 0 || len(sd.SharedKeys.EncShares) == 0 || sd.SharedKeys.Required == 0 {
+		return nil, fmt.Errorf("parsing the Security Domain: expected `EncData` and `SharedKeys` to be populated")
+	}
+
+	return &sd, nil
+}
+
+// decryptShares decrypts the required number of shares, trying each of the private keys against each share
+func decryptShares(input sharedKeys, privateKeys []*rsa.PrivateKey) ([][]uint16, error) {
+	shares := make([][]uint16, 0, input.Required)
+	used := make(map[int]bool)
+
+	for _, share := range input.EncShares {
+		for i, privateKey := range privateKeys {
+			if used[i] {
+				continue
+			}
+
+			plaintext, err := decryptJwe(share.EncKey, privateKeyDecrypter(privateKey))
+			if err != nil {
+				// this share was encrypted using a different certificate
+				continue
+			}
+
+			decoded, err := decodeShare(plaintext)
+			if err != nil {
+				return nil, err
+			}
+			shares = append(shares, decoded)
+			used[i] = true
+			break
+		}
+
+		if len(shares) == input.Required {
+			return shares, nil
+		}
+	}
+
+	return nil, fmt.Errorf("only %d of the %d shares required to decrypt the Security Domain could be decrypted using the specified certificates", len(shares), input.Required)
+}
@@ -0,0 +1,216 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package securitydomain
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestCombineShares(t *testing.T) {
+	secret := []byte("the-master-key-of-the-managedhsm")
+
+	shares := splitSecret(t, secret, 5, 3)
+
+	testData := []struct {
+		name   string
+		shares [][]uint16
+		error  bool
+	}{
+		{
+			name:   "first three shares",
+			shares: shares[:3],
+		},
+		{
+			name:   "last three shares",
+			shares: shares[2:],
+		},
+		{
+			name:   "all shares",
+			shares: shares,
+		},
+		{
+			name:   "duplicate shares",
+			shares: [][]uint16{shares[0], shares[0], shares[1]},
+			error:  true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		actual, err := combineShares(v.shares)
+		if err != nil {
+			if v.error {
+				continue
+			}
+			t.Fatalf("unexpected error for %q: %+v", v.name, err)
+		}
+		if v.error {
+			t.Fatalf("expected an error for %q but didn't get one", v.name)
+		}
+		if !bytes.Equal(actual, secret) {
+			t.Fatalf("expected %q but got %q for %q", secret, actual, v.name)
+		}
+	}
+}
+
+func TestJweRoundTrip(t *testing.T) {
+	key := deriveKey([]byte("master-key"), "tag", 512)
+	plaintext := []byte("some data which isn't a multiple of the block size")
+
+	encrypted, err := encryptJwe(jweHeader{Algorithm: jweAlgorithmDirect, Encryption: jweEncryptionA256CbcHs512}, key, nil, plaintext)
+	if err != nil {
+		t.Fatalf("encrypting: %+v", err)
+	}
+
+	actual, err := decryptJwe(encrypted, directKey(key))
+	if err != nil {
+		t.Fatalf("decrypting: %+v", err)
+	}
+	if !bytes.Equal(actual, plaintext) {
+		t.Fatalf("expected %q but got %q", plaintext, actual)
+	}
+
+	if _, err := decryptJwe(encrypted, directKey(deriveKey([]byte("another-key"), "tag", 512))); err == nil {
+		t.Fatalf("expected an error when decrypting using a different key")
+	}
+}
+
+func TestRestoreBlob(t *testing.T) {
+	masterKey := make([]byte, 32)
+	if _, err := rand.Read(masterKey); err != nil {
+		t.Fatal(err)
+	}
+
+	quorumKeys := []*rsa.PrivateKey{generateKey(t), generateKey(t), generateKey(t)}
+	shares := splitSecret(t, masterKey, len(quorumKeys), 2)
+
+	sd := securityDomain{
+		EncData: encryptedData{
+			Kdf: kdfSp800108,
+		},
+		SharedKeys: sharedKeys{
+			Required: 2,
+		},
+	}
+	for i, share := range shares {
+		encoded := make([]byte, len(share)*2)
+		for j, v := range share {
+			binary.LittleEndian.PutUint16(encoded[j*2:], v)
+		}
+		encrypted, err := encryptJweUsingPublicKey(&quorumKeys[i].PublicKey, "", encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sd.SharedKeys.EncShares = append(sd.SharedKeys.EncShares, encryptedKey{EncKey: encrypted})
+	}
+	encrypted, err := encryptJwe(jweHeader{Algorithm: jweAlgorithmDirect, Encryption: jweEncryptionA256CbcHs512, KeyId: "data"}, deriveKey(masterKey, "data", 512), nil, []byte("the managed hsm data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sd.EncData.Data = append(sd.EncData.Data, encryptedDatum{CompactJwe: encrypted, Tag: "data"})
+
+	raw, err := json.Marshal(sd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transferKey := generateKey(t)
+	transferCertificate := generateCertificate(t, transferKey)
+
+	// an unrelated key and the last two of the quorum are sufficient to decrypt the shares
+	blob, err := RestoreBlob(string(raw), []*rsa.PrivateKey{generateKey(t), quorumKeys[2], quorumKeys[1]}, transferCertificate)
+	if err != nil {
+		t.Fatalf("building the restore blob: %+v", err)
+	}
+
+	var actual restoreBlob
+	if err := json.Unmarshal([]byte(blob), &actual); err != nil {
+		t.Fatalf("parsing the restore blob: %+v", err)
+	}
+	if actual.WrappedKey.X5tS256 != Thumbprint(transferCertificate) {
+		t.Fatalf("expected the thumbprint %q but got %q", Thumbprint(transferCertificate), actual.WrappedKey.X5tS256)
+	}
+	unwrapped, err := decryptJwe(actual.WrappedKey.EncKey, privateKeyDecrypter(transferKey))
+	if err != nil {
+		t.Fatalf("unwrapping the master key: %+v", err)
+	}
+	if !bytes.Equal(unwrapped, masterKey) {
+		t.Fatalf("expected the wrapped key to be the master key")
+	}
+	if len(actual.EncData.Data) != 1 || actual.EncData.Data[0] != sd.EncData.Data[0] {
+		t.Fatalf("expected the encrypted data to be unchanged")
+	}
+
+	// a single key of the quorum isn't sufficient
+	if _, err := RestoreBlob(string(raw), []*rsa.PrivateKey{quorumKeys[0]}, transferCertificate); err == nil {
+		t.Fatalf("expected an error when too few shares can be decrypted")
+	}
+}
+
+// splitSecret splits the secret into `count` shares, `required` of which are needed to recombine it
+func splitSecret(t *testing.T, secret []byte, count, required int) [][]uint16 {
+	shares := make([][]uint16, count)
+	for i := range shares {
+		shares[i] = make([]uint16, len(secret))
+	}
+
+	for i, b := range secret {
+		coefficients := []int{int(b)}
+		for j := 1; j < required; j++ {
+			v, err := rand.Int(rand.Reader, big.NewInt(sharePrime))
+			if err != nil {
+				t.Fatal(err)
+			}
+			coefficients = append(coefficients, int(v.Int64()))
+		}
+
+		for x := 1; x <= count; x++ {
+			y := 0
+			power := 1
+			for _, c := range coefficients {
+				y = (y + c*power) % sharePrime
+				power = power * x % sharePrime
+			}
+			shares[x-1][i] = uint16(x<<9 | y)
+		}
+	}
+
+	return shares
+}
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func generateCertificate(t *testing.T, key *rsa.PrivateKey) *x509.Certificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "transfer-key"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certificate
+}
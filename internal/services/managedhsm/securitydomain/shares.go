@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package securitydomain
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+)
+
+// sharePrime is the prime field over which each byte of the master key is split
+const sharePrime = 257
+
+// decodeShare decodes a share, which is a little-endian array of uint16 values - one for each byte of the master key
+func decodeShare(input []byte) ([]uint16, error) {
+	if len(input) == 0 || len(input)%2 != 0 {
+		return nil, fmt.Errorf("expected a share to contain an even number of bytes but got %d", len(input))
+	}
+
+	out := make([]uint16, len(input)/2)
+	for i := range out {
+		out[i] = binary.LittleEndian.Uint16(input[i*2:])
+	}
+	return out, nil
+}
+
+// combineShares recombines the master key from the shares using Lagrange interpolation, where each uint16 value
+// within a share contains the x coordinate in the upper 7 bits and the y coordinate in the lower 9 bits
+func combineShares(shares [][]uint16) ([]byte, error) {
+	length := len(shares[0])
+	for _, share := range shares {
+		if len(share) != length {
+			return nil, fmt.Errorf("expected all shares to have the same length")
+		}
+	}
+
+	out := make([]byte, length)
+	for i := 0; i < length; i++ {
+		secret := 0
+		for j, share := range shares {
+			xj := int(share[i] >> 9)
+			yj := int(share[i] & 0x1ff)
+
+			numerator := 1
+			denominator := 1
+			for k, other := range shares {
+				if j == k {
+					continue
+				}
+				xk := int(other[i] >> 9)
+				if xk == xj {
+					return nil, fmt.Errorf("the shares contain duplicate x coordinates")
+				}
+				numerator = numerator * xk % sharePrime
+				denominator = denominator * ((xk - xj + sharePrime) % sharePrime) % sharePrime
+			}
+
+			coefficient := numerator * modInverse(denominator) % sharePrime
+			secret = (secret + coefficient*yj) % sharePrime
+		}
+
+		if secret > 255 {
+			return nil, fmt.Errorf("the recombined secret was out of range")
+		}
+		out[i] = byte(secret)
+	}
+
+	return out, nil
+}
+
+// modInverse returns the multiplicative inverse of `a` within the prime field, using Fermat's little theorem
+func modInverse(a int) int {
+	result := 1
+	base := a % sharePrime
+	for exponent := sharePrime - 2; exponent > 0; exponent >>= 1 {
+		if exponent&1 == 1 {
+			result = result * base % sharePrime
+		}
+		base = base * base % sharePrime
+	}
+	return result
+}
+
+// deriveKey derives a key of `bits` length from the master key using the SP800-108 KDF in counter mode (HMAC-SHA512),
+// with the tag of the encrypted data as the label and an empty context
+func deriveKey(key []byte, label string, bits int) []byte {
+	out := make([]byte, 0, bits/8)
+	for counter := uint32(1); len(out) < bits/8; counter++ {
+		mac := hmac.New(sha512.New, key)
+		_ = binary.Write(mac, binary.BigEndian, counter)
+		mac.Write([]byte(label))
+		mac.Write([]byte{0x00})
+		_ = binary.Write(mac, binary.BigEndian, uint32(bits))
+		out = mac.Sum(out)
+	}
+	return out[:bits/8]
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"testing"
+)
+
+func TestManagedHSMKeyId(t *testing.T) {
+	cases := []struct {
+		Input       string
+		ExpectError bool
+	}{
+		{
+			Input:       "",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-hsm.managedhsm.azure.net/",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-hsm.managedhsm.azure.net/secrets/test",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-hsm.managedhsm.azure.net/keys/test",
+			ExpectError: false,
+		},
+		{
+			Input:       "https://my-hsm.managedhsm.azure.net/keys/test/fdf067c93bbb4b22bff4d8b7a9a56217",
+			ExpectError: false,
+		},
+		{
+			Input:       "https://my-hsm.managedhsm.azure.net/keys/test/fdf067c93bbb4b22bff4d8b7a9a56217/suffix",
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := ManagedHSMKeyId(tc.Input, "example")
+		if tc.ExpectError && len(errors) == 0 {
+			t.Fatalf("Got no errors for input %q but expected some", tc.Input)
+		} else if !tc.ExpectError && len(errors) > 0 {
+			t.Fatalf("Got %d errors for input %q when didn't expect any", len(errors), tc.Input)
+		}
+	}
+}
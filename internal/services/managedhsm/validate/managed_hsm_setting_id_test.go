@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"testing"
+)
+
+func TestManagedHSMSettingId(t *testing.T) {
+	cases := []struct {
+		Input       string
+		ExpectError bool
+	}{
+		{
+			Input:       "",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-hsm.managedhsm.azure.net/",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-hsm.managedhsm.azure.net/keys/AllowKeyManagementOperationsThroughARM",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-hsm.managedhsm.azure.net/settings/AllowKeyManagementOperationsThroughARM",
+			ExpectError: false,
+		},
+		{
+			Input:       "https://my-hsm.managedhsm.azure.net/settings/AllowKeyManagementOperationsThroughARM/suffix",
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := ManagedHSMSettingId(tc.Input, "example")
+		if tc.ExpectError && len(errors) == 0 {
+			t.Fatalf("Got no errors for input %q but expected some", tc.Input)
+		} else if !tc.ExpectError && len(errors) > 0 {
+			t.Fatalf("Got %d errors for input %q when didn't expect any", len(errors), tc.Input)
+		}
+	}
+}
@@ -4,6 +4,7 @@
 package network
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -18,6 +19,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/quota"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
@@ -29,7 +31,7 @@ import (
 )
 
 func resourcePublicIp() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
+	return quota.WithWarnings(&pluginsdk.Resource{
 		Create: resourcePublicIpCreateUpdate,
 		Read:   resourcePublicIpRead,
 		Update: resourcePublicIpCreateUpdate,
@@ -175,7 +177,35 @@ func resourcePublicIp() *pluginsdk.Resource {
 
 			"tags": tags.Schema(),
 		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourcePublicIpQuotaPreflight),
+	}, resourcePublicIpQuotaKey)
+}
+
+// resourcePublicIpQuotaPreflight checks the Public IP Address Quota is sufficient when creating a Public IP Address
+func resourcePublicIpQuotaPreflight(ctx context.Context, diff *pluginsdk.ResourceDiff, meta interface{}) error {
+	if diff.Id() != "" || !diff.NewValueKnown("location") || !diff.NewValueKnown("allocation_method") {
+		return nil
+	}
+
+	key, err := resourcePublicIpQuotaKey(diff, meta)
+	if err != nil {
+		return err
 	}
+	static := strings.EqualFold(diff.Get("allocation_method").(string), string(network.IPAllocationMethodStatic))
+
+	return quota.CheckPublicIPAddress(ctx, meta.(*clients.Client), key, diff.Get("location").(string), static)
+}
+
+// resourcePublicIpQuotaKey returns the key used to identify the Public IP Address within the Quota Pre-flight checks,
+// which is the Resource ID of the Public IP Address
+func resourcePublicIpQuotaKey(d quota.ResourceGetter, meta interface{}) (string, error) {
+	if id := d.Id(); id != "" {
+		return id, nil
+	}
+
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	return parse.NewPublicIpAddressID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string)).ID(), nil
 }
 
 func resourcePublicIpCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
@@ -4,10 +4,8 @@
 package migration
 
 import (
-	"context"
-	"log"
-
 	"github.com/hashicorp/go-azure-sdk/resource-manager/redis/2023-08-01/firewallrules"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -47,18 +45,6 @@ func (FirewallRuleV0ToV1) Schema() map[string]*pluginsdk.Schema {
 	}
 }
 
-func (FirewallRuleV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
-	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
-		oldIdRaw := rawState["id"].(string)
-		oldId, err := firewallrules.ParseFirewallRuleIDInsensitively(oldIdRaw)
-		if err != nil {
-			return rawState, err
-		}
-
-		newId := oldId.ID()
-		log.Printf("[DEBUG] Updating ID from %q to %q", oldId, newId)
-		rawState["id"] = newId
-
-		return rawState, nil
-	}
+func (s FirewallRuleV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
+	return sdk.NewIDStateUpgrade(s.Schema(), &firewallrules.FirewallRuleId{}).UpgradeFunc()
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -25,7 +26,7 @@ import (
 )
 
 func resourceResourceGroup() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
+	return sdk.WithSubscriptionOverride(&pluginsdk.Resource{
 		Create: resourceResourceGroupCreateUpdate,
 		Read:   resourceResourceGroupRead,
 		Update: resourceResourceGroupCreateUpdate,
@@ -55,7 +56,7 @@ func resourceResourceGroup() *pluginsdk.Resource {
 				ValidateFunc: validation.StringIsNotEmpty,
 			},
 		},
-	}
+	})
 }
 
 func resourceResourceGroupCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
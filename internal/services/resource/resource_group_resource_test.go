@@ -78,6 +78,31 @@ func TestAccResourceGroup_withTags(t *testing.T) {
 	})
 }
 
+func TestAccResourceGroup_tagsInAzure(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group", "test")
+	testResource := ResourceGroupResource{}
+	assert := check.That(data.ResourceName)
+	data.ResourceTest(t, testResource, []acceptance.TestStep{
+		{
+			Config: testResource.withTagsConfig(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				assert.InAzure().Key("tags").HasValue(map[string]string{
+					"cost_center": "MSFT",
+					"environment": "Production",
+				}),
+			),
+		},
+		{
+			Config: testResource.withTagsUpdatedConfig(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				assert.InAzure().Key("tags").HasValue(map[string]string{
+					"environment": "staging",
+				}),
+			),
+		},
+	})
+}
+
 func TestAccResourceGroup_withManagedBy(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_resource_group", "test")
 	testResource := ResourceGroupResource{}
@@ -94,6 +119,25 @@ func TestAccResourceGroup_withManagedBy(t *testing.T) {
 	})
 }
 
+func TestAccResourceGroup_subscriptionOverride(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group", "test")
+	testResource := ResourceGroupResource{}
+	if data.Subscriptions.Secondary == "" {
+		t.Skip("Skipping since `ARM_TEST_SUBSCRIPTION_ID_ALT` is not specified")
+	}
+
+	data.ResourceTest(t, testResource, []acceptance.TestStep{
+		{
+			Config: testResource.subscriptionOverrideConfig(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(testResource),
+				check.That(data.ResourceName).Key("subscription_id").HasValue(data.Subscriptions.Secondary),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccResourceGroup_withNestedItemsAndFeatureFlag(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_resource_group", "test")
 	r := ResourceGroupResource{}
@@ -284,3 +328,17 @@ resource "azurerm_resource_group" "test" {
 }
 `, data.RandomInteger, data.Locations.Primary)
 }
+
+func (t ResourceGroupResource) subscriptionOverrideConfig(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name            = "acctestRG-%d"
+  location        = "%s"
+  subscription_id = "%s"
+}
+`, data.RandomInteger, data.Locations.Primary, data.Subscriptions.Secondary)
+}
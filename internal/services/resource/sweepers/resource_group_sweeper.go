@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sweepers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2023-07-01/resourcegroups"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/sweep"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// Sweepers returns the Sweepers which remove the Resources leaked by the acceptance tests for the Resources Service
+func Sweepers() []sweep.Sweeper {
+	return []sweep.Sweeper{
+		resourceGroupSweeper(func(client *clients.Client) (resourceGroupsSweeperClient, string) {
+			return client.Resource.ResourceGroupsClient, client.Account.SubscriptionId
+		}),
+	}
+}
+
+// resourceGroupsSweeperClient is the subset of the Resource Groups Client used by the Sweeper
+type resourceGroupsSweeperClient interface {
+	ListComplete(ctx context.Context, id commonids.SubscriptionId, options resourcegroups.ListOperationOptions) (resourcegroups.ListCompleteResult, error)
+	DeleteThenPoll(ctx context.Context, id commonids.ResourceGroupId, options resourcegroups.DeleteOperationOptions) error
+}
+
+// resourceGroupSweeper removes the Resource Groups leaked by the acceptance tests, which contain the majority of
+// the Resources created by the acceptance tests
+func resourceGroupSweeper(clientFunc func(client *clients.Client) (resourceGroupsSweeperClient, string)) sweep.Sweeper {
+	return sweep.Sweeper{
+		Name: "azurerm_resource_group",
+		Prefixes: []string{
+			"acctestRG-",
+			"acctestrg-",
+			"acctest-rg-",
+		},
+		List: func(ctx context.Context, client *clients.Client) ([]sweep.Resource, error) {
+			rgClient, subscriptionId := clientFunc(client)
+			id := commonids.NewSubscriptionID(subscriptionId)
+			resp, err := rgClient.ListComplete(ctx, id, resourcegroups.DefaultListOperationOptions())
+			if err != nil {
+				return nil, fmt.Errorf("listing Resource Groups within %s: %+v", id, err)
+			}
+
+			resources := make([]sweep.Resource, 0)
+			for _, item := range resp.Items {
+				if item.Id == nil {
+					continue
+				}
+
+				// Resource Groups managed by another Resource (e.g. a Kubernetes Cluster) are removed with that Resource
+				if item.ManagedBy != nil && *item.ManagedBy != "" {
+					continue
+				}
+
+				resources = append(resources, sweep.Resource{
+					ID:   *item.Id,
+					Name: pointer.From(item.Name),
+				})
+			}
+
+			return resources, nil
+		},
+		Delete: func(ctx context.Context, client *clients.Client, resource sweep.Resource) error {
+			rgClient, _ := clientFunc(client)
+			id, err := commonids.ParseResourceGroupIDInsensitively(resource.ID)
+			if err != nil {
+				return err
+			}
+
+			options := resourcegroups.DeleteOperationOptions{
+				ForceDeletionTypes: pointer.To("Microsoft.Compute/virtualMachines,Microsoft.Compute/virtualMachineScaleSets"),
+			}
+			if err := rgClient.DeleteThenPoll(ctx, *id, options); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
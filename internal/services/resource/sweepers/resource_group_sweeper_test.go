@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sweepers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2023-07-01/resourcegroups"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/sweep"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+type fakeResourceGroupsSweeperClient struct {
+	items   []resourcegroups.ResourceGroup
+	deleted []string
+}
+
+func (f *fakeResourceGroupsSweeperClient) ListComplete(_ context.Context, _ commonids.SubscriptionId, _ resourcegroups.ListOperationOptions) (resourcegroups.ListCompleteResult, error) {
+	return resourcegroups.ListCompleteResult{
+		Items: f.items,
+	}, nil
+}
+
+func (f *fakeResourceGroupsSweeperClient) DeleteThenPoll(_ context.Context, id commonids.ResourceGroupId, _ resourcegroups.DeleteOperationOptions) error {
+	f.deleted = append(f.deleted, id.ResourceGroupName)
+	return nil
+}
+
+func TestResourceGroupSweeper(t *testing.T) {
+	fake := &fakeResourceGroupsSweeperClient{
+		items: []resourcegroups.ResourceGroup{
+			{
+				Id:   pointer.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG-1234"),
+				Name: pointer.To("acctestRG-1234"),
+			},
+			{
+				Id:   pointer.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/production"),
+				Name: pointer.To("production"),
+			},
+			{
+				Id:        pointer.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG-aks-nodes"),
+				Name:      pointer.To("acctestRG-aks-nodes"),
+				ManagedBy: pointer.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG-5678/providers/Microsoft.ContainerService/managedClusters/example"),
+			},
+			{
+				Id:   pointer.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/acctestrg-5678"),
+				Name: pointer.To("acctestrg-5678"),
+			},
+		},
+	}
+
+	registry := sweep.NewRegistry()
+	err := registry.Register(resourceGroupSweeper(func(_ *clients.Client) (resourceGroupsSweeperClient, string) {
+		return fake, "00000000-0000-0000-0000-000000000000"
+	}))
+	if err != nil {
+		t.Fatalf("registering sweeper: %+v", err)
+	}
+
+	report, err := registry.Run(context.TODO(), nil, sweep.Options{})
+	if err != nil {
+		t.Fatalf("running sweeper: %+v", err)
+	}
+	if report.HasErrors() {
+		t.Fatalf("unexpected errors:\n%s", report.String())
+	}
+
+	expected := []string{"acctestRG-1234", "acctestrg-5678"}
+	if !reflect.DeepEqual(fake.deleted, expected) {
+		t.Fatalf("expected %+v to be deleted but got %+v", expected, fake.deleted)
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/shim"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+)
+
+const defaultBlobContentType = "application/octet-stream"
+
+// BlobDirectorySync mirrors the files within a local directory into a Storage Container, below an (optional) prefix.
+//
+// Files are compared using their MD5 hash, such that only files which are new or have changed are uploaded (using
+// BlobUpload), and Blobs for files which have been removed from the directory are deleted.
+type BlobDirectorySync struct {
+	BlobsClient      *blobs.Client
+	ContainersClient shim.StorageContainerWrapper
+
+	AccountName     string
+	ContainerName   string
+	Prefix          string
+	SourceDirectory string
+
+	CacheControl string
+
+	// ContentTypes is a map of file extensions (e.g. `.html`) to the Content Type which should be used for these files,
+	// which takes precedence over the Content Type inferred from the file extension
+	ContentTypes map[string]string
+
+	// DeleteExtraneousBlobs specifies whether Blobs below the Prefix which don't exist in the directory should be
+	// deleted, rather than only those which were previously uploaded from the directory
+	DeleteExtraneousBlobs bool
+
+	EncryptionScope string
+	Parallelism     int
+}
+
+// LocalBlobDirectoryHashes returns a map of the relative path (using forward slashes) to the hex-encoded MD5 hash for
+// each file within the directory `directory`
+func LocalBlobDirectoryHashes(directory string) (map[string]string, error) {
+	output := make(map[string]string)
+	err := filepath.WalkDir(directory, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			log.Printf("[DEBUG] Skipping %q since it's not a regular file", filePath)
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(directory, filePath)
+		if err != nil {
+			return err
+		}
+
+		hash, err := fileMD5(filePath)
+		if err != nil {
+			return fmt.Errorf("hashing %q: %+v", filePath, err)
+		}
+		output[filepath.ToSlash(relativePath)] = hash
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading the directory %q: %+v", directory, err)
+	}
+
+	return output, nil
+}
+
+func fileMD5(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := md5.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// RemoteHashes returns a map of the path (relative to the Prefix) to the hex-encoded MD5 hash for each Blob below the
+// Prefix - Blobs without an MD5 hash are included with an empty hash, so that these are always uploaded
+func (s BlobDirectorySync) RemoteHashes(ctx context.Context) (map[string]string, error) {
+	blobList, err := s.ContainersClient.ListBlobs(ctx, s.ContainerName, s.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make(map[string]string)
+	for _, blob := range pointer.From(blobList) {
+		relativePath := strings.TrimPrefix(blob.Name, s.Prefix)
+		if relativePath == "" || blob.Deleted {
+			continue
+		}
+
+		hash := ""
+		if blob.Properties != nil && pointer.From(blob.Properties.ContentMD5) != "" {
+			// Azure uses a Base64 encoded representation of the standard MD5 sum of the file
+			hash, err = convertBase64ToHexEncoding(*blob.Properties.ContentMD5)
+			if err != nil {
+				return nil, fmt.Errorf("parsing the MD5 hash for the Blob %q: %+v", blob.Name, err)
+			}
+		}
+		output[relativePath] = hash
+	}
+
+	return output, nil
+}
+
+// Sync uploads the files within the directory which differ from the Blobs in the Container (or all files when `force`
+// is set) and then deletes any Blobs which are no longer needed - `managed` is the set of files which were previously
+// uploaded from the directory. The hashes of the files within the directory are returned.
+func (s BlobDirectorySync) Sync(ctx context.Context, managed map[string]string, force bool) (map[string]string, error) {
+	local, err := LocalBlobDirectoryHashes(s.SourceDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := s.RemoteHashes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving the existing Blobs: %+v", err)
+	}
+
+	uploads := make([]string, 0)
+	for file, hash := range local {
+		if existing, ok := remote[file]; force || !ok || existing != hash {
+			uploads = append(uploads, file)
+		}
+	}
+	sort.Strings(uploads)
+
+	deletions := make([]string, 0)
+	for file := range remote {
+		if _, ok := local[file]; ok {
+			continue
+		}
+		if _, ok := managed[file]; ok || s.DeleteExtraneousBlobs {
+			deletions = append(deletions, file)
+		}
+	}
+	sort.Strings(deletions)
+
+	log.Printf("[DEBUG] Syncing %q to Container %q (Account %q): uploading %d files and deleting %d blobs", s.SourceDirectory, s.ContainerName, s.AccountName, len(uploads), len(deletions))
+
+	err = s.forEachFile(ctx, uploads, func(ctx context.Context, file string) error {
+		hash, err := convertHexToBase64Encoding(local[file])
+		if err != nil {
+			return err
+		}
+
+		upload := BlobUpload{
+			Client:          s.BlobsClient,
+			AccountName:     s.AccountName,
+			ContainerName:   s.ContainerName,
+			BlobName:        s.Prefix + file,
+			BlobType:        "Block",
+			CacheControl:    s.CacheControl,
+			ContentMD5:      hash,
+			ContentType:     blobContentType(file, s.ContentTypes),
+			EncryptionScope: s.EncryptionScope,
+			Source:          filepath.Join(s.SourceDirectory, filepath.FromSlash(file)),
+		}
+		if err := upload.Create(ctx); err != nil {
+			return fmt.Errorf("uploading %q: %+v", file, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Delete(ctx, deletions); err != nil {
+		return nil, err
+	}
+
+	return local, nil
+}
+
+// Delete deletes the Blobs for the specified files (relative to the Prefix), Blobs which don't exist are ignored
+func (s BlobDirectorySync) Delete(ctx context.Context, files []string) error {
+	return s.forEachFile(ctx, files, func(ctx context.Context, file string) error {
+		resp, err := s.BlobsClient.Delete(ctx, s.ContainerName, s.Prefix+file, blobs.DeleteInput{
+			DeleteSnapshots: true,
+		})
+		if err != nil && !response.WasNotFound(resp.HttpResponse) {
+			return fmt.Errorf("deleting the Blob %q: %+v", s.Prefix+file, err)
+		}
+		return nil
+	})
+}
+
+// forEachFile runs `action` for each of the files in parallel, returning the first error
+func (s BlobDirectorySync) forEachFile(ctx context.Context, files []string, action func(ctx context.Context, file string) error) error {
+	workerCount := s.Parallelism
+	if workerCount < 1 {
+		workerCount = 1
+	}
+
+	queue := make(chan string, len(files))
+	for _, file := range files {
+		queue <- file
+	}
+	close(queue)
+
+	errors := make(chan error, len(files))
+	wg := &sync.WaitGroup{}
+	for i := 0; i < workerCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range queue {
+				if err := action(ctx, file); err != nil {
+					errors <- err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errors)
+
+	return <-errors
+}
+
+// blobContentType returns the Content Type for the file `file`, using the Content Type specified for the file
+// extension in `overrides` when present, otherwise inferring this from the file extension
+func blobContentType(file string, overrides map[string]string) string {
+	extension := strings.ToLower(path.Ext(file))
+	if contentType, ok := overrides[extension]; ok {
+		return contentType
+	}
+
+	if contentType := mime.TypeByExtension(extension); contentType != "" {
+		return contentType
+	}
+
+	return defaultBlobContentType
+}
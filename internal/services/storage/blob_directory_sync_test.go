@@ -0,0 +1,340 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/shim"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/containers"
+)
+
+type fakeBlob struct {
+	Content      []byte
+	ContentMD5   string
+	ContentType  string
+	CacheControl string
+}
+
+// fakeBlobEndpoint is a minimal in-memory (Azurite-style) Blob endpoint supporting the operations used by BlobUpload
+// and BlobDirectorySync
+type fakeBlobEndpoint struct {
+	lock  sync.Mutex
+	blobs map[string]fakeBlob
+
+	// uncommittedBlocks is a map of the Blob to the uncommitted Blocks (by Block ID) for that Blob
+	uncommittedBlocks map[string]map[string][]byte
+
+	// putBlockCount is the number of Blocks which have been uploaded
+	putBlockCount int
+
+	// rejectBlock, when set, causes the upload of any Block with this Block ID to fail
+	rejectBlock string
+}
+
+func (e *fakeBlobEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	// paths are in the format `/{container}/{blob}`
+	segments := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	switch {
+	case r.Method == http.MethodGet && len(segments) == 1 && r.URL.Query().Get("comp") == "list":
+		prefix := r.URL.Query().Get("prefix")
+		names := make([]string, 0)
+		for name := range e.blobs {
+			if strings.HasPrefix(name, segments[0]+"/"+prefix) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		result := "<EnumerationResults><Blobs>"
+		for _, name := range names {
+			result += fmt.Sprintf("<Blob><Name>%s</Name><Properties><Content-MD5>%s</Content-MD5></Properties></Blob>", strings.TrimPrefix(name, segments[0]+"/"), e.blobs[name].ContentMD5)
+		}
+		result += "</Blobs><NextMarker /></EnumerationResults>"
+
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(result))
+
+	case r.Method == http.MethodPut && len(segments) == 2 && r.URL.Query().Get("comp") == "block":
+		blockId := r.URL.Query().Get("blockid")
+		if blockId == e.rejectBlock {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		content, _ := io.ReadAll(r.Body)
+		if e.uncommittedBlocks == nil {
+			e.uncommittedBlocks = make(map[string]map[string][]byte)
+		}
+		if e.uncommittedBlocks[r.URL.Path[1:]] == nil {
+			e.uncommittedBlocks[r.URL.Path[1:]] = make(map[string][]byte)
+		}
+		e.uncommittedBlocks[r.URL.Path[1:]][blockId] = content
+		e.putBlockCount++
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodGet && len(segments) == 2 && r.URL.Query().Get("comp") == "blocklist":
+		if _, ok := e.uncommittedBlocks[r.URL.Path[1:]]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		result := "<BlockList><CommittedBlocks /><UncommittedBlocks>"
+		for blockId, content := range e.uncommittedBlocks[r.URL.Path[1:]] {
+			result += fmt.Sprintf("<Block><Name>%s</Name><Size>%d</Size></Block>", blockId, len(content))
+		}
+		result += "</UncommittedBlocks></BlockList>"
+
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(result))
+
+	case r.Method == http.MethodPut && len(segments) == 2 && r.URL.Query().Get("comp") == "blocklist":
+		var blockList blobs.BlockList
+		if err := xml.NewDecoder(r.Body).Decode(&blockList); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		content := make([]byte, 0)
+		for _, blockId := range blockList.LatestBlockIDs {
+			block, ok := e.uncommittedBlocks[r.URL.Path[1:]][blockId.Value]
+			if !ok {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			content = append(content, block...)
+		}
+		delete(e.uncommittedBlocks, r.URL.Path[1:])
+		e.blobs[r.URL.Path[1:]] = fakeBlob{
+			Content:      content,
+			ContentMD5:   r.Header.Get("x-ms-blob-content-md5"),
+			ContentType:  r.Header.Get("x-ms-blob-content-type"),
+			CacheControl: r.Header.Get("x-ms-blob-cache-control"),
+		}
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodPut && len(segments) == 2:
+		content, _ := io.ReadAll(r.Body)
+		e.blobs[r.URL.Path[1:]] = fakeBlob{
+			Content:      content,
+			ContentMD5:   r.Header.Get("x-ms-blob-content-md5"),
+			ContentType:  r.Header.Get("x-ms-blob-content-type"),
+			CacheControl: r.Header.Get("x-ms-blob-cache-control"),
+		}
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodDelete && len(segments) == 2:
+		if _, ok := e.blobs[r.URL.Path[1:]]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(e.blobs, r.URL.Path[1:])
+		w.WriteHeader(http.StatusAccepted)
+
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func (e *fakeBlobEndpoint) names() []string {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	output := make([]string, 0)
+	for name := range e.blobs {
+		output = append(output, name)
+	}
+	sort.Strings(output)
+	return output
+}
+
+func newTestBlobDirectorySync(t *testing.T, endpoint *fakeBlobEndpoint, directory string) BlobDirectorySync {
+	server := httptest.NewServer(endpoint)
+	t.Cleanup(server.Close)
+
+	blobsClient, err := blobs.NewWithBaseUri(server.URL)
+	if err != nil {
+		t.Fatalf("building Blobs Client: %+v", err)
+	}
+	containersClient, err := containers.NewWithBaseUri(server.URL)
+	if err != nil {
+		t.Fatalf("building Containers Client: %+v", err)
+	}
+
+	return BlobDirectorySync{
+		BlobsClient:      blobsClient,
+		ContainersClient: shim.NewDataPlaneStorageContainerWrapper(containersClient),
+		AccountName:      "example",
+		ContainerName:    "content",
+		Prefix:           "site/",
+		SourceDirectory:  directory,
+		Parallelism:      4,
+	}
+}
+
+func writeTestFiles(t *testing.T, directory string, files map[string]string) {
+	for name, contents := range files {
+		filePath := filepath.Join(directory, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+			t.Fatalf("creating the directory for %q: %+v", name, err)
+		}
+		if err := os.WriteFile(filePath, []byte(contents), 0o644); err != nil {
+			t.Fatalf("writing %q: %+v", name, err)
+		}
+	}
+}
+
+func TestBlobDirectorySync(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Minute)
+	defer cancel()
+
+	directory := t.TempDir()
+	writeTestFiles(t, directory, map[string]string{
+		"index.html":     "<html></html>",
+		"css/site.css":   "body {}",
+		"data/file.data": "hello",
+	})
+
+	endpoint := &fakeBlobEndpoint{
+		blobs: map[string]fakeBlob{
+			// a Blob which wasn't uploaded from the directory, which should only be removed when requested
+			"content/site/other.txt": {},
+		},
+	}
+	directorySync := newTestBlobDirectorySync(t, endpoint, directory)
+	directorySync.ContentTypes = map[string]string{
+		".data": "application/x-example",
+	}
+
+	managed, err := directorySync.Sync(ctx, nil, false)
+	if err != nil {
+		t.Fatalf("syncing: %+v", err)
+	}
+	expected := []string{"content/site/css/site.css", "content/site/data/file.data", "content/site/index.html", "content/site/other.txt"}
+	if actual := endpoint.names(); !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected the Blobs %v but got %v", expected, actual)
+	}
+	if len(managed) != 3 {
+		t.Fatalf("expected 3 managed files but got %d", len(managed))
+	}
+
+	expectedContentTypes := map[string]string{
+		"content/site/index.html":     "text/html; charset=utf-8",
+		"content/site/css/site.css":   "text/css; charset=utf-8",
+		"content/site/data/file.data": "application/x-example",
+	}
+	for name, contentType := range expectedContentTypes {
+		if actual := endpoint.blobs[name].ContentType; actual != contentType {
+			t.Fatalf("expected the Content Type for %q to be %q but got %q", name, contentType, actual)
+		}
+	}
+
+	remote, err := directorySync.RemoteHashes(ctx)
+	if err != nil {
+		t.Fatalf("retrieving the remote hashes: %+v", err)
+	}
+	for file, hash := range managed {
+		if remote[file] != hash {
+			t.Fatalf("expected the remote hash for %q to be %q but got %q", file, hash, remote[file])
+		}
+	}
+
+	// only the changed file should be uploaded, and the removed file should be deleted
+	writeTestFiles(t, directory, map[string]string{
+		"index.html": "<html><body></body></html>",
+	})
+	if err := os.Remove(filepath.Join(directory, "css", "site.css")); err != nil {
+		t.Fatalf("removing file: %+v", err)
+	}
+	endpoint.blobs["content/site/data/file.data"] = fakeBlob{ContentMD5: endpoint.blobs["content/site/data/file.data"].ContentMD5, ContentType: "unchanged"}
+
+	if _, err := directorySync.Sync(ctx, managed, false); err != nil {
+		t.Fatalf("syncing: %+v", err)
+	}
+	expected = []string{"content/site/data/file.data", "content/site/index.html", "content/site/other.txt"}
+	if actual := endpoint.names(); !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected the Blobs %v but got %v", expected, actual)
+	}
+	if actual := endpoint.blobs["content/site/data/file.data"].ContentType; actual != "unchanged" {
+		t.Fatalf("expected the unchanged file not to be uploaded but it was")
+	}
+
+	// forcing the sync should upload every file, and extraneous Blobs should be deleted when requested
+	directorySync.DeleteExtraneousBlobs = true
+	directorySync.CacheControl = "no-cache"
+	if _, err := directorySync.Sync(ctx, nil, true); err != nil {
+		t.Fatalf("syncing: %+v", err)
+	}
+	expected = []string{"content/site/data/file.data", "content/site/index.html"}
+	if actual := endpoint.names(); !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected the Blobs %v but got %v", expected, actual)
+	}
+	for _, name := range expected {
+		if actual := endpoint.blobs[name].CacheControl; actual != "no-cache" {
+			t.Fatalf("expected the Cache Control for %q to be %q but got %q", name, "no-cache", actual)
+		}
+	}
+
+	// deleting should ignore Blobs which no longer exist
+	if err := directorySync.Delete(ctx, []string{"data/file.data", "index.html", "css/site.css"}); err != nil {
+		t.Fatalf("deleting: %+v", err)
+	}
+	if actual := endpoint.names(); len(actual) != 0 {
+		t.Fatalf("expected no Blobs but got %v", actual)
+	}
+}
+
+func TestBlobContentType(t *testing.T) {
+	cases := []struct {
+		file      string
+		overrides map[string]string
+		expected  string
+	}{
+		{
+			file:     "index.html",
+			expected: "text/html; charset=utf-8",
+		},
+		{
+			file:     "images/LOGO.PNG",
+			expected: "image/png",
+		},
+		{
+			file:     "file.unknownextension",
+			expected: defaultBlobContentType,
+		},
+		{
+			file:     "LICENSE",
+			expected: defaultBlobContentType,
+		},
+		{
+			file: "index.html",
+			overrides: map[string]string{
+				".html": "text/html",
+			},
+			expected: "text/html",
+		},
+	}
+
+	for _, c := range cases {
+		if actual := blobContentType(c.file, c.overrides); actual != c.expected {
+			t.Fatalf("expected the Content Type for %q to be %q but got %q", c.file, c.expected, actual)
+		}
+	}
+}
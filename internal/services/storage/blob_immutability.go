@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+)
+
+// TODO: move the Blob-level Immutability Policy and Legal Hold operations into Giovanni
+
+const (
+	BlobImmutabilityPolicyModeLocked   = "Locked"
+	BlobImmutabilityPolicyModeUnlocked = "Unlocked"
+)
+
+type BlobImmutabilityPolicy struct {
+	ExpiryTime time.Time
+	Mode       string
+}
+
+// BlobImmutability returns the Immutability Policy (if any) and whether a Legal Hold is set from the headers returned
+// when retrieving the properties of a Blob
+func BlobImmutability(resp *http.Response) (*BlobImmutabilityPolicy, bool, error) {
+	if resp == nil {
+		return nil, false, nil
+	}
+
+	legalHold := false
+	if v := resp.Header.Get("x-ms-legal-hold"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, false, fmt.Errorf("parsing `x-ms-legal-hold` header value %q: %+v", v, err)
+		}
+		legalHold = b
+	}
+
+	v := resp.Header.Get("x-ms-immutability-policy-until-date")
+	if v == "" {
+		return nil, legalHold, nil
+	}
+	expiryTime, err := http.ParseTime(v)
+	if err != nil {
+		return nil, false, fmt.Errorf("parsing `x-ms-immutability-policy-until-date` header value %q: %+v", v, err)
+	}
+
+	mode := BlobImmutabilityPolicyModeUnlocked
+	if strings.EqualFold(resp.Header.Get("x-ms-immutability-policy-mode"), BlobImmutabilityPolicyModeLocked) {
+		mode = BlobImmutabilityPolicyModeLocked
+	}
+
+	return &BlobImmutabilityPolicy{
+		ExpiryTime: expiryTime.UTC(),
+		Mode:       mode,
+	}, legalHold, nil
+}
+
+// SetBlobImmutabilityPolicy sets (or extends) the Immutability Policy for the specified Blob
+func SetBlobImmutabilityPolicy(ctx context.Context, blobsClient *blobs.Client, containerName, blobName string, policy BlobImmutabilityPolicy) error {
+	return blobDataPlaneRequest(ctx, blobsClient, http.MethodPut, containerName, blobName, blobDataPlaneOptions{
+		comp: "immutabilityPolicies",
+		headers: map[string]string{
+			"x-ms-immutability-policy-until-date": policy.ExpiryTime.UTC().Format(http.TimeFormat),
+			"x-ms-immutability-policy-mode":       policy.Mode,
+		},
+	})
+}
+
+// DeleteBlobImmutabilityPolicy removes the (Unlocked) Immutability Policy for the specified Blob
+func DeleteBlobImmutabilityPolicy(ctx context.Context, blobsClient *blobs.Client, containerName, blobName string) error {
+	return blobDataPlaneRequest(ctx, blobsClient, http.MethodDelete, containerName, blobName, blobDataPlaneOptions{
+		comp: "immutabilityPolicies",
+	})
+}
+
+// SetBlobLegalHold sets or clears the Legal Hold for the specified Blob
+func SetBlobLegalHold(ctx context.Context, blobsClient *blobs.Client, containerName, blobName string, enabled bool) error {
+	return blobDataPlaneRequest(ctx, blobsClient, http.MethodPut, containerName, blobName, blobDataPlaneOptions{
+		comp: "legalhold",
+		headers: map[string]string{
+			"x-ms-legal-hold": strconv.FormatBool(enabled),
+		},
+	})
+}
+
+func blobDataPlaneRequest(ctx context.Context, blobsClient *blobs.Client, httpMethod, containerName, blobName string, options blobDataPlaneOptions) error {
+	opts := client.RequestOptions{
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod:    httpMethod,
+		OptionsObject: options,
+		Path:          fmt.Sprintf("/%s/%s", containerName, blobName),
+	}
+
+	req, err := blobsClient.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("building request: %+v", err)
+	}
+
+	if _, err := req.Execute(ctx); err != nil {
+		return fmt.Errorf("executing request: %+v", err)
+	}
+
+	return nil
+}
+
+type blobDataPlaneOptions struct {
+	comp    string
+	headers map[string]string
+}
+
+func (o blobDataPlaneOptions) ToHeaders() *client.Headers {
+	headers := &client.Headers{}
+	for k, v := range o.headers {
+		headers.Append(k, v)
+	}
+	return headers
+}
+
+func (o blobDataPlaneOptions) ToOData() *odata.Query {
+	return nil
+}
+
+func (o blobDataPlaneOptions) ToQuery() *client.QueryParams {
+	out := &client.QueryParams{}
+	out.Append("comp", o.comp)
+	return out
+}
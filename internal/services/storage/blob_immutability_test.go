@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBlobImmutability(t *testing.T) {
+	cases := []struct {
+		headers   map[string]string
+		policy    *BlobImmutabilityPolicy
+		legalHold bool
+		error     bool
+	}{
+		{
+			headers: map[string]string{},
+		},
+		{
+			headers: map[string]string{
+				"x-ms-legal-hold": "true",
+			},
+			legalHold: true,
+		},
+		{
+			headers: map[string]string{
+				"x-ms-legal-hold":                     "false",
+				"x-ms-immutability-policy-until-date": "Tue, 01 Oct 2024 10:00:00 GMT",
+				"x-ms-immutability-policy-mode":       "locked",
+			},
+			policy: &BlobImmutabilityPolicy{
+				ExpiryTime: time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC),
+				Mode:       BlobImmutabilityPolicyModeLocked,
+			},
+		},
+		{
+			headers: map[string]string{
+				"x-ms-immutability-policy-until-date": "Tue, 01 Oct 2024 10:00:00 GMT",
+				"x-ms-immutability-policy-mode":       "unlocked",
+			},
+			policy: &BlobImmutabilityPolicy{
+				ExpiryTime: time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC),
+				Mode:       BlobImmutabilityPolicyModeUnlocked,
+			},
+		},
+		{
+			headers: map[string]string{
+				"x-ms-immutability-policy-until-date": "tomorrow",
+			},
+			error: true,
+		},
+	}
+
+	for _, c := range cases {
+		resp := &http.Response{
+			Header: http.Header{},
+		}
+		for k, v := range c.headers {
+			resp.Header.Set(k, v)
+		}
+
+		policy, legalHold, err := BlobImmutability(resp)
+		if err != nil {
+			if !c.error {
+				t.Fatalf("unexpected error for %+v: %+v", c.headers, err)
+			}
+			continue
+		}
+		if c.error {
+			t.Fatalf("expected an error for %+v but didn't get one", c.headers)
+		}
+
+		if legalHold != c.legalHold {
+			t.Fatalf("expected Legal Hold to be %t for %+v but got %t", c.legalHold, c.headers, legalHold)
+		}
+		if (policy == nil) != (c.policy == nil) {
+			t.Fatalf("expected Immutability Policy %+v for %+v but got %+v", c.policy, c.headers, policy)
+		}
+		if policy != nil && (!policy.ExpiryTime.Equal(c.policy.ExpiryTime) || policy.Mode != c.policy.Mode) {
+			t.Fatalf("expected Immutability Policy %+v for %+v but got %+v", c.policy, c.headers, policy)
+		}
+	}
+}
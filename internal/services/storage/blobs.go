@@ -6,16 +6,22 @@ package storage
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"io"
+	"log"
+	"net/http"
 	"os"
 	"runtime"
 	"strings"
 	"sync"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
 	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
 )
 
@@ -26,7 +32,12 @@ type BlobUpload struct {
 	BlobName      string
 	ContainerName string
 
-	BlobType        string
+	BlobType string
+
+	// BlockSize is the size in bytes of each Block (for Block blobs) or the maximum size of each Page (for Page blobs)
+	// which is uploaded, when unset a default size is used
+	BlockSize int64
+
 	CacheControl    string
 	ContentType     string
 	ContentMD5      string
@@ -73,6 +84,9 @@ func (sbu BlobUpload) Create(ctx context.Context) error {
 		if sbu.ContentMD5 != "" {
 			return fmt.Errorf("`content_md5` cannot be specified for a Page blob")
 		}
+		if sbu.BlockSize > maxPageSize || sbu.BlockSize%minPageSize != 0 {
+			return fmt.Errorf("the block size for a Page blob must be a multiple of %d bytes and cannot exceed %d bytes", minPageSize, maxPageSize)
+		}
 		if sbu.SourceUri != "" {
 			return sbu.copy(ctx)
 		}
@@ -161,10 +175,26 @@ func (sbu BlobUpload) uploadBlockBlob(ctx context.Context) error {
 	}
 	defer file.Close()
 
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("Could not stat file %q: %s", file.Name(), err)
+	}
+
+	// files larger than a single block are uploaded in blocks, which can be uploaded in parallel and resumed
+	if blockSize := sbu.blockSize(info.Size()); info.Size() > blockSize {
+		if err := sbu.blockUploadFromSource(ctx, file, info.Size(), blockSize); err != nil {
+			return fmt.Errorf("uploading blocks: %s", err)
+		}
+		return nil
+	}
+
 	input := blobs.PutBlockBlobInput{
 		ContentType: pointer.To(sbu.ContentType),
 		MetaData:    sbu.MetaData,
 	}
+	if sbu.CacheControl != "" {
+		input.CacheControl = pointer.To(sbu.CacheControl)
+	}
 	if sbu.ContentMD5 != "" {
 		input.ContentMD5 = pointer.To(sbu.ContentMD5)
 	}
@@ -262,8 +292,10 @@ type storageBlobPage struct {
 	section *io.SectionReader
 }
 
+// pageUploadFromSource uploads the non-empty Pages of the file in parallel - unlike Block blobs the Pages which have
+// already been written can't be identified, as such a failed upload is restarted from the beginning.
 func (sbu BlobUpload) pageUploadFromSource(ctx context.Context, file io.ReaderAt, fileSize int64) error {
-	workerCount := sbu.Parallelism * runtime.NumCPU()
+	workerCount := sbu.workerCount()
 
 	// first we chunk the file and assign them to 'pages'
 	pageList, err := sbu.storageBlobPageSplit(file, fileSize)
@@ -309,6 +341,14 @@ const (
 	maxPageSize int64 = 4 * 1024 * 1024
 )
 
+// pageSize returns the maximum size of each Page which is uploaded
+func (sbu BlobUpload) pageSize() int64 {
+	if sbu.BlockSize > 0 {
+		return sbu.BlockSize
+	}
+	return maxPageSize
+}
+
 func (sbu BlobUpload) storageBlobPageSplit(file io.ReaderAt, fileSize int64) ([]storageBlobPage, error) {
 	// whilst the file Size can be any arbitrary Size, it must be uploaded in fixed-Size pages
 	blobSize := fileSize
@@ -340,7 +380,7 @@ func (sbu BlobUpload) storageBlobPageSplit(file io.ReaderAt, fileSize int64) ([]
 			}
 		} else {
 			currentRange.length += minPageSize
-			if currentRange.length == maxPageSize || (currentRange.offset+currentRange.length == blobSize) {
+			if currentRange.length == sbu.pageSize() || (currentRange.offset+currentRange.length == blobSize) {
 				nonEmptyRanges = append(nonEmptyRanges, currentRange)
 				currentRange = byteRange{
 					offset: i + minPageSize,
@@ -376,27 +416,313 @@ func (sbu BlobUpload) blobPageUploadWorker(ctx context.Context, uploadCtx blobPa
 		}
 		size := end - start + 1
 
-		chunk := make([]byte, size)
-		if _, err := page.section.Read(chunk); err != nil && err != io.EOF {
-			uploadCtx.errors <- fmt.Errorf("reading source file %q at offset %d: %s", sbu.Source, page.offset, err)
-			uploadCtx.wg.Done()
-			continue
+		uploadBytesInFlight.acquire(size)
+		if err := sbu.uploadPage(ctx, page, start, end); err != nil {
+			uploadCtx.errors <- err
 		}
+		uploadBytesInFlight.release(size)
+
+		uploadCtx.wg.Done()
+	}
+}
+
+func (sbu BlobUpload) uploadPage(ctx context.Context, page storageBlobPage, start, end int64) error {
+	chunk := make([]byte, end-start+1)
+	if _, err := page.section.Read(chunk); err != nil && err != io.EOF {
+		return fmt.Errorf("reading source file %q at offset %d: %s", sbu.Source, page.offset, err)
+	}
+
+	input := blobs.PutPageUpdateInput{
+		StartByte: start,
+		EndByte:   end,
+		Content:   chunk,
+	}
+
+	if _, err := sbu.Client.PutPageUpdate(ctx, sbu.ContainerName, sbu.BlobName, input); err != nil {
+		return fmt.Errorf("writing page at offset %d for file %q: %s", page.offset, sbu.Source, err)
+	}
+
+	return nil
+}
+
+// workerCount returns the number of workers used to upload Blocks or Pages concurrently
+func (sbu BlobUpload) workerCount() int {
+	return max(sbu.Parallelism, 1) * runtime.NumCPU()
+}
+
+// maxUploadBytesInFlight is the maximum number of bytes held in memory by the workers uploading Blocks or Pages at
+// any one time, across all of the uploads within the Provider - since each worker reads an entire Block/Page into
+// memory the number of workers alone doesn't bound the memory used for large Block sizes.
+const maxUploadBytesInFlight int64 = 256 * 1024 * 1024
+
+var uploadBytesInFlight = newByteSemaphore(maxUploadBytesInFlight)
+
+// byteSemaphore limits the number of bytes which can be acquired at any one time
+type byteSemaphore struct {
+	cond      *sync.Cond
+	size      int64
+	available int64
+}
 
-		input := blobs.PutPageUpdateInput{
-			StartByte: start,
-			EndByte:   end,
-			Content:   chunk,
+func newByteSemaphore(size int64) *byteSemaphore {
+	return &byteSemaphore{
+		cond:      sync.NewCond(&sync.Mutex{}),
+		size:      size,
+		available: size,
+	}
+}
+
+// acquire blocks until `n` bytes are available - requests larger than the size of the semaphore acquire all of
+// the available bytes, such that these are processed one at a time
+func (s *byteSemaphore) acquire(n int64) {
+	n = min(n, s.size)
+
+	s.cond.L.Lock()
+	defer s.cond.L.Unlock()
+	for s.available < n {
+		s.cond.Wait()
+	}
+	s.available -= n
+}
+
+// release returns `n` bytes to the semaphore, which must match the amount previously acquired
+func (s *byteSemaphore) release(n int64) {
+	n = min(n, s.size)
+
+	s.cond.L.Lock()
+	defer s.cond.L.Unlock()
+	s.available += n
+	s.cond.Broadcast()
+}
+
+const (
+	defaultBlockSize int64 = 4 * 1024 * 1024
+	maxBlockSize     int64 = 4000 * 1024 * 1024
+
+	// a Block Blob can contain at most 50,000 committed blocks
+	maxBlockCount int64 = 50000
+)
+
+// blockSize returns the size of each Block to upload for a file of the size `fileSize`, which is increased (in
+// increments of 1MB) when needed to stay within the maximum number of blocks
+func (sbu BlobUpload) blockSize(fileSize int64) int64 {
+	blockSize := defaultBlockSize
+	if sbu.BlockSize > 0 {
+		blockSize = sbu.BlockSize
+	}
+
+	if fileSize > blockSize*maxBlockCount {
+		const increment = 1024 * 1024
+		blockSize = ((fileSize/maxBlockCount)/increment + 1) * increment
+	}
+
+	return min(blockSize, maxBlockSize)
+}
+
+type storageBlobBlock struct {
+	index   int
+	offset  int64
+	section *io.SectionReader
+}
+
+// blockUploadFromSource uploads the file as a series of Blocks in parallel, which are then committed in order.
+//
+// Each Block ID is derived from the position and MD5 hash of the Block, meaning that Blocks which have already been
+// uploaded (but not committed, for example as the previous upload failed part-way) can be identified from the list
+// of uncommitted Blocks and are skipped, allowing the upload to be resumed.
+func (sbu BlobUpload) blockUploadFromSource(ctx context.Context, file io.ReaderAt, fileSize int64, blockSize int64) error {
+	uncommittedBlocks, err := sbu.uncommittedBlocks(ctx)
+	if err != nil {
+		return err
+	}
+
+	blockCount := int((fileSize + blockSize - 1) / blockSize)
+	blockIds := make([]string, blockCount)
+
+	blocks := make(chan storageBlobBlock, blockCount)
+	for i := 0; i < blockCount; i++ {
+		offset := int64(i) * blockSize
+		blocks <- storageBlobBlock{
+			index:   i,
+			offset:  offset,
+			section: io.NewSectionReader(file, offset, min(blockSize, fileSize-offset)),
 		}
+	}
+	close(blocks)
+
+	errors := make(chan error, blockCount)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < min(sbu.workerCount(), blockCount); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for block := range blocks {
+				uploadBytesInFlight.acquire(block.section.Size())
+				blockId, err := sbu.uploadBlock(ctx, block, uncommittedBlocks)
+				uploadBytesInFlight.release(block.section.Size())
+				if err != nil {
+					errors <- err
+					continue
+				}
+				blockIds[block.index] = blockId
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errors) > 0 {
+		return fmt.Errorf("while uploading source file %q: %s", sbu.Source, <-errors)
+	}
+
+	blockList := blobs.BlockList{
+		LatestBlockIDs: make([]blobs.BlockID, 0, blockCount),
+	}
+	for _, blockId := range blockIds {
+		blockList.LatestBlockIDs = append(blockList.LatestBlockIDs, blobs.BlockID{
+			Value: blockId,
+		})
+	}
+
+	input := blobs.PutBlockListInput{
+		BlockList:   blockList,
+		ContentType: pointer.To(sbu.ContentType),
+		MetaData:    sbu.MetaData,
+	}
+	if sbu.CacheControl != "" {
+		input.CacheControl = pointer.To(sbu.CacheControl)
+	}
+	if sbu.ContentMD5 != "" {
+		input.ContentMD5 = pointer.To(sbu.ContentMD5)
+	}
+	if sbu.EncryptionScope != "" {
+		input.EncryptionScope = pointer.To(sbu.EncryptionScope)
+	}
+	if err := sbu.putBlockList(ctx, input); err != nil {
+		return fmt.Errorf("committing %d blocks for file %q: %s", blockCount, sbu.Source, err)
+	}
+
+	return nil
+}
+
+// putBlockList commits the Blocks within `input`.
+//
+// TODO: switch to `blobs.Client.PutBlockList` once Giovanni specifies the Content Type for this request, which is
+// required for the Block List to be serialized
+func (sbu BlobUpload) putBlockList(ctx context.Context, input blobs.PutBlockListInput) error {
+	opts := client.RequestOptions{
+		ContentType: "application/xml; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusCreated,
+		},
+		HttpMethod: http.MethodPut,
+		OptionsObject: putBlockListOptions{
+			input: input,
+		},
+		Path: fmt.Sprintf("/%s/%s", sbu.ContainerName, sbu.BlobName),
+	}
+
+	req, err := sbu.Client.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("building request: %+v", err)
+	}
+
+	if err := req.Marshal(&input.BlockList); err != nil {
+		return fmt.Errorf("marshalling request: %+v", err)
+	}
+
+	if _, err := req.Execute(ctx); err != nil {
+		return fmt.Errorf("executing request: %+v", err)
+	}
+
+	return nil
+}
 
-		if _, err := sbu.Client.PutPageUpdate(ctx, sbu.ContainerName, sbu.BlobName, input); err != nil {
-			uploadCtx.errors <- fmt.Errorf("writing page at offset %d for file %q: %s", page.offset, sbu.Source, err)
-			uploadCtx.wg.Done()
-			continue
+type putBlockListOptions struct {
+	input blobs.PutBlockListInput
+}
+
+func (p putBlockListOptions) ToHeaders() *client.Headers {
+	headers := &client.Headers{}
+
+	if p.input.CacheControl != nil {
+		headers.Append("x-ms-blob-cache-control", *p.input.CacheControl)
+	}
+	if p.input.ContentMD5 != nil {
+		headers.Append("x-ms-blob-content-md5", *p.input.ContentMD5)
+	}
+	if p.input.ContentType != nil {
+		headers.Append("x-ms-blob-content-type", *p.input.ContentType)
+	}
+	if p.input.EncryptionScope != nil {
+		headers.Append("x-ms-encryption-scope", *p.input.EncryptionScope)
+	}
+	for k, v := range p.input.MetaData {
+		headers.Append(fmt.Sprintf("x-ms-meta-%s", k), v)
+	}
+
+	return headers
+}
+
+func (p putBlockListOptions) ToOData() *odata.Query {
+	return nil
+}
+
+func (p putBlockListOptions) ToQuery() *client.QueryParams {
+	out := &client.QueryParams{}
+	out.Append("comp", "blocklist")
+	return out
+}
+
+// uncommittedBlocks returns a map of the ID to the size of each Block which has been uploaded but not yet committed
+func (sbu BlobUpload) uncommittedBlocks(ctx context.Context) (map[string]int64, error) {
+	output := make(map[string]int64)
+
+	resp, err := sbu.Client.GetBlockList(ctx, sbu.ContainerName, sbu.BlobName, blobs.GetBlockListInput{
+		BlockListType: blobs.Uncommitted,
+	})
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return output, nil
 		}
+		return nil, fmt.Errorf("retrieving the uncommitted blocks: %s", err)
+	}
 
-		uploadCtx.wg.Done()
+	for _, block := range resp.UncommittedBlocks.Blocks {
+		output[block.Name] = block.Size
 	}
+
+	return output, nil
+}
+
+// uploadBlock uploads the Block `block` (unless it's already been uploaded) and returns the Block ID
+func (sbu BlobUpload) uploadBlock(ctx context.Context, block storageBlobBlock, uncommittedBlocks map[string]int64) (string, error) {
+	chunk := make([]byte, block.section.Size())
+	if _, err := block.section.ReadAt(chunk, 0); err != nil && err != io.EOF {
+		return "", fmt.Errorf("reading source file %q at offset %d: %s", sbu.Source, block.offset, err)
+	}
+
+	// Block IDs must be Base64 encoded and the same length for every Block within the Blob
+	hash := md5.Sum(chunk)
+	blockId := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%06d-%x", block.index, hash)))
+
+	if size, ok := uncommittedBlocks[blockId]; ok && size == int64(len(chunk)) {
+		log.Printf("[DEBUG] Skipping block %d for file %q since it's already been uploaded", block.index, sbu.Source)
+		return blockId, nil
+	}
+
+	input := blobs.PutBlockInput{
+		BlockID:    blockId,
+		Content:    chunk,
+		ContentMD5: pointer.To(base64.StdEncoding.EncodeToString(hash[:])),
+	}
+	if sbu.EncryptionScope != "" {
+		input.EncryptionScope = pointer.To(sbu.EncryptionScope)
+	}
+	if _, err := sbu.Client.PutBlock(ctx, sbu.ContainerName, sbu.BlobName, input); err != nil {
+		return "", fmt.Errorf("writing block %d for file %q: %s", block.index, sbu.Source, err)
+	}
+
+	return blockId, nil
 }
 
 func convertHexToBase64Encoding(str string) (string, error) {
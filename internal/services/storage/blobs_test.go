@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+)
+
+func TestBlobUploadBlockBlobResume(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Minute)
+	defer cancel()
+
+	content := make([]byte, 4000)
+	if _, err := rand.Read(content); err != nil {
+		t.Fatalf("generating content: %+v", err)
+	}
+	source := filepath.Join(t.TempDir(), "example.bin")
+	if err := os.WriteFile(source, content, 0o644); err != nil {
+		t.Fatalf("writing %q: %+v", source, err)
+	}
+
+	endpoint := &fakeBlobEndpoint{
+		blobs: map[string]fakeBlob{},
+	}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	client, err := blobs.NewWithBaseUri(server.URL)
+	if err != nil {
+		t.Fatalf("building Blobs Client: %+v", err)
+	}
+
+	upload := BlobUpload{
+		Client:        client,
+		AccountName:   "example",
+		ContainerName: "content",
+		BlobName:      "example.bin",
+		BlobType:      "Block",
+		BlockSize:     1024,
+		ContentType:   "application/octet-stream",
+		Parallelism:   2,
+		Source:        source,
+	}
+
+	// the third of the four blocks fails to upload, so the Blob isn't committed
+	hash := md5.Sum(content[2048:3072])
+	endpoint.rejectBlock = base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%06d-%x", 2, hash)))
+	if err := upload.Create(ctx); err == nil {
+		t.Fatalf("expected an error when a block fails to upload but didn't get one")
+	}
+	if _, ok := endpoint.blobs["content/example.bin"]; ok {
+		t.Fatalf("expected the Blob not to be committed when a block fails to upload")
+	}
+	if endpoint.putBlockCount != 3 {
+		t.Fatalf("expected 3 blocks to be uploaded but got %d", endpoint.putBlockCount)
+	}
+
+	// retrying the upload should only upload the remaining block
+	endpoint.rejectBlock = ""
+	endpoint.putBlockCount = 0
+	if err := upload.Create(ctx); err != nil {
+		t.Fatalf("uploading: %+v", err)
+	}
+	if endpoint.putBlockCount != 1 {
+		t.Fatalf("expected 1 block to be uploaded when resuming but got %d", endpoint.putBlockCount)
+	}
+	if !bytes.Equal(endpoint.blobs["content/example.bin"].Content, content) {
+		t.Fatalf("expected the committed Blob to match the source file")
+	}
+}
+
+func TestBlobUploadBlockSize(t *testing.T) {
+	const mb = 1024 * 1024
+	cases := []struct {
+		blockSize int64
+		fileSize  int64
+		expected  int64
+	}{
+		{
+			fileSize: 10 * mb,
+			expected: defaultBlockSize,
+		},
+		{
+			blockSize: 16 * mb,
+			fileSize:  10 * mb,
+			expected:  16 * mb,
+		},
+		{
+			// 50,000 blocks of 4MB isn't large enough, so the block size is increased
+			fileSize: 400 * 1024 * mb,
+			expected: 9 * mb,
+		},
+		{
+			blockSize: 100 * mb,
+			fileSize:  5000 * 1024 * mb,
+			expected:  103 * mb,
+		},
+	}
+
+	for _, c := range cases {
+		upload := BlobUpload{
+			BlockSize: c.blockSize,
+		}
+		if actual := upload.blockSize(c.fileSize); actual != c.expected {
+			t.Fatalf("expected the block size for a file of %d bytes to be %d but got %d", c.fileSize, c.expected, actual)
+		}
+	}
+}
+
+func TestByteSemaphore(t *testing.T) {
+	semaphore := newByteSemaphore(10)
+
+	inFlight := int64(0)
+	maxInFlight := int64(0)
+	lock := &sync.Mutex{}
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(n int64) {
+			defer wg.Done()
+			semaphore.acquire(n)
+			lock.Lock()
+			inFlight += min(n, 10)
+			maxInFlight = max(maxInFlight, inFlight)
+			lock.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			lock.Lock()
+			inFlight -= min(n, 10)
+			lock.Unlock()
+			semaphore.release(n)
+		}(int64(i%4*4 + 1))
+	}
+	wg.Wait()
+
+	if maxInFlight > 10 {
+		t.Fatalf("expected at most 10 bytes to be in flight but got %d", maxInFlight)
+	}
+	if semaphore.available != 10 {
+		t.Fatalf("expected all of the bytes to be released but %d are available", semaphore.available)
+	}
+}
@@ -9,11 +9,14 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-09-01/storage" // nolint: staticcheck
 	storage_v2023_01_01 "github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/storagesync/2020-03-01/cloudendpointresource"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storagesync/2020-03-01/registeredserverresource"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storagesync/2020-03-01/serverendpointresource"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/storagesync/2020-03-01/storagesyncservicesresource"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/storagesync/2020-03-01/syncgroupresource"
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/client/resourcemanager"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/sdkhacks"
 )
 
 // StorageDomainSuffix is used by validation functions
@@ -23,10 +26,13 @@ type Client struct {
 	StorageDomainSuffix string
 
 	// NOTE: These SDK clients use `hashicorp/go-azure-sdk` and should be used going forwards
-	ResourceManager          *storage_v2023_01_01.Client
-	SyncCloudEndpointsClient *cloudendpointresource.CloudEndpointResourceClient
-	SyncGroupsClient         *syncgroupresource.SyncGroupResourceClient
-	SyncServiceClient        *storagesyncservicesresource.StorageSyncServicesResourceClient
+	ResourceManager             *storage_v2023_01_01.Client
+	AccountMigrationsClient     *sdkhacks.StorageAccountMigrationClient
+	SyncCloudEndpointsClient    *cloudendpointresource.CloudEndpointResourceClient
+	SyncGroupsClient            *syncgroupresource.SyncGroupResourceClient
+	SyncRegisteredServersClient *registeredserverresource.RegisteredServerResourceClient
+	SyncServerEndpointsClient   *serverendpointresource.ServerEndpointResourceClient
+	SyncServiceClient           *storagesyncservicesresource.StorageSyncServicesResourceClient
 
 	// NOTE: these SDK clients use the legacy `Azure/azure-sdk-for-go` and should no longer be used
 	// for new functionality - please instead use the `hashicorp/go-azure-sdk` clients above.
@@ -34,7 +40,8 @@ type Client struct {
 	BlobServicesClient *storage.BlobServicesClient
 	FileServicesClient *storage.FileServicesClient
 
-	authConfigForAzureAD *auth.Credentials
+	authConfigForAzureAD     *auth.Credentials
+	authorizerFuncForAzureAD common.ApiAuthorizerFunc
 }
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
@@ -62,12 +69,30 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 		return nil, fmt.Errorf("building ResourceManager clients: %+v", err)
 	}
 
+	accountMigrationsClient, err := sdkhacks.NewStorageAccountMigrationClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("building StorageAccountMigration client: %+v", err)
+	}
+	o.Configure(accountMigrationsClient.Client, o.Authorizers.ResourceManager)
+
 	syncCloudEndpointsClient, err := cloudendpointresource.NewCloudEndpointResourceClientWithBaseURI(o.Environment.ResourceManager)
 	if err != nil {
 		return nil, fmt.Errorf("building CloudEndpoint client: %+v", err)
 	}
 	o.Configure(syncCloudEndpointsClient.Client, o.Authorizers.ResourceManager)
 
+	syncRegisteredServersClient, err := registeredserverresource.NewRegisteredServerResourceClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("building RegisteredServer client: %+v", err)
+	}
+	o.Configure(syncRegisteredServersClient.Client, o.Authorizers.ResourceManager)
+
+	syncServerEndpointsClient, err := serverendpointresource.NewServerEndpointResourceClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("building ServerEndpoint client: %+v", err)
+	}
+	o.Configure(syncServerEndpointsClient.Client, o.Authorizers.ResourceManager)
+
 	syncServiceClient, err := storagesyncservicesresource.NewStorageSyncServicesResourceClientWithBaseURI(o.Environment.ResourceManager)
 	if err != nil {
 		return nil, fmt.Errorf("building StorageSyncService client: %+v", err)
@@ -83,19 +108,23 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	// TODO: switch Storage Containers to using the storage.BlobContainersClient
 	// (which should fix #2977) when the storage clients have been moved in here
 	client := Client{
-		AccountsClient:           &accountsClient,
-		BlobServicesClient:       &blobServicesClient,
-		FileServicesClient:       &fileServicesClient,
-		ResourceManager:          resourceManager,
-		SyncCloudEndpointsClient: syncCloudEndpointsClient,
-		SyncServiceClient:        syncServiceClient,
-		SyncGroupsClient:         syncGroupsClient,
+		AccountMigrationsClient:     accountMigrationsClient,
+		AccountsClient:              &accountsClient,
+		BlobServicesClient:          &blobServicesClient,
+		FileServicesClient:          &fileServicesClient,
+		ResourceManager:             resourceManager,
+		SyncCloudEndpointsClient:    syncCloudEndpointsClient,
+		SyncRegisteredServersClient: syncRegisteredServersClient,
+		SyncServerEndpointsClient:   syncServerEndpointsClient,
+		SyncServiceClient:           syncServiceClient,
+		SyncGroupsClient:            syncGroupsClient,
 
 		StorageDomainSuffix: *storageSuffix,
 	}
 
 	if o.StorageUseAzureAD {
 		client.authConfigForAzureAD = o.AuthConfig
+		client.authorizerFuncForAzureAD = o.Authorizers.AuthorizerFunc
 	}
 
 	return &client, nil
@@ -37,6 +37,10 @@ type accountDetails struct {
 	IsHnsEnabled     bool
 	StorageAccountId commonids.StorageAccountId
 
+	// SharedKeyAccessEnabled specifies whether the Storage Account permits requests authorized using the Account Key,
+	// which is required for the Data Plane operations which only support Shared Key authentication
+	SharedKeyAccessEnabled bool
+
 	accountKey *string
 
 	// primaryBlobEndpoint is the Primary Blob Endpoint for the Data Plane API for this Storage Account
@@ -200,6 +204,9 @@ func populateAccountDetails(accountId commonids.StorageAccountId, account storag
 	props := *account.Properties
 	out.IsHnsEnabled = pointer.From(props.IsHnsEnabled)
 
+	// Shared Key access is enabled unless this has been explicitly disabled
+	out.SharedKeyAccessEnabled = props.AllowSharedKeyAccess == nil || *props.AllowSharedKeyAccess
+
 	endpoints := *props.PrimaryEndpoints
 	if endpoints.Blob != nil {
 		endpoint := strings.TrimSuffix(*endpoints.Blob, "/")
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+// NOTE: this file is generated via 'go:generate' - manual changes will be overwritten
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+type StorageAccountFailoverId struct {
+	SubscriptionId     string
+	ResourceGroup      string
+	StorageAccountName string
+	FailoverName       string
+}
+
+func NewStorageAccountFailoverID(subscriptionId, resourceGroup, storageAccountName, failoverName string) StorageAccountFailoverId {
+	return StorageAccountFailoverId{
+		SubscriptionId:     subscriptionId,
+		ResourceGroup:      resourceGroup,
+		StorageAccountName: storageAccountName,
+		FailoverName:       failoverName,
+	}
+}
+
+func (id StorageAccountFailoverId) String() string {
+	segments := []string{
+		fmt.Sprintf("Failover Name %q", id.FailoverName),
+		fmt.Sprintf("Storage Account Name %q", id.StorageAccountName),
+		fmt.Sprintf("Resource Group %q", id.ResourceGroup),
+	}
+	segmentsStr := strings.Join(segments, " / ")
+	return fmt.Sprintf("%s: (%s)", "Storage Account Failover", segmentsStr)
+}
+
+func (id StorageAccountFailoverId) ID() string {
+	fmtString := "/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Storage/storageAccounts/%s/failover/%s"
+	return fmt.Sprintf(fmtString, id.SubscriptionId, id.ResourceGroup, id.StorageAccountName, id.FailoverName)
+}
+
+// StorageAccountFailoverID parses a StorageAccountFailover ID into an StorageAccountFailoverId struct
+func StorageAccountFailoverID(input string) (*StorageAccountFailoverId, error) {
+	id, err := resourceids.ParseAzureResourceID(input)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q as an StorageAccountFailover ID: %+v", input, err)
+	}
+
+	resourceId := StorageAccountFailoverId{
+		SubscriptionId: id.SubscriptionID,
+		ResourceGroup:  id.ResourceGroup,
+	}
+
+	if resourceId.SubscriptionId == "" {
+		return nil, fmt.Errorf("ID was missing the 'subscriptions' element")
+	}
+
+	if resourceId.ResourceGroup == "" {
+		return nil, fmt.Errorf("ID was missing the 'resourceGroups' element")
+	}
+
+	if resourceId.StorageAccountName, err = id.PopSegment("storageAccounts"); err != nil {
+		return nil, err
+	}
+	if resourceId.FailoverName, err = id.PopSegment("failover"); err != nil {
+		return nil, err
+	}
+
+	if err := id.ValidateNoEmptySegments(input); err != nil {
+		return nil, err
+	}
+
+	return &resourceId, nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/containers"
+)
+
+// This is a special case ID for a meta resource that mirrors a local directory into a Storage Container below a
+// Prefix - the Prefix is joined to the Container ID using `|`, so that this doesn't collide with the ID of a Blob
+
+var _ resourceids.Id = StorageBlobDirectorySyncId{}
+
+type StorageBlobDirectorySyncId struct {
+	containers.ContainerId
+	Prefix string
+}
+
+func NewStorageBlobDirectorySyncID(containerId containers.ContainerId, prefix string) StorageBlobDirectorySyncId {
+	return StorageBlobDirectorySyncId{
+		ContainerId: containerId,
+		Prefix:      prefix,
+	}
+}
+
+func (id StorageBlobDirectorySyncId) ID() string {
+	return fmt.Sprintf("%s|%s", id.ContainerId.ID(), id.Prefix)
+}
+
+func (id StorageBlobDirectorySyncId) String() string {
+	components := []string{
+		fmt.Sprintf("Container %q", id.ContainerId.String()),
+		fmt.Sprintf("Prefix %q", id.Prefix),
+	}
+	return fmt.Sprintf("Storage Blob Directory Sync %s", strings.Join(components, " / "))
+}
+
+// StorageBlobDirectorySyncID parses `input` into a StorageBlobDirectorySyncId using a known `domainSuffix`
+func StorageBlobDirectorySyncID(input, domainSuffix string) (*StorageBlobDirectorySyncId, error) {
+	idParts := strings.Split(input, "|")
+	if len(idParts) != 2 {
+		return nil, fmt.Errorf("could not parse Storage Blob Directory Sync ID, expected a Container ID and a Prefix joined by `|`")
+	}
+
+	containerId, err := containers.ParseContainerID(idParts[0], domainSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse Container portion of Storage Blob Directory Sync ID: %+v", err)
+	}
+	if containerId.ContainerName == "" {
+		return nil, fmt.Errorf("could not parse Container portion of Storage Blob Directory Sync ID: the Container Name was empty")
+	}
+
+	return &StorageBlobDirectorySyncId{
+		ContainerId: *containerId,
+		Prefix:      idParts[1],
+	}, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/accounts"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/containers"
+)
+
+func TestStorageBlobDirectorySyncIDFormatter(t *testing.T) {
+	accountId := accounts.AccountId{
+		AccountName:   "account1",
+		DomainSuffix:  "core.windows.net",
+		SubDomainType: accounts.BlobSubDomainType,
+	}
+	actual := NewStorageBlobDirectorySyncID(containers.NewContainerID(accountId, "container1"), "site/").ID()
+	expected := "https://account1.blob.core.windows.net/container1|site/"
+	if actual != expected {
+		t.Fatalf("Expected %q but got %q", expected, actual)
+	}
+}
+
+func TestStorageBlobDirectorySyncID(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Error    bool
+		Expected *StorageBlobDirectorySyncId
+	}{
+		{
+			// empty
+			Input: "",
+			Error: true,
+		},
+		{
+			// the ID of a Blob
+			Input: "https://account1.blob.core.windows.net/container1/site/index.html",
+			Error: true,
+		},
+		{
+			// missing Container
+			Input: "https://account1.blob.core.windows.net|site/",
+			Error: true,
+		},
+		{
+			// no Prefix
+			Input: "https://account1.blob.core.windows.net/container1|",
+			Expected: &StorageBlobDirectorySyncId{
+				ContainerId: containers.ContainerId{
+					AccountId: accounts.AccountId{
+						AccountName:   "account1",
+						DomainSuffix:  "core.windows.net",
+						SubDomainType: accounts.BlobSubDomainType,
+					},
+					ContainerName: "container1",
+				},
+			},
+		},
+		{
+			// valid
+			Input: "https://account1.blob.core.windows.net/container1|site/assets/",
+			Expected: &StorageBlobDirectorySyncId{
+				ContainerId: containers.ContainerId{
+					AccountId: accounts.AccountId{
+						AccountName:   "account1",
+						DomainSuffix:  "core.windows.net",
+						SubDomainType: accounts.BlobSubDomainType,
+					},
+					ContainerName: "container1",
+				},
+				Prefix: "site/assets/",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := StorageBlobDirectorySyncID(v.Input, "core.windows.net")
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual.ContainerId != v.Expected.ContainerId {
+			t.Fatalf("Expected %+v but got %+v for ContainerId", v.Expected.ContainerId, actual.ContainerId)
+		}
+		if actual.Prefix != v.Expected.Prefix {
+			t.Fatalf("Expected %q but got %q for Prefix", v.Expected.Prefix, actual.Prefix)
+		}
+	}
+}
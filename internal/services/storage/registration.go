@@ -38,9 +38,11 @@ func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 		"azurerm_storage_container":                  dataSourceStorageContainer(),
 		"azurerm_storage_encryption_scope":           dataSourceStorageEncryptionScope(),
 		"azurerm_storage_management_policy":          dataSourceStorageManagementPolicy(),
+		"azurerm_storage_queue":                      dataSourceStorageQueue(),
 		"azurerm_storage_share":                      dataSourceStorageShare(),
 		"azurerm_storage_sync":                       dataSourceStorageSync(),
 		"azurerm_storage_sync_group":                 dataSourceStorageSyncGroup(),
+		"azurerm_storage_table":                      dataSourceStorageTable(),
 		"azurerm_storage_table_entity":               dataSourceStorageTableEntity(),
 	}
 }
@@ -75,11 +77,18 @@ func (r Registration) DataSources() []sdk.DataSource {
 	return []sdk.DataSource{
 		storageTableEntitiesDataSource{},
 		storageContainersDataSource{},
+		storageQueuesDataSource{},
+		storageSyncRegisteredServerDataSource{},
 	}
 }
 
 func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
 		LocalUserResource{},
+		StorageAccountFailoverResource{},
+		StorageBlobDirectorySyncResource{},
+		StorageContainerImmutabilityPolicyResource{},
+		StorageContainerLegalHoldResource{},
+		StorageSyncServerEndpointResource{},
 	}
 }
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdkhacks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/resourcemanager"
+	sdkEnv "github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+// TODO: remove this once the Storage SDK has been updated to API Version 2023-05-01 or later, which
+// includes the Customer Initiated Migration operations used to change the redundancy of a Storage Account
+
+const storageAccountMigrationApiVersion = "2023-05-01"
+
+type StorageAccountMigrationClient struct {
+	Client *resourcemanager.Client
+}
+
+func NewStorageAccountMigrationClientWithBaseURI(sdkApi sdkEnv.Api) (*StorageAccountMigrationClient, error) {
+	client, err := resourcemanager.NewResourceManagerClient(sdkApi, "storageaccounts", storageAccountMigrationApiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("instantiating StorageAccountMigrationClient: %+v", err)
+	}
+
+	return &StorageAccountMigrationClient{
+		Client: client,
+	}, nil
+}
+
+type MigrationStatus string
+
+const (
+	MigrationStatusComplete               MigrationStatus = "Complete"
+	MigrationStatusFailed                 MigrationStatus = "Failed"
+	MigrationStatusInProgress             MigrationStatus = "InProgress"
+	MigrationStatusInvalid                MigrationStatus = "Invalid"
+	MigrationStatusSubmittedForConversion MigrationStatus = "SubmittedForConversion"
+)
+
+type StorageAccountMigration struct {
+	Id         *string                           `json:"id,omitempty"`
+	Name       *string                           `json:"name,omitempty"`
+	Properties StorageAccountMigrationProperties `json:"properties"`
+	Type       *string                           `json:"type,omitempty"`
+}
+
+type StorageAccountMigrationProperties struct {
+	MigrationFailedDetailedReason *string                 `json:"migrationFailedDetailedReason,omitempty"`
+	MigrationFailedReason         *string                 `json:"migrationFailedReason,omitempty"`
+	MigrationStatus               *MigrationStatus        `json:"migrationStatus,omitempty"`
+	TargetSkuName                 storageaccounts.SkuName `json:"targetSkuName"`
+}
+
+type CustomerInitiatedMigrationOperationResponse struct {
+	Poller       pollers.Poller
+	HttpResponse *http.Response
+	OData        *odata.OData
+}
+
+type GetCustomerInitiatedMigrationOperationResponse struct {
+	HttpResponse *http.Response
+	OData        *odata.OData
+	Model        *StorageAccountMigration
+}
+
+// CustomerInitiatedMigration ...
+func (c StorageAccountMigrationClient) CustomerInitiatedMigration(ctx context.Context, id commonids.StorageAccountId, input StorageAccountMigration) (result CustomerInitiatedMigrationOperationResponse, err error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusAccepted,
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodPost,
+		Path:       fmt.Sprintf("%s/startAccountMigration", id.ID()),
+	}
+
+	req, err := c.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return
+	}
+
+	if err = req.Marshal(input); err != nil {
+		return
+	}
+
+	var resp *client.Response
+	resp, err = req.Execute(ctx)
+	if resp != nil {
+		result.OData = resp.OData
+		result.HttpResponse = resp.Response
+	}
+	if err != nil {
+		return
+	}
+
+	result.Poller, err = resourcemanager.PollerFromResponse(resp, c.Client)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// CustomerInitiatedMigrationThenPoll performs CustomerInitiatedMigration then polls until it's completed
+func (c StorageAccountMigrationClient) CustomerInitiatedMigrationThenPoll(ctx context.Context, id commonids.StorageAccountId, input StorageAccountMigration) error {
+	result, err := c.CustomerInitiatedMigration(ctx, id, input)
+	if err != nil {
+		return fmt.Errorf("performing CustomerInitiatedMigration: %+v", err)
+	}
+
+	if err := result.Poller.PollUntilDone(ctx); err != nil {
+		return fmt.Errorf("polling after CustomerInitiatedMigration: %+v", err)
+	}
+
+	return nil
+}
+
+// GetCustomerInitiatedMigration ...
+func (c StorageAccountMigrationClient) GetCustomerInitiatedMigration(ctx context.Context, id commonids.StorageAccountId) (result GetCustomerInitiatedMigrationOperationResponse, err error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		Path:       fmt.Sprintf("%s/accountMigrations/default", id.ID()),
+	}
+
+	req, err := c.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return
+	}
+
+	var resp *client.Response
+	resp, err = req.Execute(ctx)
+	if resp != nil {
+		result.OData = resp.OData
+		result.HttpResponse = resp.Response
+	}
+	if err != nil {
+		return
+	}
+
+	if err = resp.Unmarshal(&result.Model); err != nil {
+		return
+	}
+
+	return
+}
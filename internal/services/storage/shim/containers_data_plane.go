@@ -69,6 +69,29 @@ func (w DataPlaneStorageContainerWrapper) Get(ctx context.Context, containerName
 	}, nil
 }
 
+func (w DataPlaneStorageContainerWrapper) ListBlobs(ctx context.Context, containerName, prefix string) (*[]containers.BlobDetails, error) {
+	input := containers.ListBlobsInput{}
+	if prefix != "" {
+		input.Prefix = pointer.To(prefix)
+	}
+
+	output := make([]containers.BlobDetails, 0)
+	for {
+		resp, err := w.client.ListBlobs(ctx, containerName, input)
+		if err != nil {
+			return nil, fmt.Errorf("listing blobs: %+v", err)
+		}
+		output = append(output, resp.Blobs.Blobs...)
+
+		if resp.NextMarker == nil || *resp.NextMarker == "" {
+			break
+		}
+		input.Marker = resp.NextMarker
+	}
+
+	return &output, nil
+}
+
 func (w DataPlaneStorageContainerWrapper) UpdateAccessLevel(ctx context.Context, containerName string, level containers.AccessLevel) error {
 	input := containers.SetAccessControlInput{
 		AccessLevel: level,
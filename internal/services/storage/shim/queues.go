@@ -14,7 +14,9 @@ type StorageQueuesWrapper interface {
 	Delete(ctx context.Context, queueName string) error
 	Exists(ctx context.Context, queueName string) (*bool, error)
 	Get(ctx context.Context, queueName string) (*StorageQueueProperties, error)
+	GetACLs(ctx context.Context, queueName string) (*[]StorageQueueSignedIdentifier, error)
 	GetServiceProperties(ctx context.Context) (*queues.StorageServiceProperties, error)
+	UpdateACLs(ctx context.Context, queueName string, acls []StorageQueueSignedIdentifier) error
 	UpdateMetaData(ctx context.Context, queueName string, metaData map[string]string) error
 	UpdateServiceProperties(ctx context.Context, properties queues.StorageServiceProperties) error
 }
@@ -22,3 +24,14 @@ type StorageQueuesWrapper interface {
 type StorageQueueProperties struct {
 	MetaData map[string]string
 }
+
+type StorageQueueSignedIdentifier struct {
+	Id           string                   `xml:"Id"`
+	AccessPolicy StorageQueueAccessPolicy `xml:"AccessPolicy"`
+}
+
+type StorageQueueAccessPolicy struct {
+	Start      string `xml:"Start"`
+	Expiry     string `xml:"Expiry"`
+	Permission string `xml:"Permission"`
+}
@@ -5,9 +5,14 @@ package shim
 
 import (
 	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
 	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/queue/queues"
 )
 
@@ -59,6 +64,37 @@ func (w DataPlaneStorageQueueWrapper) Get(ctx context.Context, queueName string)
 	}, nil
 }
 
+// TODO: move the Queue ACL operations into Giovanni
+
+func (w DataPlaneStorageQueueWrapper) GetACLs(ctx context.Context, queueName string) (*[]StorageQueueSignedIdentifier, error) {
+	opts := client.RequestOptions{
+		ContentType: "application/xml; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod:    http.MethodGet,
+		OptionsObject: queueAclOptions{},
+		Path:          fmt.Sprintf("/%s", queueName),
+	}
+
+	req, err := w.client.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %+v", err)
+	}
+
+	resp, err := req.Execute(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %+v", err)
+	}
+
+	var result queueAcl
+	if err := resp.Unmarshal(&result); err != nil {
+		return nil, fmt.Errorf("unmarshalling response: %+v", err)
+	}
+
+	return &result.SignedIdentifiers, nil
+}
+
 func (w DataPlaneStorageQueueWrapper) GetServiceProperties(ctx context.Context) (*queues.StorageServiceProperties, error) {
 	serviceProps, err := w.client.GetServiceProperties(ctx)
 	if err != nil {
@@ -71,6 +107,33 @@ func (w DataPlaneStorageQueueWrapper) GetServiceProperties(ctx context.Context)
 	return &serviceProps.StorageServiceProperties, nil
 }
 
+func (w DataPlaneStorageQueueWrapper) UpdateACLs(ctx context.Context, queueName string, acls []StorageQueueSignedIdentifier) error {
+	opts := client.RequestOptions{
+		ContentType: "application/xml; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusNoContent,
+		},
+		HttpMethod:    http.MethodPut,
+		OptionsObject: queueAclOptions{},
+		Path:          fmt.Sprintf("/%s", queueName),
+	}
+
+	req, err := w.client.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("building request: %+v", err)
+	}
+
+	if err := req.Marshal(queueAcl{SignedIdentifiers: acls}); err != nil {
+		return fmt.Errorf("marshalling request: %+v", err)
+	}
+
+	if _, err := req.Execute(ctx); err != nil {
+		return fmt.Errorf("executing request: %+v", err)
+	}
+
+	return nil
+}
+
 func (w DataPlaneStorageQueueWrapper) UpdateMetaData(ctx context.Context, queueName string, metaData map[string]string) error {
 	input := queues.SetMetaDataInput{
 		MetaData: metaData,
@@ -86,3 +149,25 @@ func (w DataPlaneStorageQueueWrapper) UpdateServiceProperties(ctx context.Contex
 	_, err := w.client.SetServiceProperties(ctx, input)
 	return err
 }
+
+type queueAcl struct {
+	SignedIdentifiers []StorageQueueSignedIdentifier `xml:"SignedIdentifier"`
+
+	XMLName xml.Name `xml:"SignedIdentifiers"`
+}
+
+type queueAclOptions struct{}
+
+func (o queueAclOptions) ToHeaders() *client.Headers {
+	return nil
+}
+
+func (o queueAclOptions) ToOData() *odata.Query {
+	return nil
+}
+
+func (o queueAclOptions) ToQuery() *client.QueryParams {
+	out := &client.QueryParams{}
+	out.Append("comp", "acl")
+	return out
+}
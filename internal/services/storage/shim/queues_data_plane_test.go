@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package shim
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/queue/queues"
+)
+
+func TestDataPlaneStorageQueueWrapperACLs(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Minute)
+	defer cancel()
+
+	var stored []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/example" || r.URL.Query().Get("comp") != "acl" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			stored = body
+			w.WriteHeader(http.StatusNoContent)
+
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusOK)
+			w.Write(stored)
+
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer server.Close()
+
+	client, err := queues.NewWithBaseUri(server.URL)
+	if err != nil {
+		t.Fatalf("building Queues Client: %+v", err)
+	}
+	wrapper := NewDataPlaneStorageQueueWrapper(client)
+
+	expected := []StorageQueueSignedIdentifier{
+		{
+			Id: "MTIzNDU2Nzg5MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTI",
+			AccessPolicy: StorageQueueAccessPolicy{
+				Start:      "2024-01-01T00:00:00.0000000Z",
+				Expiry:     "2025-01-01T00:00:00.0000000Z",
+				Permission: "raup",
+			},
+		},
+	}
+	if err := wrapper.UpdateACLs(ctx, "example", expected); err != nil {
+		t.Fatalf("updating ACLs: %+v", err)
+	}
+
+	actual, err := wrapper.GetACLs(ctx, "example")
+	if err != nil {
+		t.Fatalf("retrieving ACLs: %+v", err)
+	}
+	if actual == nil || !reflect.DeepEqual(*actual, expected) {
+		t.Fatalf("expected ACLs %+v but got %+v", expected, actual)
+	}
+}
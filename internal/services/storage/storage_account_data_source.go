@@ -11,9 +11,11 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-09-01/storage" // nolint: staticcheck
 	azautorest "github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
@@ -112,6 +114,36 @@ func dataSourceStorageAccount() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"failover_in_progress": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
+			"failover_available": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
+			"planned_failover_available": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
+			"geo_replication_status": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"geo_replication_last_sync_time": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"last_geo_failover_time": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"primary_blob_endpoint": {
 				Type:     pluginsdk.TypeString,
 				Computed: true,
@@ -535,6 +567,7 @@ func dataSourceStorageAccount() *pluginsdk.Resource {
 
 func dataSourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Storage.AccountsClient
+	accountsClient := meta.(*clients.Client).Storage.ResourceManager.StorageAccounts
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
 	defer cancel()
@@ -683,5 +716,43 @@ func dataSourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) e
 		return fmt.Errorf("setting `identity`: %+v", err)
 	}
 
+	failoverInProgress := false
+	lastGeoFailoverTime := ""
+	if props := resp.AccountProperties; props != nil {
+		failoverInProgress = pointer.From(props.FailoverInProgress)
+		if props.LastGeoFailoverTime != nil {
+			lastGeoFailoverTime = props.LastGeoFailoverTime.Format(time.RFC3339)
+		}
+	}
+	d.Set("failover_in_progress", failoverInProgress)
+	d.Set("last_geo_failover_time", lastGeoFailoverTime)
+
+	failoverAvailable := false
+	plannedFailoverAvailable := false
+	geoReplicationStatus := ""
+	geoReplicationLastSyncTime := ""
+	// the geo-replication statistics are only available for geo-redundant Storage Accounts
+	if storageAccountReplicationTypeGeoRedundancy(d.Get("account_replication_type").(string)) != "" {
+		opts := storageaccounts.GetPropertiesOperationOptions{
+			Expand: pointer.To(storageaccounts.StorageAccountExpandGeoReplicationStats),
+		}
+		geoResp, err := accountsClient.GetProperties(ctx, id, opts)
+		if err != nil {
+			return fmt.Errorf("retrieving geo-replication statistics for %s: %+v", id, err)
+		}
+		if model := geoResp.Model; model != nil && model.Properties != nil {
+			if stats := model.Properties.GeoReplicationStats; stats != nil {
+				failoverAvailable = pointer.From(stats.CanFailover)
+				plannedFailoverAvailable = pointer.From(stats.CanPlannedFailover)
+				geoReplicationStatus = string(pointer.From(stats.Status))
+				geoReplicationLastSyncTime = pointer.From(stats.LastSyncTime)
+			}
+		}
+	}
+	d.Set("failover_available", failoverAvailable)
+	d.Set("planned_failover_available", plannedFailoverAvailable)
+	d.Set("geo_replication_status", geoReplicationStatus)
+	d.Set("geo_replication_last_sync_time", geoReplicationLastSyncTime)
+
 	return tags.FlattenAndSet(d, resp.Tags)
 }
@@ -32,6 +32,22 @@ func TestAccDataSourceStorageAccount_basic(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceStorageAccount_geoRedundant(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_account", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: StorageAccountDataSource{}.geoRedundant(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("account_replication_type").HasValue("RAGRS"),
+				check.That(data.ResourceName).Key("failover_in_progress").HasValue("false"),
+				check.That(data.ResourceName).Key("geo_replication_status").IsSet(),
+				check.That(data.ResourceName).Key("last_geo_failover_time").IsEmpty(),
+			),
+		},
+	})
+}
+
 func TestAccDataSourceStorageAccount_withWriteLock(t *testing.T) {
 	data := acceptance.BuildTestData(t, "data.azurerm_storage_account", "test")
 
@@ -201,6 +217,33 @@ resource "azurerm_storage_account" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomString)
 }
 
+func (d StorageAccountDataSource) geoRedundant(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                = "acctestsads%s"
+  resource_group_name = azurerm_resource_group.test.name
+
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "RAGRS"
+}
+
+data "azurerm_storage_account" "test" {
+  name                = azurerm_storage_account.test.name
+  resource_group_name = azurerm_storage_account.test.resource_group_name
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
 func (d StorageAccountDataSource) basicWriteLock(data acceptance.TestData) string {
 	template := d.basic(data)
 	return fmt.Sprintf(`
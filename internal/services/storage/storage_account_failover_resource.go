@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+const storageAccountFailoverTypeUnplanned = "Unplanned"
+
+type StorageAccountFailoverResource struct{}
+
+var _ sdk.Resource = StorageAccountFailoverResource{}
+
+type StorageAccountFailoverModel struct {
+	StorageAccountId string            `tfschema:"storage_account_id"`
+	FailoverType     string            `tfschema:"failover_type"`
+	Triggers         map[string]string `tfschema:"triggers"`
+	LastFailoverTime string            `tfschema:"last_failover_time"`
+}
+
+func (r StorageAccountFailoverResource) ResourceType() string {
+	return "azurerm_storage_account_failover"
+}
+
+func (r StorageAccountFailoverResource) ModelObject() interface{} {
+	return &StorageAccountFailoverModel{}
+}
+
+func (r StorageAccountFailoverResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.StorageAccountFailoverID
+}
+
+func (r StorageAccountFailoverResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"storage_account_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: commonids.ValidateStorageAccountID,
+		},
+
+		"failover_type": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Default:  string(storageaccounts.FailoverTypePlanned),
+			ValidateFunc: validation.StringInSlice([]string{
+				string(storageaccounts.FailoverTypePlanned),
+				storageAccountFailoverTypeUnplanned,
+			}, false),
+		},
+
+		"triggers": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			ForceNew: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (r StorageAccountFailoverResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"last_failover_time": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r StorageAccountFailoverResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 2 * time.Hour,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.ResourceManager.StorageAccounts
+
+			var config StorageAccountFailoverModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id, err := commonids.ParseStorageAccountID(config.StorageAccountId)
+			if err != nil {
+				return err
+			}
+
+			locks.ByName(id.StorageAccountName, storageAccountResourceName)
+			defer locks.UnlockByName(id.StorageAccountName, storageAccountResourceName)
+
+			opts := storageaccounts.GetPropertiesOperationOptions{
+				Expand: pointer.To(storageaccounts.StorageAccountExpandGeoReplicationStats),
+			}
+			existing, err := client.GetProperties(ctx, *id, opts)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if existing.Model == nil || existing.Model.Properties == nil {
+				return fmt.Errorf("retrieving %s: `properties` was nil", id)
+			}
+			props := existing.Model.Properties
+
+			if pointer.From(props.FailoverInProgress) {
+				return fmt.Errorf("a failover is already in progress for %s", id)
+			}
+
+			stats := props.GeoReplicationStats
+			if stats == nil {
+				return fmt.Errorf("geo-replication statistics are unavailable for %s - failover is only supported for geo-redundant Storage Accounts", id)
+			}
+
+			options := storageaccounts.DefaultFailoverOperationOptions()
+			if config.FailoverType == string(storageaccounts.FailoverTypePlanned) {
+				if !pointer.From(stats.CanPlannedFailover) {
+					return fmt.Errorf("a planned failover is not currently possible for %s (geo-replication status is %q)", id, pointer.From(stats.Status))
+				}
+				options.FailoverType = pointer.To(storageaccounts.FailoverTypePlanned)
+			} else if !pointer.From(stats.CanFailover) {
+				return fmt.Errorf("a failover is not currently possible for %s (geo-replication status is %q)", id, pointer.From(stats.Status))
+			}
+
+			if err := client.FailoverThenPoll(ctx, *id, options); err != nil {
+				return fmt.Errorf("failing over %s: %+v", id, err)
+			}
+
+			metadata.SetID(parse.NewStorageAccountFailoverID(id.SubscriptionId, id.ResourceGroupName, id.StorageAccountName, "default"))
+			return nil
+		},
+	}
+}
+
+func (r StorageAccountFailoverResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.ResourceManager.StorageAccounts
+
+			id, err := parse.StorageAccountFailoverID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			storageAccountId := commonids.NewStorageAccountID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName)
+			resp, err := client.GetProperties(ctx, storageAccountId, storageaccounts.DefaultGetPropertiesOperationOptions())
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", storageAccountId, err)
+			}
+
+			// the failover itself isn't exposed by the API, so `failover_type` and `triggers` are retained from the state
+			var state StorageAccountFailoverModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+			state.StorageAccountId = storageAccountId.ID()
+			if state.FailoverType == "" {
+				state.FailoverType = string(storageaccounts.FailoverTypePlanned)
+			}
+
+			if model := resp.Model; model != nil && model.Properties != nil {
+				state.LastFailoverTime = pointer.From(model.Properties.LastGeoFailoverTime)
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r StorageAccountFailoverResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			// a failover can't be undone, instead the Storage Account can be failed over again (e.g. by changing the `triggers`)
+			// as such this only removes the resource from the state
+			return nil
+		},
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+// NOTE: a Storage Account can only be failed over once the geo-replication to the secondary region is live, which
+// can take a considerable amount of time after it's created - as such these tests use an existing geo-redundant
+// Storage Account (ARM_TEST_STORAGE_ACCOUNT_FAILOVER_ID), which is failed over to the secondary region
+
+type StorageAccountFailoverResource struct{}
+
+func TestAccStorageAccountFailover_planned(t *testing.T) {
+	if os.Getenv("ARM_TEST_STORAGE_ACCOUNT_FAILOVER_ID") == "" {
+		t.Skipf("Skipping as ARM_TEST_STORAGE_ACCOUNT_FAILOVER_ID is not set")
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_storage_account_failover", "test")
+	r := StorageAccountFailoverResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.planned(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("last_failover_time").IsSet(),
+			),
+		},
+		data.ImportStep("triggers"),
+	})
+}
+
+func (r StorageAccountFailoverResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.StorageAccountFailoverID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	storageAccountId := commonids.NewStorageAccountID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName)
+	resp, err := client.Storage.ResourceManager.StorageAccounts.GetProperties(ctx, storageAccountId, storageaccounts.DefaultGetPropertiesOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", storageAccountId, err)
+	}
+
+	return utils.Bool(resp.Model != nil && resp.Model.Properties != nil && resp.Model.Properties.LastGeoFailoverTime != nil), nil
+}
+
+func (r StorageAccountFailoverResource) planned(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_storage_account_failover" "test" {
+  storage_account_id = %q
+  failover_type      = "Planned"
+
+  triggers = {
+    test = "%d"
+  }
+}
+`, os.Getenv("ARM_TEST_STORAGE_ACCOUNT_FAILOVER_ID"), data.RandomInteger)
+}
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/sdkhacks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// storageAccountReplicationTypeIsZoneRedundant returns whether the specified `account_replication_type` replicates
+// data across Availability Zones in the primary region
+func storageAccountReplicationTypeIsZoneRedundant(input string) bool {
+	switch strings.ToUpper(input) {
+	case "ZRS", "GZRS", "RAGZRS":
+		return true
+	}
+	return false
+}
+
+// storageAccountReplicationTypeGeoRedundancy returns the geo-redundancy of the specified `account_replication_type`
+// irrespective of whether it's zone-redundant, for example both `RAGRS` and `RAGZRS` return `RAGRS`
+func storageAccountReplicationTypeGeoRedundancy(input string) string {
+	switch strings.ToUpper(input) {
+	case "GRS", "GZRS":
+		return "GRS"
+	case "RAGRS", "RAGZRS":
+		return "RAGRS"
+	}
+	return ""
+}
+
+// storageAccountReplicationTypeChangeRequiresNew returns whether changing the `account_replication_type` from `old` to
+// `new` requires the Storage Account to be recreated. Changing either the geo-redundancy or the zone-redundancy of a
+// Storage Account can be done in-place (the latter via a Customer Initiated Migration), however both can't be changed
+// at the same time.
+func storageAccountReplicationTypeChangeRequiresNew(old, new string) bool {
+	zoneRedundancyChanged := storageAccountReplicationTypeIsZoneRedundant(old) != storageAccountReplicationTypeIsZoneRedundant(new)
+	geoRedundancyChanged := storageAccountReplicationTypeGeoRedundancy(old) != storageAccountReplicationTypeGeoRedundancy(new)
+	return zoneRedundancyChanged && geoRedundancyChanged
+}
+
+// migrateStorageAccountRedundancy converts the Storage Account to the specified SKU using a Customer Initiated Migration,
+// waiting for the conversion to complete - which can take a considerable amount of time for larger Storage Accounts.
+//
+// Since a migration continues in Azure once it's been accepted, a migration to the same SKU which is already in progress
+// (for example when a previous apply timed out) is resumed rather than being submitted again.
+func migrateStorageAccountRedundancy(ctx context.Context, client *sdkhacks.StorageAccountMigrationClient, id commonids.StorageAccountId, targetSku string) error {
+	existing, err := client.GetCustomerInitiatedMigration(ctx, id)
+	if err != nil && !response.WasNotFound(existing.HttpResponse) {
+		return fmt.Errorf("retrieving Customer Initiated Migration for %s: %+v", id, err)
+	}
+
+	resume, err := storageAccountMigrationShouldResume(existing.Model, targetSku)
+	if err != nil {
+		return fmt.Errorf("migrating %s to %q: %+v", id, targetSku, err)
+	}
+
+	if resume {
+		log.Printf("[DEBUG] Resuming the in-progress Customer Initiated Migration of %s to %q..", id, targetSku)
+	} else {
+		payload := sdkhacks.StorageAccountMigration{
+			Properties: sdkhacks.StorageAccountMigrationProperties{
+				TargetSkuName: storageaccounts.SkuName(targetSku),
+			},
+		}
+		if err := client.CustomerInitiatedMigrationThenPoll(ctx, id, payload); err != nil {
+			return fmt.Errorf("migrating %s to %q: %+v", id, targetSku, err)
+		}
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return fmt.Errorf("internal-error: context had no deadline")
+	}
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending: []string{
+			string(sdkhacks.MigrationStatusSubmittedForConversion),
+			string(sdkhacks.MigrationStatusInProgress),
+		},
+		Target: []string{
+			string(sdkhacks.MigrationStatusComplete),
+		},
+		Refresh:      storageAccountMigrationRefreshFunc(ctx, client, id),
+		MinTimeout:   30 * time.Second,
+		PollInterval: time.Minute,
+		Timeout:      time.Until(deadline),
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("waiting for the migration of %s to %q to complete (the migration continues in Azure and will be resumed the next time this is applied): %+v", id, targetSku, err)
+	}
+
+	return nil
+}
+
+// storageAccountMigrationShouldResume returns whether the existing Customer Initiated Migration is an in-progress
+// migration to `targetSku` which should be resumed - an error is returned when a migration to a different SKU is in progress
+func storageAccountMigrationShouldResume(existing *sdkhacks.StorageAccountMigration, targetSku string) (bool, error) {
+	if existing == nil || existing.Properties.MigrationStatus == nil {
+		return false, nil
+	}
+
+	status := *existing.Properties.MigrationStatus
+	if status != sdkhacks.MigrationStatusSubmittedForConversion && status != sdkhacks.MigrationStatusInProgress {
+		return false, nil
+	}
+
+	if !strings.EqualFold(string(existing.Properties.TargetSkuName), targetSku) {
+		return false, fmt.Errorf("a Customer Initiated Migration to %q is already in progress and must complete first", string(existing.Properties.TargetSkuName))
+	}
+
+	return true, nil
+}
+
+func storageAccountMigrationRefreshFunc(ctx context.Context, client *sdkhacks.StorageAccountMigrationClient, id commonids.StorageAccountId) pluginsdk.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := client.GetCustomerInitiatedMigration(ctx, id)
+		if err != nil {
+			return nil, "", fmt.Errorf("retrieving Customer Initiated Migration for %s: %+v", id, err)
+		}
+
+		if resp.Model == nil || resp.Model.Properties.MigrationStatus == nil {
+			return nil, "", fmt.Errorf("retrieving Customer Initiated Migration for %s: `properties.migrationStatus` was nil", id)
+		}
+
+		status := *resp.Model.Properties.MigrationStatus
+		if status == sdkhacks.MigrationStatusFailed || status == sdkhacks.MigrationStatusInvalid {
+			return resp, string(status), fmt.Errorf("the Customer Initiated Migration for %s failed: %s (%s)", id, pointer.From(resp.Model.Properties.MigrationFailedReason), pointer.From(resp.Model.Properties.MigrationFailedDetailedReason))
+		}
+
+		return resp, string(status), nil
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/sdkhacks"
+)
+
+func TestStorageAccountReplicationTypeChangeRequiresNew(t *testing.T) {
+	testData := []struct {
+		old      string
+		new      string
+		expected bool
+	}{
+		// geo-redundancy only
+		{old: "LRS", new: "GRS", expected: false},
+		{old: "GRS", new: "RAGRS", expected: false},
+		{old: "ZRS", new: "GZRS", expected: false},
+		{old: "RAGZRS", new: "ZRS", expected: false},
+
+		// zone-redundancy only
+		{old: "LRS", new: "ZRS", expected: false},
+		{old: "ZRS", new: "LRS", expected: false},
+		{old: "GRS", new: "GZRS", expected: false},
+		{old: "RAGZRS", new: "RAGRS", expected: false},
+
+		// both
+		{old: "LRS", new: "GZRS", expected: true},
+		{old: "LRS", new: "RAGZRS", expected: true},
+		{old: "ZRS", new: "GRS", expected: true},
+		{old: "GZRS", new: "LRS", expected: true},
+		{old: "RAGRS", new: "ZRS", expected: true},
+
+		// casing
+		{old: "lrs", new: "ZRS", expected: false},
+		{old: "lrs", new: "gzrs", expected: true},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q -> %q", v.old, v.new)
+
+		actual := storageAccountReplicationTypeChangeRequiresNew(v.old, v.new)
+		if actual != v.expected {
+			t.Fatalf("expected %t but got %t for %q -> %q", v.expected, actual, v.old, v.new)
+		}
+	}
+}
+
+func TestStorageAccountMigrationShouldResume(t *testing.T) {
+	migration := func(status sdkhacks.MigrationStatus, targetSku string) *sdkhacks.StorageAccountMigration {
+		return &sdkhacks.StorageAccountMigration{
+			Properties: sdkhacks.StorageAccountMigrationProperties{
+				MigrationStatus: pointer.To(status),
+				TargetSkuName:   storageaccounts.SkuName(targetSku),
+			},
+		}
+	}
+
+	testData := []struct {
+		name     string
+		existing *sdkhacks.StorageAccountMigration
+		expected bool
+		error    bool
+	}{
+		{
+			name:     "no migration",
+			existing: nil,
+		},
+		{
+			name:     "no status",
+			existing: &sdkhacks.StorageAccountMigration{},
+		},
+		{
+			name:     "completed",
+			existing: migration(sdkhacks.MigrationStatusComplete, "Standard_ZRS"),
+		},
+		{
+			name:     "failed",
+			existing: migration(sdkhacks.MigrationStatusFailed, "Standard_ZRS"),
+		},
+		{
+			name:     "submitted for the same sku",
+			existing: migration(sdkhacks.MigrationStatusSubmittedForConversion, "Standard_ZRS"),
+			expected: true,
+		},
+		{
+			name:     "in progress for the same sku",
+			existing: migration(sdkhacks.MigrationStatusInProgress, "standard_zrs"),
+			expected: true,
+		},
+		{
+			name:     "in progress for a different sku",
+			existing: migration(sdkhacks.MigrationStatusInProgress, "Standard_LRS"),
+			error:    true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		actual, err := storageAccountMigrationShouldResume(v.existing, "Standard_ZRS")
+		if err != nil {
+			if v.error {
+				continue
+			}
+			t.Fatalf("unexpected error for %q: %+v", v.name, err)
+		}
+		if v.error {
+			t.Fatalf("expected an error for %q but didn't get one", v.name)
+		}
+		if actual != v.expected {
+			t.Fatalf("expected %t but got %t for %q", v.expected, actual, v.name)
+		}
+	}
+}
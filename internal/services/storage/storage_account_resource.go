@@ -1292,19 +1292,7 @@ func resourceStorageAccount() *pluginsdk.Resource {
 				return nil
 			}),
 			pluginsdk.ForceNewIfChange("account_replication_type", func(ctx context.Context, old, new, meta interface{}) bool {
-				newAccRep := strings.ToUpper(new.(string))
-
-				switch strings.ToUpper(old.(string)) {
-				case "LRS", "GRS", "RAGRS":
-					if newAccRep == "GZRS" || newAccRep == "RAGZRS" || newAccRep == "ZRS" {
-						return true
-					}
-				case "ZRS", "GZRS", "RAGZRS":
-					if newAccRep == "LRS" || newAccRep == "GRS" || newAccRep == "RAGRS" {
-						return true
-					}
-				}
-				return false
+				return storageAccountReplicationTypeChangeRequiresNew(old.(string), new.(string))
 			}),
 		),
 	}
@@ -1688,6 +1676,7 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 	tenantId := meta.(*clients.Client).Account.TenantId
 	client := meta.(*clients.Client).Storage.AccountsClient
+	migrationsClient := meta.(*clients.Client).Storage.AccountMigrationsClient
 	keyVaultClient := meta.(*clients.Client).KeyVault
 	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
@@ -1711,6 +1700,17 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		}
 	}
 
+	if d.HasChange("account_replication_type") {
+		oldReplicationType, newReplicationType := d.GetChange("account_replication_type")
+		if storageAccountReplicationTypeIsZoneRedundant(oldReplicationType.(string)) != storageAccountReplicationTypeIsZoneRedundant(newReplicationType.(string)) {
+			// the zone-redundancy can't be changed by updating the SKU and instead requires a migration - since the
+			// geo-redundancy can't change at the same time (force-new) the SKU then matches `storageType`
+			if err := migrateStorageAccountRedundancy(ctx, migrationsClient, *id, storageType); err != nil {
+				return err
+			}
+		}
+	}
+
 	existing, err := client.GetProperties(ctx, id.ResourceGroupName, id.StorageAccountName, "")
 	if err != nil {
 		return fmt.Errorf("reading for %s: %+v", id, err)
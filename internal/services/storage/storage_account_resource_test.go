@@ -861,6 +861,34 @@ func TestAccStorageAccount_replicationTypeGZRS(t *testing.T) {
 	})
 }
 
+func TestAccStorageAccount_replicationTypeZoneRedundancyMigration(t *testing.T) {
+	if os.Getenv("ARM_TEST_STORAGE_REDUNDANCY_MIGRATION") == "" {
+		t.Skipf("Skipping as ARM_TEST_STORAGE_REDUNDANCY_MIGRATION is not set - migrating the redundancy of a Storage Account can take several hours")
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_storage_account", "test")
+	r := StorageAccountResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.update(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("account_replication_type").HasValue("GRS"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.replicationTypeGZRSMigration(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("account_replication_type").HasValue("GZRS"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccStorageAccount_largeFileShare(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_storage_account", "test")
 	r := StorageAccountResource{}
@@ -3275,6 +3303,36 @@ resource "azurerm_storage_account" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomString)
 }
 
+func (r StorageAccountResource) replicationTypeGZRSMigration(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                = "unlikely23exst2acct%s"
+  resource_group_name = azurerm_resource_group.test.name
+
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "GZRS"
+
+  tags = {
+    environment = "staging"
+  }
+
+  timeouts {
+    update = "24h"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
 func (r StorageAccountResource) replicationTypeGZRS(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -0,0 +1,387 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/accounts"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/containers"
+)
+
+type StorageBlobDirectorySyncResource struct{}
+
+var (
+	_ sdk.ResourceWithUpdate        = StorageBlobDirectorySyncResource{}
+	_ sdk.ResourceWithCustomizeDiff = StorageBlobDirectorySyncResource{}
+)
+
+type StorageBlobDirectorySyncResourceModel struct {
+	StorageAccountName    string            `tfschema:"storage_account_name"`
+	StorageContainerName  string            `tfschema:"storage_container_name"`
+	SourceDirectory       string            `tfschema:"source_directory"`
+	Prefix                string            `tfschema:"prefix"`
+	CacheControl          string            `tfschema:"cache_control"`
+	ContentTypes          map[string]string `tfschema:"content_types"`
+	DeleteExtraneousBlobs bool              `tfschema:"delete_extraneous_blobs"`
+	EncryptionScope       string            `tfschema:"encryption_scope"`
+	Parallelism           int64             `tfschema:"parallelism"`
+	Files                 map[string]string `tfschema:"files"`
+}
+
+func (r StorageBlobDirectorySyncResource) ResourceType() string {
+	return "azurerm_storage_blob_directory_sync"
+}
+
+func (r StorageBlobDirectorySyncResource) ModelObject() interface{} {
+	return &StorageBlobDirectorySyncResourceModel{}
+}
+
+func (r StorageBlobDirectorySyncResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.StorageBlobDirectorySyncID
+}
+
+func (r StorageBlobDirectorySyncResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"storage_account_name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.StorageAccountName,
+		},
+
+		"storage_container_name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.StorageContainerName,
+		},
+
+		"source_directory": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"prefix": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			ForceNew: true,
+			ValidateFunc: validation.All(
+				validation.StringIsNotEmpty,
+				validation.StringDoesNotContainAny("\\"),
+				validation.StringMatch(regexp.MustCompile(`^[^/]`), "`prefix` cannot start with a `/`"),
+				validation.StringMatch(regexp.MustCompile(`/$`), "`prefix` must end with a `/`"),
+			),
+		},
+
+		"cache_control": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+
+		"content_types": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+
+		"delete_extraneous_blobs": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"encryption_scope": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validate.StorageEncryptionScopeName,
+		},
+
+		"parallelism": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Default:      8,
+			ValidateFunc: validation.IntBetween(1, 64),
+		},
+	}
+}
+
+func (r StorageBlobDirectorySyncResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"files": {
+			Type:     pluginsdk.TypeMap,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (r StorageBlobDirectorySyncResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			diff := metadata.ResourceDiff
+
+			sourceDirectory := diff.Get("source_directory").(string)
+			if !diff.NewValueKnown("source_directory") {
+				return diff.SetNewComputed("files")
+			}
+			if _, err := os.Stat(sourceDirectory); os.IsNotExist(err) {
+				// the directory may be populated during the apply, for example by a `local_file`
+				return diff.SetNewComputed("files")
+			}
+
+			local, err := LocalBlobDirectoryHashes(sourceDirectory)
+			if err != nil {
+				return err
+			}
+
+			existing := make(map[string]string)
+			for k, v := range diff.Get("files").(map[string]interface{}) {
+				existing[k] = v.(string)
+			}
+			if reflect.DeepEqual(existing, local) {
+				return nil
+			}
+
+			files := make(map[string]interface{}, len(local))
+			for k, v := range local {
+				files[k] = v
+			}
+			return diff.SetNew("files", files)
+		},
+	}
+}
+
+func (r StorageBlobDirectorySyncResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			storageClient := metadata.Client.Storage
+
+			var config StorageBlobDirectorySyncResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			accountId := accounts.AccountId{
+				AccountName:   config.StorageAccountName,
+				DomainSuffix:  storageClient.StorageDomainSuffix,
+				SubDomainType: accounts.BlobSubDomainType,
+			}
+			id := parse.NewStorageBlobDirectorySyncID(containers.NewContainerID(accountId, config.StorageContainerName), config.Prefix)
+
+			directorySync, err := r.directorySync(ctx, metadata, id, config)
+			if err != nil {
+				return err
+			}
+
+			if _, err := directorySync.Sync(ctx, nil, false); err != nil {
+				return fmt.Errorf("syncing %q to %s: %+v", config.SourceDirectory, id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r StorageBlobDirectorySyncResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			storageClient := metadata.Client.Storage
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			id, err := parse.StorageBlobDirectorySyncID(metadata.ResourceData.Id(), storageClient.StorageDomainSuffix)
+			if err != nil {
+				return err
+			}
+
+			account, err := storageClient.FindAccount(ctx, subscriptionId, id.AccountId.AccountName)
+			if err != nil {
+				return fmt.Errorf("retrieving Account %q for %s: %+v", id.AccountId.AccountName, id, err)
+			}
+			if account == nil {
+				return metadata.MarkAsGone(id)
+			}
+
+			containersClient, err := storageClient.ContainersDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+			if err != nil {
+				return fmt.Errorf("building Containers Client: %+v", err)
+			}
+
+			exists, err := containersClient.Exists(ctx, id.ContainerName)
+			if err != nil {
+				return fmt.Errorf("checking for the presence of Container %q for %s: %+v", id.ContainerName, id, err)
+			}
+			if !*exists {
+				return metadata.MarkAsGone(id)
+			}
+
+			var state StorageBlobDirectorySyncResourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+			state.StorageAccountName = id.AccountId.AccountName
+			state.StorageContainerName = id.ContainerName
+			state.Prefix = id.Prefix
+
+			directorySync := BlobDirectorySync{
+				ContainersClient: containersClient,
+				ContainerName:    id.ContainerName,
+				Prefix:           id.Prefix,
+			}
+			remote, err := directorySync.RemoteHashes(ctx)
+			if err != nil {
+				return fmt.Errorf("retrieving the Blobs for %s: %+v", id, err)
+			}
+
+			// only the Blobs uploaded from the directory are tracked, unless extraneous Blobs should be deleted - in
+			// which case any additional Blobs need to show a diff
+			files := make(map[string]string)
+			for file, hash := range remote {
+				if _, ok := state.Files[file]; ok || state.DeleteExtraneousBlobs {
+					files[file] = hash
+				}
+			}
+			state.Files = files
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r StorageBlobDirectorySyncResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			storageClient := metadata.Client.Storage
+
+			id, err := parse.StorageBlobDirectorySyncID(metadata.ResourceData.Id(), storageClient.StorageDomainSuffix)
+			if err != nil {
+				return err
+			}
+
+			var config StorageBlobDirectorySyncResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			directorySync, err := r.directorySync(ctx, metadata, *id, config)
+			if err != nil {
+				return err
+			}
+
+			managed := make(map[string]string)
+			oldFiles, _ := metadata.ResourceData.GetChange("files")
+			for k, v := range oldFiles.(map[string]interface{}) {
+				managed[k] = v.(string)
+			}
+
+			// the properties of every Blob need to be updated when these change, rather than only those which differ
+			force := metadata.ResourceData.HasChanges("cache_control", "content_types", "encryption_scope")
+
+			if _, err := directorySync.Sync(ctx, managed, force); err != nil {
+				return fmt.Errorf("syncing %q to %s: %+v", config.SourceDirectory, id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r StorageBlobDirectorySyncResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			storageClient := metadata.Client.Storage
+
+			id, err := parse.StorageBlobDirectorySyncID(metadata.ResourceData.Id(), storageClient.StorageDomainSuffix)
+			if err != nil {
+				return err
+			}
+
+			var config StorageBlobDirectorySyncResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			directorySync, err := r.directorySync(ctx, metadata, *id, config)
+			if err != nil {
+				return err
+			}
+
+			// only the Blobs uploaded from the directory are deleted
+			files := make([]string, 0, len(config.Files))
+			for file := range config.Files {
+				files = append(files, file)
+			}
+			if err := directorySync.Delete(ctx, files); err != nil {
+				return fmt.Errorf("deleting the Blobs for %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r StorageBlobDirectorySyncResource) directorySync(ctx context.Context, metadata sdk.ResourceMetaData, id parse.StorageBlobDirectorySyncId, config StorageBlobDirectorySyncResourceModel) (*BlobDirectorySync, error) {
+	storageClient := metadata.Client.Storage
+	subscriptionId := metadata.Client.Account.SubscriptionId
+
+	account, err := storageClient.FindAccount(ctx, subscriptionId, id.AccountId.AccountName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Account %q for %s: %+v", id.AccountId.AccountName, id, err)
+	}
+	if account == nil {
+		return nil, fmt.Errorf("locating Storage Account %q", id.AccountId.AccountName)
+	}
+
+	blobsClient, err := storageClient.BlobsDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+	if err != nil {
+		return nil, fmt.Errorf("building Blobs Client: %+v", err)
+	}
+
+	containersClient, err := storageClient.ContainersDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+	if err != nil {
+		return nil, fmt.Errorf("building Containers Client: %+v", err)
+	}
+
+	contentTypes := make(map[string]string, len(config.ContentTypes))
+	for extension, contentType := range config.ContentTypes {
+		contentTypes[strings.ToLower(extension)] = contentType
+	}
+
+	return &BlobDirectorySync{
+		BlobsClient:           blobsClient,
+		ContainersClient:      containersClient,
+		AccountName:           id.AccountId.AccountName,
+		ContainerName:         id.ContainerName,
+		Prefix:                id.Prefix,
+		SourceDirectory:       config.SourceDirectory,
+		CacheControl:          config.CacheControl,
+		ContentTypes:          contentTypes,
+		DeleteExtraneousBlobs: config.DeleteExtraneousBlobs,
+		EncryptionScope:       config.EncryptionScope,
+		Parallelism:           int(config.Parallelism),
+	}, nil
+}
@@ -0,0 +1,231 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type StorageBlobDirectorySyncResource struct{}
+
+func TestAccStorageBlobDirectorySync_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory_sync", "test")
+	r := StorageBlobDirectorySyncResource{}
+	directory := r.sourceDirectory(t, map[string]string{
+		"index.html":   "<html></html>",
+		"css/site.css": "body {}",
+	})
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, directory),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("files.%").HasValue("2"),
+			),
+		},
+		data.ImportStep("source_directory", "files"),
+	})
+}
+
+func TestAccStorageBlobDirectorySync_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory_sync", "test")
+	r := StorageBlobDirectorySyncResource{}
+	directory := r.sourceDirectory(t, map[string]string{
+		"index.html":   "<html></html>",
+		"css/site.css": "body {}",
+	})
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, directory),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("files.%").HasValue("2"),
+			),
+		},
+		{
+			PreConfig: func() {
+				r.writeFiles(t, directory, map[string]string{
+					"index.html":   "<html><body></body></html>",
+					"js/site.js":   "console.log('hello');",
+					"data/file.ex": "hello",
+				})
+				if err := os.Remove(filepath.Join(directory, "css", "site.css")); err != nil {
+					t.Fatalf("removing file: %+v", err)
+				}
+			},
+			Config: r.complete(data, directory),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("files.%").HasValue("3"),
+			),
+		},
+		{
+			Config: r.basic(data, directory),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("files.%").HasValue("3"),
+			),
+		},
+	})
+}
+
+func TestAccStorageBlobDirectorySync_deleteExtraneousBlobs(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory_sync", "test")
+	r := StorageBlobDirectorySyncResource{}
+	directory := r.sourceDirectory(t, map[string]string{
+		"index.html": "<html></html>",
+	})
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.deleteExtraneousBlobs(data, directory),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				// the Blob created outside of the directory is removed
+				check.That(data.ResourceName).Key("files.%").HasValue("1"),
+			),
+		},
+	})
+}
+
+func (r StorageBlobDirectorySyncResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.StorageBlobDirectorySyncID(state.ID, client.Storage.StorageDomainSuffix)
+	if err != nil {
+		return nil, err
+	}
+	account, err := client.Storage.FindAccount(ctx, client.Account.SubscriptionId, id.AccountId.AccountName)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, fmt.Errorf("unable to locate Account %q for %s", id.AccountId.AccountName, id)
+	}
+	containersClient, err := client.Storage.ContainersDataPlaneClient(ctx, *account, client.Storage.DataPlaneOperationSupportingAnyAuthMethod())
+	if err != nil {
+		return nil, fmt.Errorf("building Containers Client: %+v", err)
+	}
+	blobList, err := containersClient.ListBlobs(ctx, id.ContainerName, id.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("listing the Blobs for %s: %+v", id, err)
+	}
+	return pointer.To(len(pointer.From(blobList)) > 0), nil
+}
+
+func (r StorageBlobDirectorySyncResource) sourceDirectory(t *testing.T, files map[string]string) string {
+	directory := t.TempDir()
+	r.writeFiles(t, directory, files)
+	return directory
+}
+
+func (r StorageBlobDirectorySyncResource) writeFiles(t *testing.T, directory string, files map[string]string) {
+	for name, contents := range files {
+		filePath := filepath.Join(directory, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+			t.Fatalf("creating the directory for %q: %+v", name, err)
+		}
+		if err := os.WriteFile(filePath, []byte(contents), 0o644); err != nil {
+			t.Fatalf("writing %q: %+v", name, err)
+		}
+	}
+}
+
+func (r StorageBlobDirectorySyncResource) basic(data acceptance.TestData, directory string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_blob_directory_sync" "test" {
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  source_directory       = %q
+  prefix                 = "site/"
+}
+`, r.template(data), directory)
+}
+
+func (r StorageBlobDirectorySyncResource) complete(data acceptance.TestData, directory string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_blob_directory_sync" "test" {
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  source_directory       = %q
+  prefix                 = "site/"
+  cache_control          = "max-age=3600"
+  parallelism            = 2
+
+  content_types = {
+    ".ex" = "text/plain"
+  }
+}
+`, r.template(data), directory)
+}
+
+func (r StorageBlobDirectorySyncResource) deleteExtraneousBlobs(data acceptance.TestData, directory string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_blob" "extraneous" {
+  name                   = "site/extraneous.txt"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "Block"
+  source_content         = "extraneous"
+
+  lifecycle {
+    ignore_changes = all
+  }
+}
+
+resource "azurerm_storage_blob_directory_sync" "test" {
+  storage_account_name    = azurerm_storage_account.test.name
+  storage_container_name  = azurerm_storage_container.test.name
+  source_directory        = %q
+  prefix                  = "site/"
+  delete_extraneous_blobs = true
+
+  depends_on = [azurerm_storage_blob.extraneous]
+}
+`, r.template(data), directory)
+}
+
+func (r StorageBlobDirectorySyncResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "test"
+  storage_account_name  = azurerm_storage_account.test.name
+  container_access_type = "private"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/suppress"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/accounts"
@@ -152,7 +153,6 @@ func resourceStorageBlob() *pluginsdk.Resource {
 			},
 
 			"parallelism": {
-				// TODO: @tombuildsstuff - a note this only works for Page blobs
 				Type:         pluginsdk.TypeInt,
 				Optional:     true,
 				Default:      8,
@@ -160,6 +160,45 @@ func resourceStorageBlob() *pluginsdk.Resource {
 				ValidateFunc: validation.IntAtLeast(1),
 			},
 
+			"block_size_in_mb": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(1, 4000),
+			},
+
+			"immutability_policy": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"expiry_time": {
+							Type:             pluginsdk.TypeString,
+							Required:         true,
+							ValidateFunc:     validation.IsRFC3339Time,
+							DiffSuppressFunc: suppress.RFC3339Time,
+						},
+
+						"mode": {
+							Type:     pluginsdk.TypeString,
+							Optional: true,
+							Default:  BlobImmutabilityPolicyModeUnlocked,
+							ValidateFunc: validation.StringInSlice([]string{
+								BlobImmutabilityPolicyModeLocked,
+								BlobImmutabilityPolicyModeUnlocked,
+							}, false),
+						},
+					},
+				},
+			},
+
+			"legal_hold_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"metadata": MetaDataComputedSchema(),
 		},
 
@@ -169,6 +208,23 @@ func resourceStorageBlob() *pluginsdk.Resource {
 					return fmt.Errorf(`"source" must be aligned to 512-byte boundary for "type" set to "Page"`)
 				}
 			}
+			if blockSize := diff.Get("block_size_in_mb").(int); blockSize > 4 && diff.Get("type") == "Page" {
+				return fmt.Errorf(`"block_size_in_mb" cannot exceed 4 for "type" set to "Page"`)
+			}
+			if diff.HasChange("immutability_policy") {
+				oldRaw, newRaw := diff.GetChange("immutability_policy")
+				oldPolicy, _ := expandStorageBlobImmutabilityPolicy(oldRaw.([]interface{}))
+				if oldPolicy != nil && oldPolicy.Mode == BlobImmutabilityPolicyModeLocked {
+					// the expiry time may not be known until apply
+					newPolicy, err := expandStorageBlobImmutabilityPolicy(newRaw.([]interface{}))
+					if err == nil && (newPolicy == nil || newPolicy.Mode != BlobImmutabilityPolicyModeLocked) {
+						return fmt.Errorf("a Locked `immutability_policy` cannot be removed or unlocked")
+					}
+					if err == nil && newPolicy.ExpiryTime.Before(oldPolicy.ExpiryTime) {
+						return fmt.Errorf("the `expiry_time` of a Locked `immutability_policy` can only be extended")
+					}
+				}
+			}
 			return nil
 		},
 	}
@@ -240,6 +296,7 @@ func resourceStorageBlobCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 		ContentType:   d.Get("content_type").(string),
 		ContentMD5:    contentMD5,
 		MetaData:      ExpandMetaData(metaDataRaw),
+		BlockSize:     int64(d.Get("block_size_in_mb").(int)) * 1024 * 1024,
 		Parallelism:   d.Get("parallelism").(int),
 		Size:          d.Get("size").(int),
 		Source:        d.Get("source").(string),
@@ -347,6 +404,34 @@ func resourceStorageBlobUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		log.Printf("[DEBUG] Updated Access Tier for %s", id)
 	}
 
+	// the Immutability Policy and Legal Hold are updated last, since these prevent further changes to the Blob
+	if d.HasChange("immutability_policy") {
+		log.Printf("[DEBUG] Updating Immutability Policy for %s...", id)
+		policy, err := expandStorageBlobImmutabilityPolicy(d.Get("immutability_policy").([]interface{}))
+		if err != nil {
+			return err
+		}
+
+		if policy == nil {
+			if err := DeleteBlobImmutabilityPolicy(ctx, blobsClient, id.ContainerName, id.BlobName); err != nil {
+				return fmt.Errorf("deleting Immutability Policy for %s: %v", id, err)
+			}
+		} else {
+			if err := SetBlobImmutabilityPolicy(ctx, blobsClient, id.ContainerName, id.BlobName, *policy); err != nil {
+				return fmt.Errorf("updating Immutability Policy for %s: %v", id, err)
+			}
+		}
+		log.Printf("[DEBUG] Updated Immutability Policy for %s", id)
+	}
+
+	if d.HasChange("legal_hold_enabled") {
+		log.Printf("[DEBUG] Updating Legal Hold for %s...", id)
+		if err := SetBlobLegalHold(ctx, blobsClient, id.ContainerName, id.BlobName, d.Get("legal_hold_enabled").(bool)); err != nil {
+			return fmt.Errorf("updating Legal Hold for %s: %v", id, err)
+		}
+		log.Printf("[DEBUG] Updated Legal Hold for %s", id)
+	}
+
 	return resourceStorageBlobRead(d, meta)
 }
 
@@ -415,6 +500,16 @@ func resourceStorageBlobRead(d *pluginsdk.ResourceData, meta interface{}) error
 	if err = d.Set("metadata", FlattenMetaData(props.MetaData)); err != nil {
 		return fmt.Errorf("setting `metadata`: %v", err)
 	}
+
+	immutabilityPolicy, legalHold, err := BlobImmutability(props.HttpResponse)
+	if err != nil {
+		return fmt.Errorf("parsing the Immutability Policy for %s: %v", id, err)
+	}
+	if err = d.Set("immutability_policy", flattenStorageBlobImmutabilityPolicy(immutabilityPolicy)); err != nil {
+		return fmt.Errorf("setting `immutability_policy`: %v", err)
+	}
+	d.Set("legal_hold_enabled", legalHold)
+
 	// The CopySource is only returned if the blob hasn't been modified (e.g. metadata configured etc)
 	// as such, we need to conditionally set this to ensure it's trackable if possible
 	if props.CopySource != "" {
@@ -448,6 +543,29 @@ func resourceStorageBlobDelete(d *pluginsdk.ResourceData, meta interface{}) erro
 		return fmt.Errorf("building Blobs Client: %v", err)
 	}
 
+	// an Unlocked Immutability Policy and a Legal Hold both prevent the Blob from being deleted, so need to be removed first
+	props, err := blobsClient.GetProperties(ctx, id.ContainerName, id.BlobName, blobs.GetPropertiesInput{})
+	if err != nil {
+		if response.WasNotFound(props.HttpResponse) {
+			return nil
+		}
+		return fmt.Errorf("retrieving properties for %s: %v", id, err)
+	}
+	immutabilityPolicy, legalHold, err := BlobImmutability(props.HttpResponse)
+	if err != nil {
+		return fmt.Errorf("parsing the Immutability Policy for %s: %v", id, err)
+	}
+	if legalHold {
+		if err := SetBlobLegalHold(ctx, blobsClient, id.ContainerName, id.BlobName, false); err != nil {
+			return fmt.Errorf("removing Legal Hold for %s: %v", id, err)
+		}
+	}
+	if immutabilityPolicy != nil && immutabilityPolicy.Mode == BlobImmutabilityPolicyModeUnlocked {
+		if err := DeleteBlobImmutabilityPolicy(ctx, blobsClient, id.ContainerName, id.BlobName); err != nil {
+			return fmt.Errorf("deleting Immutability Policy for %s: %v", id, err)
+		}
+	}
+
 	input := blobs.DeleteInput{
 		DeleteSnapshots: true,
 	}
@@ -457,3 +575,33 @@ func resourceStorageBlobDelete(d *pluginsdk.ResourceData, meta interface{}) erro
 
 	return nil
 }
+
+func expandStorageBlobImmutabilityPolicy(input []interface{}) (*BlobImmutabilityPolicy, error) {
+	if len(input) == 0 || input[0] == nil {
+		return nil, nil
+	}
+	v := input[0].(map[string]interface{})
+
+	expiryTime, err := time.Parse(time.RFC3339, v["expiry_time"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("parsing `expiry_time`: %+v", err)
+	}
+
+	return &BlobImmutabilityPolicy{
+		ExpiryTime: expiryTime,
+		Mode:       v["mode"].(string),
+	}, nil
+}
+
+func flattenStorageBlobImmutabilityPolicy(input *BlobImmutabilityPolicy) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"expiry_time": input.ExpiryTime.Format(time.RFC3339),
+			"mode":        input.Mode,
+		},
+	}
+}
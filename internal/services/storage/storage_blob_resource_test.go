@@ -244,6 +244,30 @@ func TestAccStorageBlob_blockFromLocalFile(t *testing.T) {
 	})
 }
 
+func TestAccStorageBlob_blockFromLocalFileWithBlockSize(t *testing.T) {
+	sourceBlob, err := os.CreateTemp("", "")
+	if err != nil {
+		t.Fatalf("Failed to create local source blob file")
+	}
+
+	if err := populateTempFile(sourceBlob); err != nil {
+		t.Fatalf("Error populating temp file: %s", err)
+	}
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob", "test")
+	r := StorageBlobResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.fromLocalBlobWithBlockSize(data, sourceBlob.Name(), "Block", 1),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClient(r.blobMatchesFile(blobs.BlockBlob, sourceBlob.Name())),
+			),
+		},
+		data.ImportStep("block_size_in_mb", "parallelism", "size", "source", "type"),
+	})
+}
+
 func TestAccStorageBlob_blockFromLocalFileWithContentMd5(t *testing.T) {
 	sourceBlob, err := os.CreateTemp("", "")
 	if err != nil {
@@ -291,6 +315,31 @@ func TestAccStorageBlob_cacheControl(t *testing.T) {
 	})
 }
 
+func TestAccStorageBlob_immutability(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob", "test")
+	r := StorageBlobResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.immutability(data, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("legal_hold_enabled").HasValue("true"),
+				check.That(data.ResourceName).Key("immutability_policy.0.mode").HasValue("Unlocked"),
+			),
+		},
+		data.ImportStep("parallelism", "size", "type"),
+		{
+			Config: r.immutability(data, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("legal_hold_enabled").HasValue("false"),
+			),
+		},
+		data.ImportStep("parallelism", "size", "type"),
+	})
+}
+
 func TestAccStorageBlob_contentType(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_storage_blob", "test")
 	r := StorageBlobResource{}
@@ -465,6 +514,30 @@ func TestAccStorageBlob_pageFromLocalFile(t *testing.T) {
 	})
 }
 
+func TestAccStorageBlob_pageFromLocalFileWithBlockSize(t *testing.T) {
+	sourceBlob, err := os.CreateTemp("", "")
+	if err != nil {
+		t.Fatalf("Failed to create local source blob file")
+	}
+
+	if err := populateTempFile(sourceBlob); err != nil {
+		t.Fatalf("Error populating temp file: %s", err)
+	}
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob", "test")
+	r := StorageBlobResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.fromLocalBlobWithBlockSize(data, sourceBlob.Name(), "Page", 2),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClient(r.blobMatchesFile(blobs.PageBlob, sourceBlob.Name())),
+			),
+		},
+		data.ImportStep("block_size_in_mb", "parallelism", "size", "source", "type"),
+	})
+}
+
 func TestAccStorageBlob_pageFromInlineContent(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_storage_blob", "test")
 	r := StorageBlobResource{}
@@ -918,6 +991,27 @@ resource "azurerm_storage_blob" "test" {
 `, template, fileName)
 }
 
+func (r StorageBlobResource) fromLocalBlobWithBlockSize(data acceptance.TestData, fileName string, blobType string, blockSize int) string {
+	template := r.template(data, "private")
+	return fmt.Sprintf(`
+%s
+
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_storage_blob" "test" {
+  name                   = "example.vhd"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "%s"
+  source                 = "%s"
+  block_size_in_mb       = %d
+  parallelism            = 4
+}
+`, template, blobType, fileName, blockSize)
+}
+
 func (r StorageBlobResource) contentMd5ForLocalFile(data acceptance.TestData, fileName string) string {
 	template := r.template(data, "blob")
 	return fmt.Sprintf(`
@@ -1338,6 +1432,66 @@ resource "azurerm_storage_blob" "test" {
 `, template, cacheControl)
 }
 
+func (r StorageBlobResource) immutability(data acceptance.TestData, enabled bool) string {
+	immutabilityPolicy := ""
+	if enabled {
+		immutabilityPolicy = `
+  immutability_policy {
+    expiry_time = timeadd(timestamp(), "24h")
+  }
+
+  lifecycle {
+    ignore_changes = [immutability_policy.0.expiry_time]
+  }
+`
+	}
+
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  blob_properties {
+    versioning_enabled = true
+  }
+
+  immutability_policy {
+    period_since_creation_in_days = 1
+    state                         = "Disabled"
+    allow_protected_append_writes = false
+  }
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "test"
+  storage_account_name  = azurerm_storage_account.test.name
+  container_access_type = "private"
+}
+
+resource "azurerm_storage_blob" "test" {
+  name                   = "example.txt"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "Block"
+  source_content         = "Hello World"
+  legal_hold_enabled     = %t
+%s
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, enabled, immutabilityPolicy)
+}
+
 func (r StorageBlobResource) template(data acceptance.TestData, accessLevel string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "test" {
@@ -0,0 +1,316 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/blobcontainers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type StorageContainerImmutabilityPolicyResource struct{}
+
+var (
+	_ sdk.ResourceWithUpdate        = StorageContainerImmutabilityPolicyResource{}
+	_ sdk.ResourceWithCustomizeDiff = StorageContainerImmutabilityPolicyResource{}
+)
+
+type StorageContainerImmutabilityPolicyModel struct {
+	StorageContainerResourceManagerId string `tfschema:"storage_container_resource_manager_id"`
+	ImmutabilityPeriodInDays          int64  `tfschema:"immutability_period_in_days"`
+	Locked                            bool   `tfschema:"locked"`
+	ProtectedAppendWritesAllEnabled   bool   `tfschema:"protected_append_writes_all_enabled"`
+	ProtectedAppendWritesEnabled      bool   `tfschema:"protected_append_writes_enabled"`
+}
+
+func (r StorageContainerImmutabilityPolicyResource) ResourceType() string {
+	return "azurerm_storage_container_immutability_policy"
+}
+
+func (r StorageContainerImmutabilityPolicyResource) ModelObject() interface{} {
+	return &StorageContainerImmutabilityPolicyModel{}
+}
+
+func (r StorageContainerImmutabilityPolicyResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.StorageContainerImmutabilityPolicyID
+}
+
+func (r StorageContainerImmutabilityPolicyResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"storage_container_resource_manager_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: commonids.ValidateStorageContainerID,
+		},
+
+		"immutability_period_in_days": {
+			Type:         pluginsdk.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntBetween(1, 146000),
+		},
+
+		"locked": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"protected_append_writes_all_enabled": {
+			Type:          pluginsdk.TypeBool,
+			Optional:      true,
+			Default:       false,
+			ConflictsWith: []string{"protected_append_writes_enabled"},
+		},
+
+		"protected_append_writes_enabled": {
+			Type:          pluginsdk.TypeBool,
+			Optional:      true,
+			Default:       false,
+			ConflictsWith: []string{"protected_append_writes_all_enabled"},
+		},
+	}
+}
+
+func (r StorageContainerImmutabilityPolicyResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r StorageContainerImmutabilityPolicyResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			diff := metadata.ResourceDiff
+
+			// once Locked, an Immutability Policy can't be unlocked and the retention period can only be extended
+			if oldLocked, _ := diff.GetChange("locked"); oldLocked.(bool) {
+				if !diff.Get("locked").(bool) {
+					return fmt.Errorf("a Locked Immutability Policy cannot be unlocked")
+				}
+
+				oldPeriod, newPeriod := diff.GetChange("immutability_period_in_days")
+				if newPeriod.(int) < oldPeriod.(int) {
+					return fmt.Errorf("the `immutability_period_in_days` of a Locked Immutability Policy can only be extended")
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r StorageContainerImmutabilityPolicyResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.ResourceManager.BlobContainers
+
+			var config StorageContainerImmutabilityPolicyModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			containerId, err := commonids.ParseStorageContainerID(config.StorageContainerResourceManagerId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewStorageContainerImmutabilityPolicyID(containerId.SubscriptionId, containerId.ResourceGroupName, containerId.StorageAccountName, "default", containerId.ContainerName, "default")
+
+			existing, err := client.Get(ctx, *containerId)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", containerId, err)
+			}
+			if existing.Model != nil && existing.Model.Properties != nil && pointer.From(existing.Model.Properties.HasImmutabilityPolicy) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			payload := blobcontainers.ImmutabilityPolicy{
+				Properties: r.expandProperties(config),
+			}
+			resp, err := client.CreateOrUpdateImmutabilityPolicy(ctx, *containerId, payload, blobcontainers.CreateOrUpdateImmutabilityPolicyOperationOptions{})
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			if config.Locked {
+				if resp.Model == nil || resp.Model.Etag == nil {
+					return fmt.Errorf("retrieving the ETag for %s: `model.etag` was nil", id)
+				}
+
+				if _, err := client.LockImmutabilityPolicy(ctx, *containerId, blobcontainers.LockImmutabilityPolicyOperationOptions{IfMatch: resp.Model.Etag}); err != nil {
+					return fmt.Errorf("locking %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r StorageContainerImmutabilityPolicyResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.ResourceManager.BlobContainers
+
+			id, err := parse.StorageContainerImmutabilityPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			containerId := commonids.NewStorageContainerID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName, id.ContainerName)
+
+			container, err := client.Get(ctx, containerId)
+			if err != nil {
+				if response.WasNotFound(container.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", containerId, err)
+			}
+			if container.Model == nil || container.Model.Properties == nil || !pointer.From(container.Model.Properties.HasImmutabilityPolicy) {
+				return metadata.MarkAsGone(id)
+			}
+
+			resp, err := client.GetImmutabilityPolicy(ctx, containerId, blobcontainers.GetImmutabilityPolicyOperationOptions{})
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			state := StorageContainerImmutabilityPolicyModel{
+				StorageContainerResourceManagerId: containerId.ID(),
+			}
+
+			if model := resp.Model; model != nil {
+				props := model.Properties
+				state.ImmutabilityPeriodInDays = pointer.From(props.ImmutabilityPeriodSinceCreationInDays)
+				state.Locked = pointer.From(props.State) == blobcontainers.ImmutabilityPolicyStateLocked
+				state.ProtectedAppendWritesAllEnabled = pointer.From(props.AllowProtectedAppendWritesAll)
+				state.ProtectedAppendWritesEnabled = pointer.From(props.AllowProtectedAppendWrites)
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r StorageContainerImmutabilityPolicyResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.ResourceManager.BlobContainers
+
+			id, err := parse.StorageContainerImmutabilityPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config StorageContainerImmutabilityPolicyModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			containerId := commonids.NewStorageContainerID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName, id.ContainerName)
+
+			existing, err := client.GetImmutabilityPolicy(ctx, containerId, blobcontainers.GetImmutabilityPolicyOperationOptions{})
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if existing.Model == nil || existing.Model.Etag == nil {
+				return fmt.Errorf("retrieving the ETag for %s: `model.etag` was nil", id)
+			}
+			etag := existing.Model.Etag
+
+			payload := blobcontainers.ImmutabilityPolicy{
+				Properties: r.expandProperties(config),
+			}
+
+			// a Locked Immutability Policy can only be extended
+			if pointer.From(existing.Model.Properties.State) == blobcontainers.ImmutabilityPolicyStateLocked {
+				if _, err := client.ExtendImmutabilityPolicy(ctx, containerId, payload, blobcontainers.ExtendImmutabilityPolicyOperationOptions{IfMatch: etag}); err != nil {
+					return fmt.Errorf("extending %s: %+v", id, err)
+				}
+				return nil
+			}
+
+			if metadata.ResourceData.HasChanges("immutability_period_in_days", "protected_append_writes_all_enabled", "protected_append_writes_enabled") {
+				resp, err := client.CreateOrUpdateImmutabilityPolicy(ctx, containerId, payload, blobcontainers.CreateOrUpdateImmutabilityPolicyOperationOptions{IfMatch: etag})
+				if err != nil {
+					return fmt.Errorf("updating %s: %+v", id, err)
+				}
+				if resp.Model == nil || resp.Model.Etag == nil {
+					return fmt.Errorf("retrieving the ETag for %s: `model.etag` was nil", id)
+				}
+				etag = resp.Model.Etag
+			}
+
+			if config.Locked {
+				if _, err := client.LockImmutabilityPolicy(ctx, containerId, blobcontainers.LockImmutabilityPolicyOperationOptions{IfMatch: etag}); err != nil {
+					return fmt.Errorf("locking %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r StorageContainerImmutabilityPolicyResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.ResourceManager.BlobContainers
+
+			id, err := parse.StorageContainerImmutabilityPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			containerId := commonids.NewStorageContainerID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName, id.ContainerName)
+
+			existing, err := client.GetImmutabilityPolicy(ctx, containerId, blobcontainers.GetImmutabilityPolicyOperationOptions{})
+			if err != nil {
+				if response.WasNotFound(existing.HttpResponse) {
+					return nil
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if existing.Model == nil || existing.Model.Etag == nil {
+				return fmt.Errorf("retrieving the ETag for %s: `model.etag` was nil", id)
+			}
+			if pointer.From(existing.Model.Properties.State) == blobcontainers.ImmutabilityPolicyStateLocked {
+				return fmt.Errorf("deleting %s: a Locked Immutability Policy cannot be deleted", id)
+			}
+
+			if _, err := client.DeleteImmutabilityPolicy(ctx, containerId, blobcontainers.DeleteImmutabilityPolicyOperationOptions{IfMatch: existing.Model.Etag}); err != nil {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r StorageContainerImmutabilityPolicyResource) expandProperties(input StorageContainerImmutabilityPolicyModel) blobcontainers.ImmutabilityPolicyProperty {
+	return blobcontainers.ImmutabilityPolicyProperty{
+		AllowProtectedAppendWrites:            pointer.To(input.ProtectedAppendWritesEnabled),
+		AllowProtectedAppendWritesAll:         pointer.To(input.ProtectedAppendWritesAllEnabled),
+		ImmutabilityPeriodSinceCreationInDays: pointer.To(input.ImmutabilityPeriodInDays),
+	}
+}
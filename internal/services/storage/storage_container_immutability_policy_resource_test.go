@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type StorageContainerImmutabilityPolicyResource struct{}
+
+func TestAccStorageContainerImmutabilityPolicy_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_container_immutability_policy", "test")
+	r := StorageContainerImmutabilityPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccStorageContainerImmutabilityPolicy_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_container_immutability_policy", "test")
+	r := StorageContainerImmutabilityPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccStorageContainerImmutabilityPolicy_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_container_immutability_policy", "test")
+	r := StorageContainerImmutabilityPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r StorageContainerImmutabilityPolicyResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.StorageContainerImmutabilityPolicyID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	containerId := commonids.NewStorageContainerID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName, id.ContainerName)
+	resp, err := clients.Storage.ResourceManager.BlobContainers.Get(ctx, containerId)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", containerId, err)
+	}
+
+	return utils.Bool(resp.Model != nil && resp.Model.Properties != nil && pointer.From(resp.Model.Properties.HasImmutabilityPolicy)), nil
+}
+
+func (r StorageContainerImmutabilityPolicyResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_container_immutability_policy" "test" {
+  storage_container_resource_manager_id = azurerm_storage_container.test.resource_manager_id
+  immutability_period_in_days           = 1
+}
+`, r.template(data))
+}
+
+func (r StorageContainerImmutabilityPolicyResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_container_immutability_policy" "import" {
+  storage_container_resource_manager_id = azurerm_storage_container_immutability_policy.test.storage_container_resource_manager_id
+  immutability_period_in_days           = azurerm_storage_container_immutability_policy.test.immutability_period_in_days
+}
+`, r.basic(data))
+}
+
+func (r StorageContainerImmutabilityPolicyResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_container_immutability_policy" "test" {
+  storage_container_resource_manager_id = azurerm_storage_container.test.resource_manager_id
+  immutability_period_in_days           = 2
+  protected_append_writes_all_enabled   = true
+}
+`, r.template(data))
+}
+
+func (r StorageContainerImmutabilityPolicyResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "acctestcontainer"
+  storage_account_name  = azurerm_storage_account.test.name
+  container_access_type = "private"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
@@ -0,0 +1,239 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/blobcontainers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type StorageContainerLegalHoldResource struct{}
+
+var _ sdk.ResourceWithUpdate = StorageContainerLegalHoldResource{}
+
+type StorageContainerLegalHoldModel struct {
+	StorageContainerResourceManagerId string   `tfschema:"storage_container_resource_manager_id"`
+	Tags                              []string `tfschema:"tags"`
+	ProtectedAppendWritesAllEnabled   bool     `tfschema:"protected_append_writes_all_enabled"`
+}
+
+func (r StorageContainerLegalHoldResource) ResourceType() string {
+	return "azurerm_storage_container_legal_hold"
+}
+
+func (r StorageContainerLegalHoldResource) ModelObject() interface{} {
+	return &StorageContainerLegalHoldModel{}
+}
+
+func (r StorageContainerLegalHoldResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.StorageContainerLegalHoldID
+}
+
+func (r StorageContainerLegalHoldResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"storage_container_resource_manager_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: commonids.ValidateStorageContainerID,
+		},
+
+		"tags": {
+			Type:     pluginsdk.TypeSet,
+			Required: true,
+			MinItems: 1,
+			MaxItems: 10,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^[a-z0-9]{3,23}$`),
+					"each tag must be between 3 and 23 lowercase alphanumeric characters",
+				),
+			},
+		},
+
+		"protected_append_writes_all_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	}
+}
+
+func (r StorageContainerLegalHoldResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r StorageContainerLegalHoldResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.ResourceManager.BlobContainers
+
+			var config StorageContainerLegalHoldModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			containerId, err := commonids.ParseStorageContainerID(config.StorageContainerResourceManagerId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewStorageContainerLegalHoldID(containerId.SubscriptionId, containerId.ResourceGroupName, containerId.StorageAccountName, "default", containerId.ContainerName, "default")
+
+			existing, err := client.Get(ctx, *containerId)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", containerId, err)
+			}
+			if existing.Model != nil && existing.Model.Properties != nil && pointer.From(existing.Model.Properties.HasLegalHold) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			payload := blobcontainers.LegalHold{
+				AllowProtectedAppendWritesAll: pointer.To(config.ProtectedAppendWritesAllEnabled),
+				Tags:                          config.Tags,
+			}
+			if _, err := client.SetLegalHold(ctx, *containerId, payload); err != nil {
+				return fmt.Errorf("setting %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r StorageContainerLegalHoldResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.ResourceManager.BlobContainers
+
+			id, err := parse.StorageContainerLegalHoldID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			containerId := commonids.NewStorageContainerID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName, id.ContainerName)
+
+			resp, err := client.Get(ctx, containerId)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", containerId, err)
+			}
+
+			if resp.Model == nil || resp.Model.Properties == nil || !pointer.From(resp.Model.Properties.HasLegalHold) {
+				return metadata.MarkAsGone(id)
+			}
+
+			state := StorageContainerLegalHoldModel{
+				StorageContainerResourceManagerId: containerId.ID(),
+				Tags:                              make([]string, 0),
+			}
+
+			if legalHold := resp.Model.Properties.LegalHold; legalHold != nil {
+				for _, tag := range pointer.From(legalHold.Tags) {
+					if tag.Tag != nil {
+						state.Tags = append(state.Tags, *tag.Tag)
+					}
+				}
+				if history := legalHold.ProtectedAppendWritesHistory; history != nil {
+					state.ProtectedAppendWritesAllEnabled = pointer.From(history.AllowProtectedAppendWritesAll)
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r StorageContainerLegalHoldResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.ResourceManager.BlobContainers
+
+			id, err := parse.StorageContainerLegalHoldID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			containerId := commonids.NewStorageContainerID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName, id.ContainerName)
+
+			var config StorageContainerLegalHoldModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// setting the Legal Hold adds the specified tags (and updates `protected_append_writes_all_enabled`), as such
+			// any tags which have been removed need to be cleared separately - which is done last so that at least one
+			// tag remains on the Container throughout
+			payload := blobcontainers.LegalHold{
+				AllowProtectedAppendWritesAll: pointer.To(config.ProtectedAppendWritesAllEnabled),
+				Tags:                          config.Tags,
+			}
+			if _, err := client.SetLegalHold(ctx, containerId, payload); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			if metadata.ResourceData.HasChange("tags") {
+				oldRaw, newRaw := metadata.ResourceData.GetChange("tags")
+				removed := make([]string, 0)
+				for _, tag := range oldRaw.(*pluginsdk.Set).Difference(newRaw.(*pluginsdk.Set)).List() {
+					removed = append(removed, tag.(string))
+				}
+
+				if len(removed) > 0 {
+					if _, err := client.ClearLegalHold(ctx, containerId, blobcontainers.LegalHold{Tags: removed}); err != nil {
+						return fmt.Errorf("clearing Legal Hold tags %v for %s: %+v", removed, id, err)
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r StorageContainerLegalHoldResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.ResourceManager.BlobContainers
+
+			id, err := parse.StorageContainerLegalHoldID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			containerId := commonids.NewStorageContainerID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName, id.ContainerName)
+
+			var state StorageContainerLegalHoldModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if _, err := client.ClearLegalHold(ctx, containerId, blobcontainers.LegalHold{Tags: state.Tags}); err != nil {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
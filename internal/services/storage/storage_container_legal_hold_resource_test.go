@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type StorageContainerLegalHoldResource struct{}
+
+func TestAccStorageContainerLegalHold_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_container_legal_hold", "test")
+	r := StorageContainerLegalHoldResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccStorageContainerLegalHold_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_container_legal_hold", "test")
+	r := StorageContainerLegalHoldResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccStorageContainerLegalHold_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_container_legal_hold", "test")
+	r := StorageContainerLegalHoldResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r StorageContainerLegalHoldResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.StorageContainerLegalHoldID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	containerId := commonids.NewStorageContainerID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName, id.ContainerName)
+	resp, err := clients.Storage.ResourceManager.BlobContainers.Get(ctx, containerId)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return utils.Bool(resp.Model != nil && resp.Model.Properties != nil && pointer.From(resp.Model.Properties.HasLegalHold)), nil
+}
+
+func (r StorageContainerLegalHoldResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_container_legal_hold" "test" {
+  storage_container_resource_manager_id = azurerm_storage_container.test.resource_manager_id
+  tags                                  = ["litigation"]
+}
+`, StorageContainerImmutabilityPolicyResource{}.template(data))
+}
+
+func (r StorageContainerLegalHoldResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_container_legal_hold" "import" {
+  storage_container_resource_manager_id = azurerm_storage_container_legal_hold.test.storage_container_resource_manager_id
+  tags                                  = azurerm_storage_container_legal_hold.test.tags
+}
+`, r.basic(data))
+}
+
+func (r StorageContainerLegalHoldResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_container_legal_hold" "test" {
+  storage_container_resource_manager_id = azurerm_storage_container.test.resource_manager_id
+  tags                                  = ["litigation", "audit2024"]
+  protected_append_writes_all_enabled   = true
+}
+`, StorageContainerImmutabilityPolicyResource{}.template(data))
+}
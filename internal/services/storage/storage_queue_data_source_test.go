@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type dataSourceStorageQueue struct{}
+
+func TestAccDataSourceStorageQueue_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_queue", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: dataSourceStorageQueue{}.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("metadata.%").HasValue("1"),
+				check.That(data.ResourceName).Key("metadata.hello").HasValue("world"),
+				check.That(data.ResourceName).Key("acl.#").HasValue("1"),
+				check.That(data.ResourceName).Key("acl.0.access_policy.0.permissions").HasValue("raup"),
+				check.That(data.ResourceName).Key("resource_manager_id").Exists(),
+			),
+		},
+	})
+}
+
+func (d dataSourceStorageQueue) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_queue" "test" {
+  name                 = "acctestqueue-%d"
+  storage_account_name = azurerm_storage_account.test.name
+
+  metadata = {
+    hello = "world"
+  }
+
+  acl {
+    id = "MTIzNDU2Nzg5MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTI"
+
+    access_policy {
+      permissions = "raup"
+      start       = "2020-11-26T08:49:37.0000000Z"
+      expiry      = "2020-11-27T08:49:37.0000000Z"
+    }
+  }
+}
+
+data "azurerm_storage_queue" "test" {
+  name                 = azurerm_storage_queue.test.name
+  storage_account_name = azurerm_storage_queue.test.storage_account_name
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, data.RandomInteger)
+}
@@ -14,8 +14,10 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/helpers"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/shim"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/accounts"
 	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/queue/queues"
@@ -62,6 +64,44 @@ func resourceStorageQueue() *pluginsdk.Resource {
 
 			"metadata": MetaDataSchema(),
 
+			"acl": {
+				Type:     pluginsdk.TypeSet,
+				Optional: true,
+				MaxItems: 5,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"id": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 64),
+						},
+						"access_policy": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"start": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+									"expiry": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+									"permissions": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
 			"resource_manager_id": {
 				Type:     pluginsdk.TypeString,
 				Computed: true,
@@ -82,6 +122,9 @@ func resourceStorageQueueCreate(d *pluginsdk.ResourceData, meta interface{}) err
 	metaDataRaw := d.Get("metadata").(map[string]interface{})
 	metaData := ExpandMetaData(metaDataRaw)
 
+	aclsRaw := d.Get("acl").(*pluginsdk.Set).List()
+	acls := expandStorageQueueACLs(aclsRaw)
+
 	account, err := storageClient.FindAccount(ctx, subscriptionId, accountName)
 	if err != nil {
 		return fmt.Errorf("retrieving Account %q for Queue %q: %v", accountName, queueName, err)
@@ -123,6 +166,18 @@ func resourceStorageQueueCreate(d *pluginsdk.ResourceData, meta interface{}) err
 
 	d.SetId(id)
 
+	if len(acls) > 0 {
+		// Setting ACLs only supports shared key authentication
+		aclClient, err := storageClient.QueuesDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingOnlySharedKeyAuth())
+		if err != nil {
+			return fmt.Errorf("building Queues Client: %v", err)
+		}
+
+		if err = aclClient.UpdateACLs(ctx, queueName, acls); err != nil {
+			return fmt.Errorf("setting ACLs for %s: %v", id, err)
+		}
+	}
+
 	return resourceStorageQueueRead(d, meta)
 }
 
@@ -153,8 +208,29 @@ func resourceStorageQueueUpdate(d *pluginsdk.ResourceData, meta interface{}) err
 		return fmt.Errorf("building Queues Client: %v", err)
 	}
 
-	if err = client.UpdateMetaData(ctx, id.QueueName, metaData); err != nil {
-		return fmt.Errorf("updating MetaData for %s: %v", id, err)
+	if d.HasChange("metadata") {
+		if err = client.UpdateMetaData(ctx, id.QueueName, metaData); err != nil {
+			return fmt.Errorf("updating MetaData for %s: %v", id, err)
+		}
+	}
+
+	if d.HasChange("acl") {
+		log.Printf("[DEBUG] Updating ACLs for %s", id)
+
+		aclsRaw := d.Get("acl").(*pluginsdk.Set).List()
+		acls := expandStorageQueueACLs(aclsRaw)
+
+		// Setting ACLs only supports shared key authentication
+		aclClient, err := storageClient.QueuesDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingOnlySharedKeyAuth())
+		if err != nil {
+			return fmt.Errorf("building Queues Client: %v", err)
+		}
+
+		if err = aclClient.UpdateACLs(ctx, id.QueueName, acls); err != nil {
+			return fmt.Errorf("updating ACLs for %s: %v", id, err)
+		}
+
+		log.Printf("[DEBUG] Updated ACLs for %s", id)
 	}
 
 	return resourceStorageQueueRead(d, meta)
@@ -203,6 +279,26 @@ func resourceStorageQueueRead(d *pluginsdk.ResourceData, meta interface{}) error
 		return fmt.Errorf("setting `metadata`: %s", err)
 	}
 
+	// Retrieving ACLs only supports shared key authentication, as such when Shared Key access is disabled on the
+	// Storage Account the ACLs can't be retrieved and the existing value in the state is retained
+	if account.SharedKeyAccessEnabled {
+		aclClient, err := storageClient.QueuesDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingOnlySharedKeyAuth())
+		if err != nil {
+			return fmt.Errorf("building Queues Client: %v", err)
+		}
+
+		acls, err := aclClient.GetACLs(ctx, id.QueueName)
+		if err != nil {
+			return fmt.Errorf("retrieving ACLs for %s: %v", id, err)
+		}
+
+		if err := d.Set("acl", flattenStorageQueueACLs(acls)); err != nil {
+			return fmt.Errorf("setting `acl`: %v", err)
+		}
+	} else {
+		log.Printf("[DEBUG] Skipping retrieving the ACLs for %s since Shared Key access is disabled on the Storage Account", id)
+	}
+
 	resourceManagerId := parse.NewStorageQueueResourceManagerID(account.StorageAccountId.SubscriptionId, account.StorageAccountId.ResourceGroupName, id.AccountId.AccountName, "default", id.QueueName)
 	d.Set("resource_manager_id", resourceManagerId.ID())
 
@@ -241,3 +337,52 @@ func resourceStorageQueueDelete(d *pluginsdk.ResourceData, meta interface{}) err
 
 	return nil
 }
+
+func expandStorageQueueACLs(input []interface{}) []shim.StorageQueueSignedIdentifier {
+	results := make([]shim.StorageQueueSignedIdentifier, 0)
+
+	for _, v := range input {
+		vals := v.(map[string]interface{})
+
+		identifier := shim.StorageQueueSignedIdentifier{
+			Id: vals["id"].(string),
+		}
+
+		if policies := vals["access_policy"].([]interface{}); len(policies) > 0 && policies[0] != nil {
+			policy := policies[0].(map[string]interface{})
+			identifier.AccessPolicy = shim.StorageQueueAccessPolicy{
+				Start:      policy["start"].(string),
+				Expiry:     policy["expiry"].(string),
+				Permission: policy["permissions"].(string),
+			}
+		}
+
+		results = append(results, identifier)
+	}
+
+	return results
+}
+
+func flattenStorageQueueACLs(input *[]shim.StorageQueueSignedIdentifier) []interface{} {
+	result := make([]interface{}, 0)
+	if input == nil {
+		return result
+	}
+
+	for _, v := range *input {
+		output := map[string]interface{}{
+			"id": v.Id,
+			"access_policy": []interface{}{
+				map[string]interface{}{
+					"start":       v.AccessPolicy.Start,
+					"expiry":      v.AccessPolicy.Expiry,
+					"permissions": v.AccessPolicy.Permission,
+				},
+			},
+		}
+
+		result = append(result, output)
+	}
+
+	return result
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type storageQueuesDataSource struct{}
+
+func TestAccDataSourceStorageQueues_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_queues", "test")
+	d := storageQueuesDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: d.basic(data, "null"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("queues.#").HasValue("2"),
+				check.That(data.ResourceName).Key("queues.0.name").HasValue("test1"),
+				check.That(data.ResourceName).Key("queues.0.resource_manager_id").HasValue(
+					fmt.Sprintf("/subscriptions/%s/resourceGroups/acctestRG-%d/providers/Microsoft.Storage/storageAccounts/acctestacc%s/queueServices/default/queues/test1",
+						data.Client().SubscriptionID, data.RandomInteger, data.RandomString),
+				),
+				check.That(data.ResourceName).Key("queues.0.data_plane_id").HasValue(
+					fmt.Sprintf("https://acctestacc%s.queue.core.windows.net/test1", data.RandomString),
+				),
+				check.That(data.ResourceName).Key("queues.1.name").HasValue("test2"),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceStorageQueues_prefix(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_queues", "test")
+	d := storageQueuesDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: d.basic(data, `"test1"`),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("queues.#").HasValue("1"),
+				check.That(data.ResourceName).Key("queues.0.name").HasValue("test1"),
+			),
+		},
+	})
+}
+
+func (d storageQueuesDataSource) basic(data acceptance.TestData, prefix string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_queue" "test1" {
+  name                 = "test1"
+  storage_account_name = azurerm_storage_account.test.name
+}
+
+resource "azurerm_storage_queue" "test2" {
+  name                 = "test2"
+  storage_account_name = azurerm_storage_account.test.name
+}
+
+data "azurerm_storage_queues" "test" {
+  storage_account_id = azurerm_storage_account.test.id
+  name_prefix        = %s
+  depends_on         = [azurerm_storage_queue.test1, azurerm_storage_queue.test2]
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, prefix)
+}
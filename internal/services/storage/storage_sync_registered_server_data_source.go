@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storagesync/2020-03-01/registeredserverresource"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storagesync/2020-03-01/storagesyncservicesresource"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type storageSyncRegisteredServerDataSource struct{}
+
+var _ sdk.DataSource = storageSyncRegisteredServerDataSource{}
+
+type storageSyncRegisteredServerDataSourceModel struct {
+	Name            string `tfschema:"name"`
+	StorageSyncId   string `tfschema:"storage_sync_id"`
+	AgentVersion    string `tfschema:"agent_version"`
+	ClusterName     string `tfschema:"cluster_name"`
+	LastHeartbeat   string `tfschema:"last_heartbeat"`
+	ServerId        string `tfschema:"server_id"`
+	ServerOSVersion string `tfschema:"server_os_version"`
+	ServerRole      string `tfschema:"server_role"`
+}
+
+func (r storageSyncRegisteredServerDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"storage_sync_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: storagesyncservicesresource.ValidateStorageSyncServiceID,
+		},
+	}
+}
+
+func (r storageSyncRegisteredServerDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"agent_version": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"cluster_name": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"last_heartbeat": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"server_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"server_os_version": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"server_role": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r storageSyncRegisteredServerDataSource) ResourceType() string {
+	return "azurerm_storage_sync_registered_server"
+}
+
+func (r storageSyncRegisteredServerDataSource) ModelObject() interface{} {
+	return &storageSyncRegisteredServerDataSourceModel{}
+}
+
+func (r storageSyncRegisteredServerDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.SyncRegisteredServersClient
+
+			var config storageSyncRegisteredServerDataSourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			serviceId, err := registeredserverresource.ParseStorageSyncServiceID(config.StorageSyncId)
+			if err != nil {
+				return err
+			}
+
+			// Registered Servers are identified by a generated Server ID, so we look them up by their (host) name
+			resp, err := client.RegisteredServersListByStorageSyncService(ctx, *serviceId)
+			if err != nil {
+				return fmt.Errorf("listing Registered Servers for %s: %+v", serviceId, err)
+			}
+
+			var server *registeredserverresource.RegisteredServer
+			if model := resp.Model; model != nil {
+				for _, item := range pointer.From(model.Value) {
+					if item.Properties != nil && strings.EqualFold(pointer.From(item.Properties.FriendlyName), config.Name) {
+						server = pointer.To(item)
+						break
+					}
+				}
+			}
+			if server == nil || server.Name == nil {
+				return fmt.Errorf("a Registered Server named %q was not found in %s", config.Name, serviceId)
+			}
+
+			id := registeredserverresource.NewRegisteredServerID(serviceId.SubscriptionId, serviceId.ResourceGroupName, serviceId.StorageSyncServiceName, *server.Name)
+
+			state := storageSyncRegisteredServerDataSourceModel{
+				Name:          config.Name,
+				StorageSyncId: serviceId.ID(),
+			}
+
+			if props := server.Properties; props != nil {
+				state.AgentVersion = pointer.From(props.AgentVersion)
+				state.ClusterName = pointer.From(props.ClusterName)
+				state.LastHeartbeat = pointer.From(props.LastHeartBeat)
+				state.ServerId = pointer.From(props.ServerId)
+				state.ServerOSVersion = pointer.From(props.ServerOSVersion)
+				state.ServerRole = pointer.From(props.ServerRole)
+			}
+
+			metadata.SetID(id)
+			return metadata.Encode(&state)
+		},
+	}
+}
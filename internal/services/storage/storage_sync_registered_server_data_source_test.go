@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type StorageSyncRegisteredServerDataSource struct{}
+
+func TestAccDataSourceStorageSyncRegisteredServer_basic(t *testing.T) {
+	if os.Getenv("ARM_TEST_STORAGE_SYNC_ID") == "" || os.Getenv("ARM_TEST_STORAGE_SYNC_REGISTERED_SERVER_NAME") == "" {
+		t.Skipf("Skipping as either ARM_TEST_STORAGE_SYNC_ID or ARM_TEST_STORAGE_SYNC_REGISTERED_SERVER_NAME is not set")
+	}
+
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_sync_registered_server", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: StorageSyncRegisteredServerDataSource{}.basic(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("server_id").IsUUID(),
+				check.That(data.ResourceName).Key("agent_version").Exists(),
+				check.That(data.ResourceName).Key("server_os_version").Exists(),
+			),
+		},
+	})
+}
+
+func (d StorageSyncRegisteredServerDataSource) basic() string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_storage_sync_registered_server" "test" {
+  name            = "%s"
+  storage_sync_id = "%s"
+}
+`, os.Getenv("ARM_TEST_STORAGE_SYNC_REGISTERED_SERVER_NAME"), os.Getenv("ARM_TEST_STORAGE_SYNC_ID"))
+}
@@ -0,0 +1,291 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storagesync/2020-03-01/registeredserverresource"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storagesync/2020-03-01/serverendpointresource"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type StorageSyncServerEndpointResource struct{}
+
+var _ sdk.ResourceWithUpdate = StorageSyncServerEndpointResource{}
+
+type StorageSyncServerEndpointModel struct {
+	Name                   string `tfschema:"name"`
+	StorageSyncGroupId     string `tfschema:"storage_sync_group_id"`
+	RegisteredServerId     string `tfschema:"registered_server_id"`
+	ServerLocalPath        string `tfschema:"server_local_path"`
+	CloudTieringEnabled    bool   `tfschema:"cloud_tiering_enabled"`
+	VolumeFreeSpacePercent int64  `tfschema:"volume_free_space_percent"`
+	TierFilesOlderThanDays int64  `tfschema:"tier_files_older_than_days"`
+	InitialDownloadPolicy  string `tfschema:"initial_download_policy"`
+	LocalCacheMode         string `tfschema:"local_cache_mode"`
+}
+
+func (r StorageSyncServerEndpointResource) ResourceType() string {
+	return "azurerm_storage_sync_server_endpoint"
+}
+
+func (r StorageSyncServerEndpointResource) ModelObject() interface{} {
+	return &StorageSyncServerEndpointModel{}
+}
+
+func (r StorageSyncServerEndpointResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return serverendpointresource.ValidateServerEndpointID
+}
+
+func (r StorageSyncServerEndpointResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.StorageSyncName,
+		},
+
+		"storage_sync_group_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: serverendpointresource.ValidateSyncGroupID,
+		},
+
+		"registered_server_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: registeredserverresource.ValidateRegisteredServerID,
+		},
+
+		"server_local_path": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"cloud_tiering_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"volume_free_space_percent": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Default:      20,
+			ValidateFunc: validation.IntBetween(1, 99),
+		},
+
+		"tier_files_older_than_days": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntBetween(0, 2147483647),
+		},
+
+		"initial_download_policy": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      string(serverendpointresource.InitialDownloadPolicyNamespaceThenModifiedFiles),
+			ValidateFunc: validation.StringInSlice(serverendpointresource.PossibleValuesForInitialDownloadPolicy(), false),
+		},
+
+		"local_cache_mode": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Default:      string(serverendpointresource.LocalCacheModeUpdateLocallyCachedFiles),
+			ValidateFunc: validation.StringInSlice(serverendpointresource.PossibleValuesForLocalCacheMode(), false),
+		},
+	}
+}
+
+func (r StorageSyncServerEndpointResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r StorageSyncServerEndpointResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 45 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.SyncServerEndpointsClient
+
+			var config StorageSyncServerEndpointModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			groupId, err := serverendpointresource.ParseSyncGroupID(config.StorageSyncGroupId)
+			if err != nil {
+				return err
+			}
+
+			id := serverendpointresource.NewServerEndpointID(groupId.SubscriptionId, groupId.ResourceGroupName, groupId.StorageSyncServiceName, groupId.SyncGroupName, config.Name)
+
+			existing, err := client.ServerEndpointsGet(ctx, id)
+			if err != nil {
+				if !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			payload := serverendpointresource.ServerEndpointCreateParameters{
+				Properties: &serverendpointresource.ServerEndpointCreateParametersProperties{
+					CloudTiering:           expandStorageSyncServerEndpointFeatureStatus(config.CloudTieringEnabled),
+					InitialDownloadPolicy:  pointer.To(serverendpointresource.InitialDownloadPolicy(config.InitialDownloadPolicy)),
+					LocalCacheMode:         pointer.To(serverendpointresource.LocalCacheMode(config.LocalCacheMode)),
+					ServerLocalPath:        pointer.To(config.ServerLocalPath),
+					ServerResourceId:       pointer.To(config.RegisteredServerId),
+					VolumeFreeSpacePercent: pointer.To(config.VolumeFreeSpacePercent),
+				},
+			}
+			if config.TierFilesOlderThanDays > 0 {
+				payload.Properties.TierFilesOlderThanDays = pointer.To(config.TierFilesOlderThanDays)
+			}
+
+			if err := client.ServerEndpointsCreateThenPoll(ctx, id, payload); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r StorageSyncServerEndpointResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.SyncServerEndpointsClient
+
+			id, err := serverendpointresource.ParseServerEndpointID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.ServerEndpointsGet(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := StorageSyncServerEndpointModel{
+				Name:               id.ServerEndpointName,
+				StorageSyncGroupId: serverendpointresource.NewSyncGroupID(id.SubscriptionId, id.ResourceGroupName, id.StorageSyncServiceName, id.SyncGroupName).ID(),
+			}
+
+			if model := resp.Model; model != nil {
+				if props := model.Properties; props != nil {
+					state.CloudTieringEnabled = pointer.From(props.CloudTiering) == serverendpointresource.FeatureStatusOn
+					state.InitialDownloadPolicy = string(pointer.From(props.InitialDownloadPolicy))
+					state.LocalCacheMode = string(pointer.From(props.LocalCacheMode))
+					state.ServerLocalPath = pointer.From(props.ServerLocalPath)
+					state.TierFilesOlderThanDays = pointer.From(props.TierFilesOlderThanDays)
+					state.VolumeFreeSpacePercent = pointer.From(props.VolumeFreeSpacePercent)
+
+					registeredServerId := ""
+					if props.ServerResourceId != nil {
+						parsed, err := registeredserverresource.ParseRegisteredServerIDInsensitively(*props.ServerResourceId)
+						if err != nil {
+							return err
+						}
+						registeredServerId = parsed.ID()
+					}
+					state.RegisteredServerId = registeredServerId
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r StorageSyncServerEndpointResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 45 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.SyncServerEndpointsClient
+
+			id, err := serverendpointresource.ParseServerEndpointID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config StorageSyncServerEndpointModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			payload := serverendpointresource.ServerEndpointUpdateParameters{
+				Properties: &serverendpointresource.ServerEndpointUpdateProperties{},
+			}
+
+			if metadata.ResourceData.HasChange("cloud_tiering_enabled") {
+				payload.Properties.CloudTiering = expandStorageSyncServerEndpointFeatureStatus(config.CloudTieringEnabled)
+			}
+
+			if metadata.ResourceData.HasChange("local_cache_mode") {
+				payload.Properties.LocalCacheMode = pointer.To(serverendpointresource.LocalCacheMode(config.LocalCacheMode))
+			}
+
+			if metadata.ResourceData.HasChange("tier_files_older_than_days") {
+				payload.Properties.TierFilesOlderThanDays = pointer.To(config.TierFilesOlderThanDays)
+			}
+
+			if metadata.ResourceData.HasChange("volume_free_space_percent") {
+				payload.Properties.VolumeFreeSpacePercent = pointer.To(config.VolumeFreeSpacePercent)
+			}
+
+			if err := client.ServerEndpointsUpdateThenPoll(ctx, *id, payload); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r StorageSyncServerEndpointResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 45 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.SyncServerEndpointsClient
+
+			id, err := serverendpointresource.ParseServerEndpointID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if err := client.ServerEndpointsDeleteThenPoll(ctx, *id); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func expandStorageSyncServerEndpointFeatureStatus(input bool) *serverendpointresource.FeatureStatus {
+	if input {
+		return pointer.To(serverendpointresource.FeatureStatusOn)
+	}
+	return pointer.To(serverendpointresource.FeatureStatusOff)
+}
@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storagesync/2020-03-01/serverendpointresource"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+// NOTE: a Server Endpoint requires a server running the Azure File Sync agent which has been registered
+// with a Storage Sync Service - since this can't be provisioned by Terraform these tests use an existing
+// Storage Sync Service (ARM_TEST_STORAGE_SYNC_ID) and Registered Server (ARM_TEST_STORAGE_SYNC_REGISTERED_SERVER_NAME)
+
+type StorageSyncServerEndpointResource struct{}
+
+func TestAccStorageSyncServerEndpoint_basic(t *testing.T) {
+	if os.Getenv("ARM_TEST_STORAGE_SYNC_ID") == "" || os.Getenv("ARM_TEST_STORAGE_SYNC_REGISTERED_SERVER_NAME") == "" {
+		t.Skipf("Skipping as either ARM_TEST_STORAGE_SYNC_ID or ARM_TEST_STORAGE_SYNC_REGISTERED_SERVER_NAME is not set")
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_storage_sync_server_endpoint", "test")
+	r := StorageSyncServerEndpointResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccStorageSyncServerEndpoint_requiresImport(t *testing.T) {
+	if os.Getenv("ARM_TEST_STORAGE_SYNC_ID") == "" || os.Getenv("ARM_TEST_STORAGE_SYNC_REGISTERED_SERVER_NAME") == "" {
+		t.Skipf("Skipping as either ARM_TEST_STORAGE_SYNC_ID or ARM_TEST_STORAGE_SYNC_REGISTERED_SERVER_NAME is not set")
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_storage_sync_server_endpoint", "test")
+	r := StorageSyncServerEndpointResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccStorageSyncServerEndpoint_update(t *testing.T) {
+	if os.Getenv("ARM_TEST_STORAGE_SYNC_ID") == "" || os.Getenv("ARM_TEST_STORAGE_SYNC_REGISTERED_SERVER_NAME") == "" {
+		t.Skipf("Skipping as either ARM_TEST_STORAGE_SYNC_ID or ARM_TEST_STORAGE_SYNC_REGISTERED_SERVER_NAME is not set")
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_storage_sync_server_endpoint", "test")
+	r := StorageSyncServerEndpointResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r StorageSyncServerEndpointResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := serverendpointresource.ParseServerEndpointID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Storage.SyncServerEndpointsClient.ServerEndpointsGet(ctx, *id)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (r StorageSyncServerEndpointResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_sync_server_endpoint" "test" {
+  name                  = "acctest-SEP-%d"
+  storage_sync_group_id = azurerm_storage_sync_group.test.id
+  registered_server_id  = data.azurerm_storage_sync_registered_server.test.id
+  server_local_path     = "D:\\acctest-%d"
+
+  depends_on = [azurerm_storage_sync_cloud_endpoint.test]
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger)
+}
+
+func (r StorageSyncServerEndpointResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_sync_server_endpoint" "test" {
+  name                       = "acctest-SEP-%d"
+  storage_sync_group_id      = azurerm_storage_sync_group.test.id
+  registered_server_id       = data.azurerm_storage_sync_registered_server.test.id
+  server_local_path          = "D:\\acctest-%d"
+  cloud_tiering_enabled      = true
+  volume_free_space_percent  = 50
+  tier_files_older_than_days = 30
+  local_cache_mode           = "DownloadNewAndModifiedFiles"
+
+  depends_on = [azurerm_storage_sync_cloud_endpoint.test]
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger)
+}
+
+func (r StorageSyncServerEndpointResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_sync_server_endpoint" "import" {
+  name                  = azurerm_storage_sync_server_endpoint.test.name
+  storage_sync_group_id = azurerm_storage_sync_server_endpoint.test.storage_sync_group_id
+  registered_server_id  = azurerm_storage_sync_server_endpoint.test.registered_server_id
+  server_local_path     = azurerm_storage_sync_server_endpoint.test.server_local_path
+}
+`, r.basic(data))
+}
+
+func (r StorageSyncServerEndpointResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_storage_sync_registered_server" "test" {
+  name            = "%[4]s"
+  storage_sync_id = "%[5]s"
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-StorageSync-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_sync_group" "test" {
+  name            = "acctest-StorageSyncGroup-%[1]d"
+  storage_sync_id = data.azurerm_storage_sync_registered_server.test.storage_sync_id
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "accstr%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_share" "test" {
+  name                 = "acctest-share-%[1]d"
+  storage_account_name = azurerm_storage_account.test.name
+  quota                = 1
+
+  acl {
+    id = "GhostedRecall"
+    access_policy {
+      permissions = "r"
+    }
+  }
+}
+
+resource "azurerm_storage_sync_cloud_endpoint" "test" {
+  name                  = "acctest-CEP-%[1]d"
+  storage_sync_group_id = azurerm_storage_sync_group.test.id
+  storage_account_id    = azurerm_storage_account.test.id
+  file_share_name       = azurerm_storage_share.test.name
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, os.Getenv("ARM_TEST_STORAGE_SYNC_REGISTERED_SERVER_NAME"), os.Getenv("ARM_TEST_STORAGE_SYNC_ID"))
+}
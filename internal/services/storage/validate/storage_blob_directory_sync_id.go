@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+func StorageBlobDirectorySyncID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	if client.StorageDomainSuffix == nil {
+		return validation.StringIsNotEmpty(input, key)
+	}
+
+	if _, err := parse.StorageBlobDirectorySyncID(v, *client.StorageDomainSuffix); err != nil {
+		errors = append(errors, err)
+	}
+
+	return
+}
@@ -27,7 +27,10 @@ type StorageMoverModel struct {
 
 type StorageMoverResource struct{}
 
-var _ sdk.ResourceWithUpdate = StorageMoverResource{}
+var (
+	_ sdk.ResourceWithUpdate               = StorageMoverResource{}
+	_ sdk.ResourceWithSubscriptionOverride = StorageMoverResource{}
+)
 
 func (r StorageMoverResource) ResourceType() string {
 	return "azurerm_storage_mover"
@@ -41,6 +44,10 @@ func (r StorageMoverResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
 	return storagemovers.ValidateStorageMoverID
 }
 
+func (r StorageMoverResource) SupportsSubscriptionOverride() bool {
+	return true
+}
+
 func (r StorageMoverResource) Arguments() map[string]*pluginsdk.Schema {
 	return map[string]*pluginsdk.Schema{
 		"name": {
@@ -4,7 +4,6 @@
 package web
 
 import (
-	"encoding/base64"
 	"fmt"
 	"log"
 	"time"
@@ -15,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/certificatebundle"
 	keyVaultParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
 	keyVaultSuppress "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/suppress"
 	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
@@ -27,7 +27,7 @@ import (
 )
 
 func resourceAppServiceCertificate() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
+	return certificatebundle.WithExpiryWarnings(&pluginsdk.Resource{
 		Create: resourceAppServiceCertificateCreateUpdate,
 		Read:   resourceAppServiceCertificateRead,
 		Update: resourceAppServiceCertificateCreateUpdate,
@@ -45,7 +45,7 @@ func resourceAppServiceCertificate() *pluginsdk.Resource {
 		},
 
 		Schema: resourceAppServiceCertificateSchema(),
-	}
+	}, "pfx_blob", "password")
 }
 
 func resourceAppServiceCertificateCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
@@ -92,9 +92,9 @@ func resourceAppServiceCertificateCreateUpdate(d *pluginsdk.ResourceData, meta i
 	}
 
 	if pfxBlob != "" {
-		decodedPfxBlob, err := base64.StdEncoding.DecodeString(pfxBlob)
+		decodedPfxBlob, err := certificatebundle.NormaliseToPkcs12(pfxBlob, password)
 		if err != nil {
-			return fmt.Errorf("Could not decode PFX blob: %+v", err)
+			return fmt.Errorf("validating `pfx_blob`: %+v", err)
 		}
 		certificate.CertificateProperties.PfxBlob = &decodedPfxBlob
 	}
@@ -14,6 +14,11 @@ type IDValidationFunc func(id string) error
 
 type ImporterFunc = func(ctx context.Context, d *ResourceData, meta interface{}) ([]*ResourceData, error)
 
+// ImportIDValidationOnly can be passed as the `meta` to an Importer created via ImporterValidatingResourceId or
+// ImporterValidatingResourceIdThen to only validate the ID (without running the 'thenFunc'), which allows
+// tooling to validate an ID without configuring the Provider.
+type ImportIDValidationOnly struct{}
+
 // ImporterValidatingResourceId validates the ID provided at import time is valid
 // using the validateFunc.
 func ImporterValidatingResourceId(validateFunc IDValidationFunc) *schema.ResourceImporter {
@@ -41,6 +46,10 @@ func ImporterValidatingResourceIdThen(validateFunc IDValidationFunc, thenFunc Im
 				return []*ResourceData{d}, err
 			}
 
+			if _, ok := meta.(ImportIDValidationOnly); ok {
+				return []*ResourceData{d}, nil
+			}
+
 			return thenFunc(ctx, d, meta)
 		},
 	}
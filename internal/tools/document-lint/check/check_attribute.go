@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"fmt"
+	"sort"
+
+	schema2 "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/schema"
+)
+
+type AttributeIssue int
+
+const (
+	AttributeNotComputed AttributeIssue = iota // listed in Attributes Reference but not Computed in the schema
+	AttributeMissing                           // Computed in the schema but not listed in Attributes Reference
+)
+
+type attributeDiff struct {
+	checkBase
+	Issue AttributeIssue
+}
+
+func newAttributeDiff(line int, key string, f *model.Field, issue AttributeIssue) *attributeDiff {
+	return &attributeDiff{
+		checkBase: newCheckBase(line, key, f),
+		Issue:     issue,
+	}
+}
+
+// ShouldSkip a missing attribute has no field in the document, so is reported against the section header instead
+func (c attributeDiff) ShouldSkip() bool {
+	return c.line == 0
+}
+
+func (c attributeDiff) String() string {
+	if c.Issue == AttributeMissing {
+		return fmt.Sprintf("%s is Computed but missing from the %s Reference", c.checkBase.Str(), model.PosAttr)
+	}
+	return fmt.Sprintf("%s is listed in the %s Reference but is not Computed, should it be in the %s Reference?", c.checkBase.Str(), model.PosAttr, model.PosArgs)
+}
+
+func (c attributeDiff) Fix(line string) (result string, err error) {
+	// attributes can't be added or moved between sections by line
+	return line, nil
+}
+
+var _ Checker = (*attributeDiff)(nil)
+
+// checkAttributes compares the Attributes Reference to the Computed fields in the schema
+func checkAttributes(r *schema.Resource, md *model.ResourceDoc) (res []Checker) {
+	// the document has no attributes section at all, which is reported elsewhere
+	headLine, ok := md.PosLines[model.PosAttr]
+	if !ok {
+		return nil
+	}
+	return diffAttributes(r.ResourceType, "", headLine, r.Schema.Schema, md.Args, md.Attr)
+}
+
+func diffAttributes(rt, path string, headLine int, sch map[string]*schema2.Schema, args, attrs model.Properties) (res []Checker) {
+	names := make([]string, 0, len(sch)+len(attrs))
+	for name := range sch {
+		names = append(names, name)
+	}
+	for name := range attrs {
+		if _, ok := sch[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		key := name
+		if path != "" {
+			key = path + "." + name
+		}
+		if isSkipProp(rt, key) {
+			continue
+		}
+
+		s := sch[name]
+		f := attrs[name]
+		switch {
+		case s == nil:
+			// attributes which don't exist in the schema are reported by crossCheckProperty
+			continue
+
+		case f == nil:
+			computedOnly := s.Computed && !s.Optional && !s.Required
+			if _, inArgs := args[name]; computedOnly && !inArgs && s.Deprecated == "" {
+				res = append(res, newAttributeDiff(headLine, key, nil, AttributeMissing))
+			}
+
+		case !s.Computed:
+			if _, inArgs := args[name]; !inArgs {
+				res = append(res, newAttributeDiff(f.Line, key, f, AttributeNotComputed))
+			}
+
+		default:
+			// check the nested attributes of Computed blocks documented in the Attributes Reference
+			if elem, ok := s.Elem.(*schema2.Resource); ok && f.Subs != nil {
+				var nestedArgs model.Properties
+				if arg, ok := args[name]; ok {
+					nestedArgs = arg.Subs
+				}
+				res = append(res, diffAttributes(rt, key, f.Line, elem.Schema, nestedArgs, f.Subs)...)
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,343 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/util"
+)
+
+type ExampleIssue int
+
+const (
+	ExampleParseError ExampleIssue = iota
+	ExampleUnknownResource
+	ExampleUnknownArgument
+	ExampleMisspelling
+	ExampleWrongPlace // block or argument nested in the wrong block
+	ExampleReadOnly
+	ExampleMissingRequired
+	ExampleShouldBeBlock
+	ExampleShouldBeArgument
+)
+
+type exampleDiff struct {
+	checkBase
+	Issue       ExampleIssue
+	detail      string // the error message for parse errors
+	name        string // the name of the argument or block in the example
+	correctName string // for misspelling and wrong place diff only
+	skip        bool
+}
+
+func newExampleDiff(line int, key string, issue ExampleIssue) *exampleDiff {
+	return &exampleDiff{
+		checkBase: newCheckBase(line, key, nil),
+		Issue:     issue,
+	}
+}
+
+// ShouldSkip examples have no field in the document, so can't rely on checkBase
+func (c exampleDiff) ShouldSkip() bool {
+	return c.skip
+}
+
+func (c exampleDiff) String() string {
+	switch c.Issue {
+	case ExampleParseError:
+		return fmt.Sprintf("%s example can not be parsed: %s", c.checkBase.Str(), c.detail)
+	case ExampleUnknownResource:
+		return fmt.Sprintf("%s used in the example does not exist in the provider", c.checkBase.Str())
+	case ExampleMisspelling:
+		return fmt.Sprintf("%s in the example does not exist in the schema - should this be %s?", c.checkBase.Str(), util.FixedCode(c.correctName))
+	case ExampleWrongPlace:
+		return fmt.Sprintf("%s in the example should be nested in %s", c.checkBase.Str(), util.ItalicCode(util.XPathDir(c.correctName)))
+	case ExampleReadOnly:
+		return fmt.Sprintf("%s in the example is Computed and can not be set", c.checkBase.Str())
+	case ExampleMissingRequired:
+		return fmt.Sprintf("%s is Required but missing from the example", c.checkBase.Str())
+	case ExampleShouldBeBlock:
+		return fmt.Sprintf("%s in the example should be declared as a block", c.checkBase.Str())
+	case ExampleShouldBeArgument:
+		return fmt.Sprintf("%s in the example should be set as an argument rather than a block", c.checkBase.Str())
+	}
+	return fmt.Sprintf("%s in the example does not exist in the schema", c.checkBase.Str())
+}
+
+var blockAsAttributeReg = regexp.MustCompile(`^(\s*)([a-zA-Z0-9_]+)\s*=\s*\{`)
+
+func (c exampleDiff) Fix(line string) (result string, err error) {
+	switch c.Issue {
+	case ExampleMisspelling:
+		reg := regexp.MustCompile(`\b` + regexp.QuoteMeta(c.name) + `\b`)
+		if loc := reg.FindStringIndex(line); loc != nil {
+			return line[:loc[0]] + c.correctName + line[loc[1]:], nil
+		}
+	case ExampleShouldBeBlock:
+		if match := blockAsAttributeReg.FindStringSubmatch(line); match != nil && match[2] == c.name {
+			return match[1] + c.name + " {" + line[len(match[0]):], nil
+		}
+	}
+	return line, nil
+}
+
+var _ Checker = (*exampleDiff)(nil)
+
+var (
+	providerSchemaOnce  sync.Once
+	providerResources   map[string]*pluginsdk.Resource
+	providerDataSources map[string]*pluginsdk.Resource
+)
+
+func providerSchemaFor(blockType string, resourceType string) *pluginsdk.Resource {
+	providerSchemaOnce.Do(func() {
+		p := provider.AzureProvider()
+		providerResources = p.ResourcesMap
+		providerDataSources = p.DataSourcesMap
+	})
+	if blockType == "data" {
+		return providerDataSources[resourceType]
+	}
+	return providerResources[resourceType]
+}
+
+// meta-arguments which are supported by every resource and data source
+var exampleMetaArguments = map[string]struct{}{
+	"count":      {},
+	"depends_on": {},
+	"for_each":   {},
+	"provider":   {},
+}
+
+var exampleMetaBlocks = map[string]struct{}{
+	"connection":  {},
+	"lifecycle":   {},
+	"provisioner": {},
+}
+
+type exampleValidator struct {
+	offset       int // line index of the code block in the document
+	resourceType string
+	prefix       string // the address of the resource in the example, e.g. azurerm_resource_group.example
+	root         map[string]*pluginsdk.Schema
+	res          []Checker
+}
+
+func (v *exampleValidator) add(line int, path string, issue ExampleIssue) *exampleDiff {
+	key := v.prefix
+	if path != "" {
+		key += "." + path
+	}
+	item := newExampleDiff(v.offset+line-1, key, issue)
+	item.skip = path != "" && isSkipProp(v.resourceType, path)
+	v.res = append(v.res, item)
+	return item
+}
+
+func checkExamples(r *schema.Resource, md *model.ResourceDoc) (res []Checker) {
+	for _, example := range md.Examples {
+		file, diags := hclsyntax.ParseConfig([]byte(example.HCL), md.ResourceName, hcl.InitialPos)
+		if diags.HasErrors() {
+			line := 1
+			if subject := diags[0].Subject; subject != nil {
+				line = subject.Start.Line
+			}
+			item := newExampleDiff(example.Line+line-1, r.ResourceType, ExampleParseError)
+			item.detail = diags[0].Summary
+			res = append(res, item)
+			continue
+		}
+
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if (block.Type != "resource" && block.Type != "data") || len(block.Labels) != 2 || !strings.HasPrefix(block.Labels[0], "azurerm_") {
+				continue
+			}
+
+			resourceType := block.Labels[0]
+			v := &exampleValidator{
+				offset:       example.Line,
+				resourceType: resourceType,
+				prefix:       fmt.Sprintf("%s.%s", resourceType, block.Labels[1]),
+			}
+			if block.Type == "data" {
+				v.prefix = "data." + v.prefix
+			}
+
+			sch := r.Schema
+			if block.Type != "resource" || resourceType != r.ResourceType {
+				sch = providerSchemaFor(block.Type, resourceType)
+			}
+			if sch == nil {
+				v.add(block.LabelRanges[0].Start.Line, "", ExampleUnknownResource)
+				res = append(res, v.res...)
+				continue
+			}
+
+			v.root = sch.Schema
+			v.validateBody(block.Body, sch.Schema, "", block.TypeRange.Start.Line, true, sch.Timeouts != nil)
+			res = append(res, v.res...)
+		}
+	}
+	return res
+}
+
+func (v *exampleValidator) validateBody(body *hclsyntax.Body, sch map[string]*pluginsdk.Schema, path string, line int, topLevel bool, hasTimeouts bool) {
+	present := map[string]struct{}{}
+
+	names := make([]string, 0, len(body.Attributes))
+	for name := range body.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		attr := body.Attributes[name]
+		if _, ok := exampleMetaArguments[name]; ok && topLevel {
+			continue
+		}
+		present[name] = struct{}{}
+
+		attrLine := attr.NameRange.Start.Line
+		s, ok := sch[name]
+		switch {
+		case !ok:
+			if correctName := v.unknown(attrLine, path, name, sch); correctName != "" {
+				present[correctName] = struct{}{}
+			}
+		case isBlockSchema(s):
+			v.add(attrLine, joinPath(path, name), ExampleShouldBeBlock).name = name
+		case !s.Required && !s.Optional:
+			v.add(attrLine, joinPath(path, name), ExampleReadOnly)
+		}
+	}
+
+	for _, block := range body.Blocks {
+		name, blockBody := block.Type, block.Body
+		if block.Type == "dynamic" && len(block.Labels) == 1 {
+			name, blockBody = block.Labels[0], nil
+			for _, content := range block.Body.Blocks {
+				if content.Type == "content" {
+					blockBody = content.Body
+				}
+			}
+		}
+
+		if topLevel {
+			if _, ok := exampleMetaBlocks[name]; ok {
+				continue
+			}
+			if name == "timeouts" && hasTimeouts {
+				continue
+			}
+		}
+		present[name] = struct{}{}
+
+		blockLine := block.TypeRange.Start.Line
+		s, ok := sch[name]
+		if !ok {
+			if correctName := v.unknown(blockLine, path, name, sch); correctName != "" {
+				present[correctName] = struct{}{}
+			}
+			continue
+		}
+
+		elem, isResource := s.Elem.(*pluginsdk.Resource)
+		switch {
+		case !isResource:
+			v.add(blockLine, joinPath(path, name), ExampleShouldBeArgument)
+		case !s.Required && !s.Optional:
+			v.add(blockLine, joinPath(path, name), ExampleReadOnly)
+		case blockBody != nil:
+			v.validateBody(blockBody, elem.Schema, joinPath(path, name), blockLine, false, false)
+		}
+	}
+
+	required := make([]string, 0)
+	for name, s := range sch {
+		if _, ok := present[name]; !ok && s.Required {
+			required = append(required, name)
+		}
+	}
+	sort.Strings(required)
+	for _, name := range required {
+		v.add(line, joinPath(path, name), ExampleMissingRequired)
+	}
+}
+
+// unknown reports an argument or block which doesn't exist at this level of the schema, checking whether this is
+// likely a misspelling of an argument at the same level or an argument which should be nested elsewhere.
+// returns the name of the argument at this level when this is a misspelling
+func (v *exampleValidator) unknown(line int, path string, name string, sch map[string]*pluginsdk.Schema) string {
+	candidates := make([]string, 0, len(sch))
+	for k, s := range sch {
+		if s.Required || s.Optional {
+			candidates = append(candidates, k)
+		}
+	}
+	sort.Strings(candidates)
+	for _, k := range candidates {
+		if levenshteinDist(name, k) <= 2 {
+			item := v.add(line, joinPath(path, name), ExampleMisspelling)
+			item.name = name
+			item.correctName = k
+			return k
+		}
+	}
+
+	if correctPath := findSchemaPath(v.root, name, ""); correctPath != "" {
+		v.add(line, joinPath(path, name), ExampleWrongPlace).correctName = correctPath
+		return ""
+	}
+
+	v.add(line, joinPath(path, name), ExampleUnknownArgument)
+	return ""
+}
+
+func findSchemaPath(sch map[string]*pluginsdk.Schema, name string, path string) string {
+	keys := make([]string, 0, len(sch))
+	for k := range sch {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		s := sch[k]
+		if k == name && (s.Required || s.Optional) {
+			return joinPath(path, k)
+		}
+		if elem, ok := s.Elem.(*pluginsdk.Resource); ok {
+			if res := findSchemaPath(elem.Schema, name, joinPath(path, k)); res != "" {
+				return res
+			}
+		}
+	}
+	return ""
+}
+
+func isBlockSchema(s *pluginsdk.Schema) bool {
+	_, ok := s.Elem.(*pluginsdk.Resource)
+	return ok && s.ConfigMode != pluginsdk.SchemaConfigModeAttr
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
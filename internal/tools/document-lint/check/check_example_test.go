@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/schema"
+)
+
+func TestCheckExamples(t *testing.T) {
+	r := &schema.Resource{
+		ResourceType: "azurerm_example",
+		Schema: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+				},
+				"location": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+				},
+				"fqdn": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+				"network": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"subnet_id": {
+								Type:     pluginsdk.TypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	doc := &model.ResourceDoc{
+		ResourceName: "azurerm_example",
+		Examples: []model.Example{
+			{
+				Line: 10,
+				HCL: `resource "azurerm_example" "example" {
+  nmae      = "example"
+  fqdn      = "example.com"
+  subnet_id = "some-id"
+  network = {
+  }
+}`,
+			},
+		},
+	}
+
+	expected := map[string]ExampleIssue{
+		"azurerm_example.example.nmae":      ExampleMisspelling,
+		"azurerm_example.example.fqdn":      ExampleReadOnly,
+		"azurerm_example.example.subnet_id": ExampleWrongPlace,
+		"azurerm_example.example.network":   ExampleShouldBeBlock,
+		"azurerm_example.example.location":  ExampleMissingRequired,
+	}
+
+	res := checkExamples(r, doc)
+	if len(res) != len(expected) {
+		t.Fatalf("expected %d issues, got %d: %+v", len(expected), len(res), res)
+	}
+	for _, item := range res {
+		diff, ok := item.(*exampleDiff)
+		if !ok {
+			t.Fatalf("expected an exampleDiff, got %T", item)
+		}
+		if issue, ok := expected[diff.Key()]; !ok || issue != diff.Issue {
+			t.Errorf("unexpected issue %d for %q", diff.Issue, diff.Key())
+		}
+	}
+}
+
+func TestExampleDiffFix(t *testing.T) {
+	misspelling := exampleDiff{Issue: ExampleMisspelling, name: "nmae", correctName: "name"}
+	if fixed, _ := misspelling.Fix(`  nmae      = "example"`); fixed != `  name      = "example"` {
+		t.Errorf("unexpected fix for misspelling: %q", fixed)
+	}
+
+	shouldBeBlock := exampleDiff{Issue: ExampleShouldBeBlock, name: "network"}
+	if fixed, _ := shouldBeBlock.Fix(`  network = {`); fixed != `  network {` {
+		t.Errorf("unexpected fix for block: %q", fixed)
+	}
+}
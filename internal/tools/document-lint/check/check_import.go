@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/util"
+)
+
+type ImportIssue int
+
+const (
+	ImportInvalidID ImportIssue = iota
+	ImportWrongResourceType
+)
+
+type importDiff struct {
+	checkBase
+	Issue        ImportIssue
+	resourceType string // the resource type used in the document
+	correctType  string // the resource type the document belongs to
+	detail       string // the error returned when validating the id
+}
+
+func newImportDiff(imp model.Import, key string, issue ImportIssue) *importDiff {
+	return &importDiff{
+		checkBase:    newCheckBase(imp.Line, key, nil),
+		Issue:        issue,
+		resourceType: imp.ResourceType,
+	}
+}
+
+// ShouldSkip an import has no field in the document, so can't rely on checkBase
+func (c importDiff) ShouldSkip() bool {
+	return false
+}
+
+func (c importDiff) String() string {
+	if c.Issue == ImportWrongResourceType {
+		return fmt.Sprintf("%s %s example uses the wrong resource type %s", c.checkBase.Str(), model.PosImport, util.ItalicCode(c.resourceType))
+	}
+	return fmt.Sprintf("%s %s example has an invalid ID: %s", c.checkBase.Str(), model.PosImport, c.detail)
+}
+
+func (c importDiff) Fix(line string) (result string, err error) {
+	if c.Issue == ImportWrongResourceType {
+		return strings.Replace(line, " "+c.resourceType+".", " "+c.correctType+".", 1), nil
+	}
+	return line, nil
+}
+
+var _ Checker = (*importDiff)(nil)
+
+// checkImports runs the ID of each import example through the ID validation of the resource
+func checkImports(r *schema.Resource, md *model.ResourceDoc) (res []Checker) {
+	for _, imp := range md.Imports {
+		if imp.ResourceType != r.ResourceType {
+			item := newImportDiff(imp, r.ResourceType, ImportWrongResourceType)
+			item.correctType = r.ResourceType
+			res = append(res, item)
+		}
+
+		// skip IDs using placeholders, e.g. `/subscriptions/{subscriptionId}/...`, which are described in the document
+		if strings.ContainsAny(imp.ResourceID, "{}<>") {
+			continue
+		}
+
+		if err := validateImportID(r, imp.ResourceID); err != nil {
+			item := newImportDiff(imp, r.ResourceType, ImportInvalidID)
+			item.detail = err.Error()
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// validateImportID uses the `IDValidationFunc` for typed resources, and the validation function passed to
+// `pluginsdk.ImporterValidatingResourceId` for untyped resources. Untyped resources using a custom Importer
+// can't be validated without running the import, so are skipped.
+func validateImportID(r *schema.Resource, id string) error {
+	if r.SDKResource != nil {
+		if validateFunc := r.SDKResource.IDValidationFunc(); validateFunc != nil {
+			if _, errs := validateFunc(id, "id"); len(errs) > 0 {
+				return errs[0]
+			}
+		}
+		return nil
+	}
+
+	if r.Schema == nil || r.Schema.Importer == nil || r.Schema.Importer.StateContext == nil {
+		return nil
+	}
+
+	// only the Importers created by `pluginsdk.ImporterValidatingResourceId(Then)` support validating the ID on
+	// its own, which is determined from the name of the function rather than by running it
+	name := util.FuncName(r.Schema.Importer.StateContext)
+	if !strings.Contains(name, "pluginsdk.ImporterValidatingResourceIdThen") {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	d := r.Schema.Data(nil)
+	d.SetId(id)
+	_, err := r.Schema.Importer.StateContext(ctx, d, pluginsdk.ImportIDValidationOnly{})
+	return err
+}
@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/schema"
+)
+
+func TestCheckImports(t *testing.T) {
+	r := &schema.Resource{
+		ResourceType: "azurerm_example",
+		Schema: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{},
+			Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+				if id != "/valid" {
+					return fmt.Errorf("parsing %q: unexpected format", id)
+				}
+				return nil
+			}),
+		},
+	}
+	doc := &model.ResourceDoc{
+		Imports: []model.Import{
+			{Line: 10, ResourceType: "azurerm_example", ResourceID: "/valid"},
+			{Line: 12, ResourceType: "azurerm_example", ResourceID: "/invalid"},
+			{Line: 14, ResourceType: "azurerm_example", ResourceID: "/subscriptions/{subscriptionId}"},
+			{Line: 16, ResourceType: "azurerm_other", ResourceID: "/valid"},
+		},
+	}
+
+	res := checkImports(r, doc)
+	if len(res) != 2 {
+		t.Fatalf("expected 2 issues, got %d: %+v", len(res), res)
+	}
+	if item := res[0].(*importDiff); item.Line() != 12 || item.Issue != ImportInvalidID {
+		t.Errorf("expected an invalid ID at line 12, got %+v", item)
+	}
+	if item := res[1].(*importDiff); item.Line() != 16 || item.Issue != ImportWrongResourceType {
+		t.Errorf("expected a wrong resource type at line 16, got %+v", item)
+	}
+
+	fixed, _ := res[1].Fix("terraform import azurerm_other.example /valid")
+	if fixed != "terraform import azurerm_example.example /valid" {
+		t.Errorf("unexpected fix %q", fixed)
+	}
+}
+
+func TestValidateImportIDSkipsImport(t *testing.T) {
+	validateFunc := func(id string) error {
+		if id != "/valid" {
+			return fmt.Errorf("parsing %q: unexpected format", id)
+		}
+		return nil
+	}
+
+	testData := []struct {
+		name     string
+		importer *sdkschema.ResourceImporter
+		id       string
+		error    bool
+	}{
+		{
+			name: "then func isn't run",
+			importer: pluginsdk.ImporterValidatingResourceIdThen(validateFunc, func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
+				panic("the import shouldn't be run")
+			}),
+			id: "/valid",
+		},
+		{
+			name: "then func with an invalid id",
+			importer: pluginsdk.ImporterValidatingResourceIdThen(validateFunc, func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
+				panic("the import shouldn't be run")
+			}),
+			id:    "/invalid",
+			error: true,
+		},
+		{
+			name: "custom importer isn't run",
+			importer: &sdkschema.ResourceImporter{
+				StateContext: func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
+					panic("the import shouldn't be run")
+				},
+			},
+			id: "/invalid",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		r := &schema.Resource{
+			ResourceType: "azurerm_example",
+			Schema: &pluginsdk.Resource{
+				Schema:   map[string]*pluginsdk.Schema{},
+				Importer: v.importer,
+			},
+		}
+		err := validateImportID(r, v.id)
+		if (err != nil) != v.error {
+			t.Fatalf("expected an error to be %t but got %+v", v.error, err)
+		}
+	}
+}
+
+func TestCheckAttributes(t *testing.T) {
+	r := &schema.Resource{
+		ResourceType: "azurerm_example",
+		Schema: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+				},
+				"tags": {
+					Type:     pluginsdk.TypeMap,
+					Optional: true,
+				},
+				"fqdn": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+				"endpoint": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+	doc := model.NewResourceDoc()
+	doc.PosLines[model.PosAttr] = 20
+	doc.Args["name"] = &model.Field{Name: "name", Line: 10, Pos: model.PosArgs}
+	doc.Attr["fqdn"] = &model.Field{Name: "fqdn", Line: 22, Pos: model.PosAttr}
+	doc.Attr["tags"] = &model.Field{Name: "tags", Line: 24, Pos: model.PosAttr}
+
+	res := checkAttributes(r, doc)
+	if len(res) != 2 {
+		t.Fatalf("expected 2 issues, got %d: %+v", len(res), res)
+	}
+	if item := res[0].(*attributeDiff); item.Key() != "endpoint" || item.Issue != AttributeMissing || item.Line() != 20 {
+		t.Errorf("expected `endpoint` to be missing, got %+v", item)
+	}
+	if item := res[1].(*attributeDiff); item.Key() != "tags" || item.Issue != AttributeNotComputed || item.Line() != 24 {
+		t.Errorf("expected `tags` to not be Computed, got %+v", item)
+	}
+}
+
+func TestCheckImportsCustomImporter(t *testing.T) {
+	// custom Importers can't be validated without running the import, which requires a client
+	r := &schema.Resource{
+		ResourceType: "azurerm_example",
+		Schema: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{},
+			Importer: &sdkschema.ResourceImporter{
+				StateContext: func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
+					t.Fatalf("the Importer shouldn't be called")
+					return nil, nil
+				},
+			},
+		},
+	}
+	doc := &model.ResourceDoc{
+		Imports: []model.Import{
+			{Line: 10, ResourceType: "azurerm_example", ResourceID: "/invalid"},
+		},
+	}
+
+	if res := checkImports(r, doc); len(res) != 0 {
+		t.Fatalf("expected no issues, got %d: %+v", len(res), res)
+	}
+}
@@ -91,4 +91,13 @@ func (r *ResourceDiff) DiffAll() {
 
 	timeouts := diffTimeout(r.tf, r.md)
 	r.Diff = append(r.Diff, timeouts...)
+
+	examples := checkExamples(r.tf, r.md)
+	r.Diff = append(r.Diff, examples...)
+
+	attributes := checkAttributes(r.tf, r.md)
+	r.Diff = append(r.Diff, attributes...)
+
+	imports := checkImports(r.tf, r.md)
+	r.Diff = append(r.Diff, imports...)
 }
@@ -65,7 +65,7 @@ func (d *DiffResult) ToString() string {
 	var count int
 	var possiblevalueMiss int
 	var crossCount, resourceCount int
-	var reqCount, defaultCount, timeoutCount, forceNewCount int
+	var reqCount, defaultCount, timeoutCount, forceNewCount, exampleCount, attributeCount, importCount int
 	var skipCount int
 	for _, diff := range d.result {
 		if len(diff.Diffs()) > 0 {
@@ -92,6 +92,12 @@ func (d *DiffResult) ToString() string {
 					timeoutCount++
 				case forceNewDiff:
 					forceNewCount++
+				case *exampleDiff:
+					exampleCount++
+				case *attributeDiff:
+					attributeCount++
+				case *importDiff:
+					importCount++
 
 				}
 			}
@@ -116,6 +116,15 @@ func (f *Fixer) TryFix() (err error) {
 			continue
 		}
 
+		// examples, attributes and imports aren't a Markdown field so the line must be fixed as-is
+		switch item.(type) {
+		case *exampleDiff, *attributeDiff, *importDiff:
+			if lines[item.Line()], err = item.Fix(lines[item.Line()]); err != nil {
+				return err
+			}
+			continue
+		}
+
 		lineIdx := item.Line()
 		line := lines[lineIdx]
 
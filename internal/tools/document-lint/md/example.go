@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package md
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+)
+
+// the languages of the code blocks which contain HCL, an empty language is assumed to be HCL
+var hclLanguages = map[string]struct{}{
+	"":          {},
+	"hcl":       {},
+	"terraform": {},
+	"tf":        {},
+}
+
+// examples returns the HCL code blocks within the `## Example Usage` section, code blocks are parsed from the raw
+// content rather than the items, since a comment within the HCL is otherwise treated as a header
+func (m *Mark) examples() (res []model.Example) {
+	if m.content == nil {
+		return nil
+	}
+
+	var (
+		inExample bool
+		inCode    bool
+		isHCL     bool
+		start     int
+		code      []string
+	)
+	for idx, line := range strings.Split(*m.content, "\n") {
+		if inCode {
+			if strings.HasPrefix(line, "```") {
+				if isHCL {
+					res = append(res, model.Example{
+						Line: start,
+						HCL:  strings.Join(code, "\n"),
+					})
+				}
+				inCode = false
+				continue
+			}
+			code = append(code, line)
+			continue
+		}
+
+		if pos := headPos(line); pos != 0 && !strings.HasPrefix(line, "###") {
+			inExample = pos == model.PosExample
+			continue
+		}
+
+		if inExample && strings.HasPrefix(line, "```") {
+			_, isHCL = hclLanguages[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(line, "```")))]
+			inCode = true
+			start = idx + 1
+			code = nil
+		}
+	}
+	return res
+}
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package md
+
+import (
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	content := "# azurerm_resource_group\n" +
+		"\n" +
+		"## Example Usage\n" +
+		"\n" +
+		"```hcl\n" +
+		"# a comment which is not a header\n" +
+		"resource \"azurerm_resource_group\" \"example\" {\n" +
+		"}\n" +
+		"```\n" +
+		"\n" +
+		"```shell\n" +
+		"az group list\n" +
+		"```\n" +
+		"\n" +
+		"## Import\n" +
+		"\n" +
+		"```shell\n" +
+		"terraform import azurerm_resource_group.example /subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1\n" +
+		"```\n"
+
+	examples := newMarkFromString(content, "").examples()
+	if len(examples) != 1 {
+		t.Fatalf("expected 1 example, got %d: %+v", len(examples), examples)
+	}
+	if examples[0].Line != 5 {
+		t.Fatalf("expected the example to start at line index 5, got %d", examples[0].Line)
+	}
+	if expected := "# a comment which is not a header\nresource \"azurerm_resource_group\" \"example\" {\n}"; examples[0].HCL != expected {
+		t.Fatalf("expected %q, got %q", expected, examples[0].HCL)
+	}
+}
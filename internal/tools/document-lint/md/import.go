@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package md
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+)
+
+// posLines returns the line index of the (first) header of each section in the document
+func (m *Mark) posLines() map[model.PosType]int {
+	res := map[model.PosType]int{}
+	for _, item := range m.Items {
+		if item.Type != ItemHeader2 {
+			continue
+		}
+		if pos := headPos(item.lines[0]); pos != 0 && pos != model.PosOther {
+			if _, ok := res[pos]; !ok {
+				res[pos] = item.FromLine
+			}
+		}
+	}
+	return res
+}
+
+// imports returns each `terraform import` command within the `## Import` section
+func (m *Mark) imports() (res []model.Import) {
+	if m.content == nil {
+		return nil
+	}
+
+	var inImport bool
+	for idx, line := range strings.Split(*m.content, "\n") {
+		if strings.HasPrefix(line, "##") && !strings.HasPrefix(line, "###") {
+			inImport = headPos(line) == model.PosImport
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if !inImport || !strings.HasPrefix(trimmed, "terraform import ") {
+			continue
+		}
+
+		// terraform import azurerm_resource_group.example /subscriptions/.../resourceGroups/group1
+		parts := strings.SplitN(strings.TrimSpace(strings.TrimPrefix(trimmed, "terraform import ")), " ", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		resourceType := parts[0]
+		if idx := strings.Index(resourceType, "."); idx > 0 {
+			resourceType = resourceType[:idx]
+		}
+		res = append(res, model.Import{
+			Line:         idx,
+			ResourceType: resourceType,
+			ResourceID:   strings.Trim(strings.TrimSpace(parts[1]), `"'`),
+		})
+	}
+	return res
+}
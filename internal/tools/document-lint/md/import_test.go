@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package md
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+)
+
+func TestImports(t *testing.T) {
+	content := "# azurerm_resource_group\n" +
+		"\n" +
+		"## Import\n" +
+		"\n" +
+		"Resource Groups can be imported using the `resource id`, e.g.\n" +
+		"\n" +
+		"```shell\n" +
+		"terraform import azurerm_resource_group.example \"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1\"\n" +
+		"```\n"
+
+	mark := newMarkFromString(content, "")
+	imports := mark.imports()
+	if len(imports) != 1 {
+		t.Fatalf("expected 1 import, got %d: %+v", len(imports), imports)
+	}
+	if imports[0].Line != 7 || imports[0].ResourceType != "azurerm_resource_group" || imports[0].ResourceID != "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1" {
+		t.Fatalf("unexpected import %+v", imports[0])
+	}
+	if line := mark.posLines()[model.PosImport]; line != 2 {
+		t.Fatalf("expected the import section to start at line index 2, got %d", line)
+	}
+}
@@ -365,6 +365,11 @@ func (m *Mark) BuildResourceDoc() *model.ResourceDoc {
 	}
 
 	doc.ResourceName = m.ResourceType
+	doc.Examples = m.examples()
+	doc.Imports = m.imports()
+	for pos, line := range m.posLines() {
+		doc.PosLines[pos] = line
+	}
 	for _, item := range m.Items {
 		if item.Type == ItemExample {
 			doc.ExampleHCL = item.content()
@@ -192,7 +192,14 @@ type Timeouts struct {
 	Delete Timeout
 }
 
+// Example is a code block within the `## Example Usage` section
+type Example struct {
+	Line int // line index of the first line of HCL in the document
+	HCL  string
+}
+
 type Import struct {
+	Line         int // line index of the `terraform import` command in the document
 	ResourceType string
 	ResourceID   string
 }
@@ -236,8 +243,10 @@ type ResourceDoc struct {
 	Args         Properties
 	Attr         Properties
 	ExampleHCL   string
+	Examples     []Example
 	Timeouts     *Timeouts // nil if no timeouts part in document
-	Import       Import
+	Imports      []Import
+	PosLines     map[PosType]int // line index of the header of each section
 
 	Blocks map[string]Properties // two pass get all blocks
 
@@ -359,5 +368,6 @@ func NewResourceDoc() *ResourceDoc {
 		Attr:           Properties{},
 		PossibleValues: map[string]PossibleValue{},
 		Blocks:         map[string]Properties{},
+		PosLines:       map[PosType]int{},
 	}
 }
@@ -4,28 +4,12 @@
 package schema
 
 import (
-	"reflect"
-	"runtime"
-	"strings"
-
-	gomonkey "github.com/agiledragon/gomonkey/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/possiblevalues"
 )
 
-func patchPossibleValuesFn() {
-	gomonkey.ApplyFunc(validation.StringInSlice,
-		func(valid []string, ignoreCase bool) schema.SchemaValidateFunc { //nolint:staticcheck
-			return func(i interface{}, k string) (warnings []string, errors []error) {
-				var res []string // must have a copy
-				res = append(res, valid...)
-				return res, nil
-			}
-		})
-}
-
 func init() {
-	patchPossibleValuesFn()
+	possiblevalues.Patch()
 }
 
 func (r *Resource) FindAllInSlicePropByMonkey() {
@@ -37,11 +21,7 @@ func (r *Resource) FindAllInSlicePropByMonkey() {
 func (r *Resource) InSlicePropByMonkey(name string, item *schema.Schema) {
 	if item.ValidateFunc != nil {
 		// check if it is StringsInSlice
-		pc := reflect.ValueOf(item.ValidateFunc).Pointer()
-		fn := runtime.FuncForPC(pc).Name()
-		// ValidateFunc may direct use sdk v2.StringInSlice then function name will be patchPossibleValuesFn
-		if strings.Contains(fn, "patchPossibleValuesFn") || strings.Contains(fn, "StringInSlice") {
-			values, _ := item.ValidateFunc(nil, "")
+		if values := possiblevalues.FromValidateFunc(item.ValidateFunc); values != nil {
 			r.PossibleValues[name] = values
 		}
 	}
@@ -24,3 +24,15 @@ func PointerFileLine(v reflect.Value) (file string, line int) {
 	file, line = runtime.FuncForPC(pc).FileLine(pc)
 	return
 }
+
+// FuncName returns the fully qualified name of the function, e.g. `github.com/org/repo/pkg.Func.func1` for a closure
+func FuncName(f interface{}) string {
+	vf := reflect.ValueOf(f)
+	if vf.Kind() != reflect.Func || vf.IsNil() {
+		return ""
+	}
+	if fn := runtime.FuncForPC(vf.Pointer()); fn != nil {
+		return fn.Name()
+	}
+	return ""
+}
@@ -0,0 +1,1603 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// NOTE: since we're using `go run` for these tools all of the code needs to live within the main.go
+
+func main() {
+	f := flag.NewFlagSet("generator-typed-resource", flag.ExitOnError)
+
+	resourceType := f.String("name", "", "The name of the Resource which should be generated (e.g. `azurerm_dev_center_project`)")
+	sdkPackage := f.String("sdk-package", "", "The import path of the go-azure-sdk package containing the API (e.g. `github.com/hashicorp/go-azure-sdk/resource-manager/devcenter/2023-04-01/projects`)")
+	vendorPath := f.String("vendor-path", "../../../vendor", "The path to the `vendor` directory containing the go-azure-sdk package")
+	clientPath := f.String("client", "", "The path to the SDK Client within the Provider's Client (e.g. `DevCenter.V20230401.Projects`)")
+	servicePackage := f.String("service-package", "", "The name of the Service Package which the Resource is generated into (e.g. `devcenter`)")
+	outputPath := f.String("output-path", "", "The path to the Service Package which the Resource should be generated into (e.g. `../../services/devcenter`)")
+	modelName := f.String("model", "", "The name of the SDK Model used to create the Resource (defaults to the Model accepted by the Create method)")
+	attributes := f.String("attributes", "", "A comma-separated list of SDK Fields which are Read-Only and should be exposed as Attributes (e.g. `DevCenterUri`)")
+	arguments := f.String("arguments", "", "A comma-separated list of SDK Fields which appear to be Read-Only but should be exposed as Arguments (e.g. `SyncState`)")
+
+	_ = f.Parse(os.Args[1:])
+
+	quitWithError := func(message string) {
+		log.Print(message)
+		os.Exit(1)
+	}
+
+	if *resourceType == "" || !strings.HasPrefix(*resourceType, "azurerm_") {
+		quitWithError("The name of the Resource (prefixed with `azurerm_`) must be specified via `-name`")
+		return
+	}
+	if *sdkPackage == "" {
+		quitWithError("The import path of the go-azure-sdk package must be specified via `-sdk-package`")
+		return
+	}
+	if *clientPath == "" {
+		quitWithError("The path to the SDK Client must be specified via `-client`")
+		return
+	}
+	if *servicePackage == "" {
+		quitWithError("The name of the Service Package must be specified via `-service-package`")
+		return
+	}
+	if *outputPath == "" {
+		quitWithError("The path to the Service Package must be specified via `-output-path`")
+		return
+	}
+
+	input := generatorInput{
+		ResourceType:   *resourceType,
+		SdkImportPath:  *sdkPackage,
+		ClientPath:     *clientPath,
+		ServicePackage: *servicePackage,
+		ModelName:      *modelName,
+		Attributes:     splitList(*attributes),
+		Arguments:      splitList(*arguments),
+	}
+	if err := run(input, filepath.Join(*vendorPath, filepath.FromSlash(*sdkPackage)), *outputPath); err != nil {
+		quitWithError(err.Error())
+	}
+}
+
+type generatorInput struct {
+	// ResourceType is the name of the Resource in Terraform, e.g. `azurerm_dev_center_project`
+	ResourceType string
+
+	// SdkImportPath is the import path of the go-azure-sdk package
+	SdkImportPath string
+
+	// ClientPath is the path to the SDK Client within `clients.Client`, e.g. `DevCenter.V20230401.Projects`
+	ClientPath string
+
+	// ServicePackage is the name of the Go package the Resource is generated into
+	ServicePackage string
+
+	// ModelName optionally overrides the SDK Model used to create the Resource
+	ModelName string
+
+	// Attributes is a list of SDK Fields which are Read-Only and should be exposed as Attributes
+	Attributes []string
+
+	// Arguments is a list of SDK Fields which appear to be Read-Only (see `readOnlyFieldSuffixes`) but which should
+	// be exposed as Arguments
+	Arguments []string
+}
+
+func run(input generatorInput, sdkPath, outputPath string) error {
+	pkg, err := parseSdkPackage(sdkPath)
+	if err != nil {
+		return fmt.Errorf("parsing the SDK Package at %q: %+v", sdkPath, err)
+	}
+
+	resource, err := buildResource(input, pkg)
+	if err != nil {
+		return fmt.Errorf("building the Resource %q: %+v", input.ResourceType, err)
+	}
+
+	fileName := strings.TrimPrefix(input.ResourceType, "azurerm_")
+	files := map[string]*template.Template{
+		fmt.Sprintf("%s_resource_gen.go", fileName):      resourceTemplate,
+		fmt.Sprintf("%s_resource_gen_test.go", fileName): resourceTestTemplate,
+	}
+	for name, tpl := range files {
+		path := filepath.Join(outputPath, name)
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("the file %q already exists - remove it to regenerate the Resource", path)
+		}
+
+		content, err := renderTemplate(tpl, resource)
+		if err != nil {
+			return fmt.Errorf("rendering %q: %+v", name, err)
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return fmt.Errorf("writing %q: %+v", path, err)
+		}
+		log.Printf("[DEBUG] Generated %q", path)
+	}
+
+	for _, todo := range resource.Todos {
+		log.Printf("[WARN] %s", todo)
+	}
+
+	return nil
+}
+
+// renderTemplate renders the template and formats the output using `gofmt`
+func renderTemplate(tpl *template.Template, resource *resourceDefinition) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, resource); err != nil {
+		return nil, err
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting the generated code: %+v\n\n%s", err, buf.String())
+	}
+	return formatted, nil
+}
+
+// SDK Package
+
+type sdkPackage struct {
+	Name string
+
+	// Clients is a map of the Client Type name to the Methods defined on that Client
+	Clients map[string]map[string]sdkMethod
+
+	// Constants is a map of the Constant Type name to the values of that Constant
+	Constants map[string][]string
+
+	// Models is a map of the Model name to the Fields within that Model
+	Models map[string][]sdkField
+
+	// ResourceIds is a map of the Resource ID Type name to the names of the Segments within it
+	ResourceIds map[string][]string
+}
+
+type sdkMethod struct {
+	Name string
+
+	// Parameters are the types of the parameters for this Method, excluding the `context.Context`
+	Parameters []string
+}
+
+type sdkField struct {
+	Name string
+	Type string
+}
+
+func parseSdkPackage(path string) (*sdkPackage, error) {
+	fileSet := token.NewFileSet()
+	packages, err := parser.ParseDir(fileSet, path, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(packages) != 1 {
+		return nil, fmt.Errorf("expected a single Go package but got %d", len(packages))
+	}
+
+	output := sdkPackage{
+		Clients:     map[string]map[string]sdkMethod{},
+		Constants:   map[string][]string{},
+		Models:      map[string][]sdkField{},
+		ResourceIds: map[string][]string{},
+	}
+	structs := map[string]*ast.StructType{}
+	functions := map[string]struct{}{}
+	for name, pkg := range packages {
+		output.Name = name
+
+		fileNames := make([]string, 0, len(pkg.Files))
+		for fileName := range pkg.Files {
+			fileNames = append(fileNames, fileName)
+		}
+		sort.Strings(fileNames)
+
+		for _, fileName := range fileNames {
+			for _, decl := range pkg.Files[fileName].Decls {
+				switch v := decl.(type) {
+				case *ast.FuncDecl:
+					if v.Recv == nil {
+						functions[v.Name.Name] = struct{}{}
+						continue
+					}
+					receiver := typeToString(v.Recv.List[0].Type)
+					if !strings.HasSuffix(receiver, "Client") {
+						continue
+					}
+					method := sdkMethod{
+						Name: v.Name.Name,
+					}
+					for _, param := range v.Type.Params.List {
+						paramType := typeToString(param.Type)
+						if paramType == "context.Context" {
+							continue
+						}
+						for i := 0; i < max(len(param.Names), 1); i++ {
+							method.Parameters = append(method.Parameters, paramType)
+						}
+					}
+					if _, ok := output.Clients[receiver]; !ok {
+						output.Clients[receiver] = map[string]sdkMethod{}
+					}
+					output.Clients[receiver][method.Name] = method
+
+				case *ast.GenDecl:
+					for _, spec := range v.Specs {
+						switch s := spec.(type) {
+						case *ast.TypeSpec:
+							if st, ok := s.Type.(*ast.StructType); ok {
+								structs[s.Name.Name] = st
+							}
+
+						case *ast.ValueSpec:
+							if v.Tok != token.CONST || s.Type == nil {
+								continue
+							}
+							constantType := typeToString(s.Type)
+							for _, value := range s.Values {
+								if lit, ok := value.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+									unquoted, err := strconv.Unquote(lit.Value)
+									if err != nil {
+										return nil, fmt.Errorf("parsing the value for the constant %q: %+v", constantType, err)
+									}
+									output.Constants[constantType] = append(output.Constants[constantType], unquoted)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for name, st := range structs {
+		fields := make([]sdkField, 0)
+		for _, field := range st.Fields.List {
+			for _, fieldName := range field.Names {
+				fields = append(fields, sdkField{
+					Name: fieldName.Name,
+					Type: typeToString(field.Type),
+				})
+			}
+		}
+
+		// Resource IDs are the structs with a matching `NewXID` function
+		if strings.HasSuffix(name, "Id") {
+			if _, ok := functions[fmt.Sprintf("New%sID", strings.TrimSuffix(name, "Id"))]; ok {
+				segments := make([]string, 0, len(fields))
+				for _, field := range fields {
+					segments = append(segments, field.Name)
+				}
+				output.ResourceIds[name] = segments
+				continue
+			}
+		}
+
+		output.Models[name] = fields
+	}
+
+	// Constants are only useful when they can be validated using the `PossibleValuesForX` function
+	for name := range output.Constants {
+		if _, ok := functions[fmt.Sprintf("PossibleValuesFor%s", name)]; !ok {
+			delete(output.Constants, name)
+		}
+	}
+
+	return &output, nil
+}
+
+func typeToString(input ast.Expr) string {
+	switch v := input.(type) {
+	case *ast.Ident:
+		return v.Name
+	case *ast.StarExpr:
+		return "*" + typeToString(v.X)
+	case *ast.SelectorExpr:
+		return typeToString(v.X) + "." + v.Sel.Name
+	case *ast.ArrayType:
+		return "[]" + typeToString(v.Elt)
+	case *ast.MapType:
+		return fmt.Sprintf("map[%s]%s", typeToString(v.Key), typeToString(v.Value))
+	case *ast.InterfaceType:
+		return "interface{}"
+	}
+	return fmt.Sprintf("%T", input)
+}
+
+// Resource Definition
+
+type resourceDefinition struct {
+	ResourceType   string
+	ResourceName   string
+	ServicePackage string
+	SdkImportPath  string
+	SdkPackage     string
+	ClientPath     string
+
+	IdType      string
+	IdNewFunc   string
+	IdParseFunc string
+	IdValidate  string
+
+	// IdArguments are the expressions used to build the Resource ID during Create
+	IdArguments []string
+
+	// ParentId is set when the Resource ID is built from the Resource ID of a Parent Resource within the SDK Package
+	ParentId *parentIdDefinition
+
+	CreateMethod  string
+	DeleteMethod  string
+	UpdateMethod  string
+	CreateModel   string
+	UpdateModel   string
+	HasLocation   bool
+	HasTags       bool
+	HasTagsUpdate bool
+	Identity      *identityDefinition
+
+	// UpdateUsesCreate is set when there's no separate Update method, meaning the existing resource is retrieved and
+	// then updated using the Create method
+	UpdateUsesCreate bool
+
+	// IdFields are the fields within the Schema which make up the Resource ID
+	IdFields []schemaField
+
+	// Fields are the fields within the Schema which map to the SDK Model
+	Fields []schemaField
+
+	// PropertiesType is the name of the SDK Model for the `Properties` field of the Create Model, if any
+	PropertiesType string
+
+	// UpdatePropertiesType is the name of the SDK Model for the `Properties` field of the Update Model, if any
+	UpdatePropertiesType string
+
+	Todos []string
+}
+
+type parentIdDefinition struct {
+	SchemaName  string
+	ModelField  string
+	ParseFunc   string
+	NewFunc     string
+	ValidateFun string
+	Segments    []string
+}
+
+type identityDefinition struct {
+	Schema       string
+	ModelType    string
+	ExpandFunc   string
+	FlattenFunc  string
+	FlattenError bool
+	FlattenPtr   bool
+}
+
+// identityTypes maps the Identity types within `go-azure-helpers` to the related Schema and Expand/Flatten functions
+var identityTypes = map[string]identityDefinition{
+	"identity.LegacySystemAndUserAssignedMap": {
+		Schema:       "commonschema.SystemAssignedUserAssignedIdentityOptional()",
+		ModelType:    "[]identity.ModelSystemAssignedUserAssigned",
+		ExpandFunc:   "identity.ExpandLegacySystemAndUserAssignedMapFromModel",
+		FlattenFunc:  "identity.FlattenLegacySystemAndUserAssignedMapToModel",
+		FlattenError: true,
+	},
+	"identity.SystemAndUserAssignedList": {
+		Schema:       "commonschema.SystemAssignedUserAssignedIdentityOptional()",
+		ModelType:    "[]identity.ModelSystemAssignedUserAssigned",
+		ExpandFunc:   "identity.ExpandSystemAndUserAssignedListFromModel",
+		FlattenFunc:  "identity.FlattenSystemAndUserAssignedListToModel",
+		FlattenError: true,
+		FlattenPtr:   true,
+	},
+	"identity.SystemAndUserAssignedMap": {
+		Schema:       "commonschema.SystemAssignedUserAssignedIdentityOptional()",
+		ModelType:    "[]identity.ModelSystemAssignedUserAssigned",
+		ExpandFunc:   "identity.ExpandSystemAndUserAssignedMapFromModel",
+		FlattenFunc:  "identity.FlattenSystemAndUserAssignedMapToModel",
+		FlattenError: true,
+		FlattenPtr:   true,
+	},
+	"identity.SystemAssigned": {
+		Schema:      "commonschema.SystemAssignedIdentityOptional()",
+		ModelType:   "[]identity.ModelSystemAssigned",
+		ExpandFunc:  "identity.ExpandSystemAssignedFromModel",
+		FlattenFunc: "identity.FlattenSystemAssignedToModel",
+	},
+	"identity.SystemOrUserAssignedList": {
+		Schema:       "commonschema.SystemOrUserAssignedIdentityOptional()",
+		ModelType:    "[]identity.ModelSystemAssignedUserAssigned",
+		ExpandFunc:   "identity.ExpandSystemOrUserAssignedListFromModel",
+		FlattenFunc:  "identity.FlattenSystemAssignedOrUserAssignedListToModel",
+		FlattenError: true,
+		FlattenPtr:   true,
+	},
+	"identity.SystemOrUserAssignedMap": {
+		Schema:       "commonschema.SystemOrUserAssignedIdentityOptional()",
+		ModelType:    "[]identity.ModelSystemAssignedUserAssigned",
+		ExpandFunc:   "identity.ExpandSystemOrUserAssignedMapFromModel",
+		FlattenFunc:  "identity.FlattenSystemOrUserAssignedMapToModel",
+		FlattenError: true,
+		FlattenPtr:   true,
+	},
+	"identity.UserAssignedList": {
+		Schema:       "commonschema.UserAssignedIdentityOptional()",
+		ModelType:    "[]identity.ModelUserAssigned",
+		ExpandFunc:   "identity.ExpandUserAssignedListFromModel",
+		FlattenFunc:  "identity.FlattenUserAssignedListToModel",
+		FlattenError: true,
+		FlattenPtr:   true,
+	},
+	"identity.UserAssignedMap": {
+		Schema:       "commonschema.UserAssignedIdentityOptional()",
+		ModelType:    "[]identity.ModelUserAssigned",
+		ExpandFunc:   "identity.ExpandUserAssignedMapFromModel",
+		FlattenFunc:  "identity.FlattenUserAssignedMapToModel",
+		FlattenError: true,
+		FlattenPtr:   true,
+	},
+}
+
+type schemaField struct {
+	SchemaName string
+	ModelField string
+	ModelType  string
+
+	// Schema is the Go expression (or the body of the `pluginsdk.Schema` struct) defining this field
+	Schema string
+
+	// SdkField is the name of the field within the SDK Model
+	SdkField string
+
+	// Expand is the Go expression used to map the Schema Model field into the SDK Model field
+	Expand string
+
+	// Flatten is the Go expression used to map the SDK Model field into the Schema Model field
+	Flatten string
+
+	// ExpandCondition is the Go expression used to determine whether an Optional field has been specified in the
+	// configuration, since only the fields which have been specified should be sent to the API
+	ExpandCondition string
+
+	// UpdateExpand is the Go expression used to map the Schema Model field into the SDK Update Model field
+	UpdateExpand string
+
+	Required  bool
+	Computed  bool
+	ForceNew  bool
+	TestValue string
+}
+
+// ignoredFields are the fields within SDK Models which aren't exposed in the Schema
+var ignoredFields = map[string]struct{}{
+	"Etag":              {},
+	"Id":                {},
+	"Name":              {},
+	"ProvisioningState": {},
+	"SystemData":        {},
+	"Type":              {},
+}
+
+// readOnlyFieldPrefixes and readOnlyFieldSuffixes are the prefixes/suffixes of SDK Fields which are conventionally
+// Read-Only within the Azure APIs - since the SDK doesn't contain whether a field is Read-Only, these are exposed as
+// Attributes (unless they're within the Update Model or are specified using `-arguments`)
+var (
+	readOnlyFieldPrefixes = []string{"Last"}
+	readOnlyFieldSuffixes = []string{"Fqdn", "State", "Status", "Uri", "Url"}
+)
+
+// isReadOnlyField returns whether the SDK Field `name` is conventionally Read-Only
+func isReadOnlyField(name string) bool {
+	for _, prefix := range readOnlyFieldPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	for _, suffix := range readOnlyFieldSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func buildResource(input generatorInput, pkg *sdkPackage) (*resourceDefinition, error) {
+	output := resourceDefinition{
+		ResourceType:   input.ResourceType,
+		ResourceName:   convertToPascalCase(strings.TrimPrefix(input.ResourceType, "azurerm_")),
+		ServicePackage: input.ServicePackage,
+		SdkImportPath:  input.SdkImportPath,
+		SdkPackage:     pkg.Name,
+		ClientPath:     input.ClientPath,
+	}
+
+	if len(pkg.Clients) != 1 {
+		return nil, fmt.Errorf("expected a single Client within the SDK Package but got %d", len(pkg.Clients))
+	}
+	var methods map[string]sdkMethod
+	for _, v := range pkg.Clients {
+		methods = v
+	}
+
+	get, ok := methods["Get"]
+	if !ok || len(get.Parameters) == 0 {
+		return nil, fmt.Errorf("the SDK Client doesn't contain a `Get` method")
+	}
+	output.IdType = get.Parameters[0]
+	segments, ok := pkg.ResourceIds[output.IdType]
+	if !ok {
+		return nil, fmt.Errorf("the Resource ID %q used by the `Get` method wasn't found within the SDK Package", output.IdType)
+	}
+	idName := strings.TrimSuffix(output.IdType, "Id")
+	output.IdNewFunc = fmt.Sprintf("New%sID", idName)
+	output.IdParseFunc = fmt.Sprintf("Parse%sID", idName)
+	output.IdValidate = fmt.Sprintf("Validate%sID", idName)
+
+	output.CreateMethod = findMethod(methods, "CreateOrUpdateThenPoll", "CreateOrUpdate", "CreateThenPoll", "Create", "PutThenPoll", "Put")
+	if output.CreateMethod == "" {
+		return nil, fmt.Errorf("the SDK Client doesn't contain a Create method")
+	}
+	output.DeleteMethod = findMethod(methods, "DeleteThenPoll", "Delete")
+	if output.DeleteMethod == "" {
+		return nil, fmt.Errorf("the SDK Client doesn't contain a Delete method")
+	}
+
+	createMethod := methods[output.CreateMethod]
+	if len(createMethod.Parameters) < 2 {
+		return nil, fmt.Errorf("expected the %q method to accept a Resource ID and a Model", output.CreateMethod)
+	}
+	output.CreateModel = createMethod.Parameters[1]
+	if input.ModelName != "" {
+		output.CreateModel = input.ModelName
+	}
+	model, ok := pkg.Models[output.CreateModel]
+	if !ok {
+		return nil, fmt.Errorf("the Model %q wasn't found within the SDK Package", output.CreateModel)
+	}
+
+	if updateMethod := findMethod(methods, "UpdateThenPoll", "Update", "PatchThenPoll", "Patch"); updateMethod != "" {
+		output.UpdateMethod = updateMethod
+		output.UpdateModel = methods[updateMethod].Parameters[1]
+	} else if strings.HasPrefix(output.CreateMethod, "CreateOrUpdate") || strings.HasPrefix(output.CreateMethod, "Put") {
+		output.UpdateMethod = output.CreateMethod
+		output.UpdateModel = output.CreateModel
+		output.UpdateUsesCreate = true
+	}
+
+	output.buildIdFields(segments, pkg)
+
+	attributes := map[string]struct{}{}
+	for _, attr := range input.Attributes {
+		attributes[attr] = struct{}{}
+	}
+	arguments := map[string]struct{}{}
+	for _, arg := range input.Arguments {
+		arguments[arg] = struct{}{}
+	}
+
+	usedNames := map[string]struct{}{}
+	for _, field := range output.IdFields {
+		usedNames[field.SchemaName] = struct{}{}
+	}
+
+	updateFields := map[string]string{}
+	if output.UpdateModel != "" && !output.UpdateUsesCreate {
+		for _, field := range pkg.Models[output.UpdateModel] {
+			switch {
+			case field.Name == "Tags":
+				output.HasTagsUpdate = true
+			case field.Name == "Properties":
+				output.UpdatePropertiesType = strings.TrimPrefix(field.Type, "*")
+				for _, prop := range pkg.Models[output.UpdatePropertiesType] {
+					updateFields["Properties."+prop.Name] = prop.Type
+				}
+			default:
+				updateFields[field.Name] = field.Type
+			}
+		}
+	}
+
+	for _, field := range model {
+		if _, ignored := ignoredFields[field.Name]; ignored {
+			continue
+		}
+
+		switch field.Name {
+		case "Location":
+			output.HasLocation = true
+			continue
+
+		case "Tags":
+			output.HasTags = true
+			continue
+
+		case "Identity":
+			definition, ok := identityTypes[strings.TrimPrefix(field.Type, "*")]
+			if !ok {
+				output.Todos = append(output.Todos, fmt.Sprintf("the Identity type %q isn't supported and must be mapped manually", field.Type))
+				continue
+			}
+			output.Identity = &definition
+			continue
+
+		case "Properties":
+			output.PropertiesType = strings.TrimPrefix(field.Type, "*")
+			properties, ok := pkg.Models[output.PropertiesType]
+			if !ok {
+				output.Todos = append(output.Todos, fmt.Sprintf("the Model %q for the field `Properties` wasn't found and must be mapped manually", field.Type))
+				continue
+			}
+			for _, property := range properties {
+				if _, ignored := ignoredFields[property.Name]; ignored {
+					continue
+				}
+				output.addField(pkg, property, "Properties.", attributes, arguments, updateFields, usedNames)
+			}
+			continue
+		}
+
+		output.addField(pkg, field, "", attributes, arguments, updateFields, usedNames)
+	}
+
+	return &output, nil
+}
+
+func findMethod(methods map[string]sdkMethod, names ...string) string {
+	for _, name := range names {
+		if method, ok := methods[name]; ok && len(method.Parameters) > 0 {
+			return name
+		}
+	}
+	return ""
+}
+
+// buildIdFields builds the Schema fields for the Segments of the Resource ID, when the Resource ID of a Parent
+// Resource exists within the SDK Package the Parent Resource ID is exposed, otherwise each Segment is exposed
+func (r *resourceDefinition) buildIdFields(segments []string, pkg *sdkPackage) {
+	nameSegment := segments[len(segments)-1]
+	parentSegments := segments[:len(segments)-1]
+
+	var parentIdType string
+	if len(parentSegments) > 2 {
+		for name, candidate := range pkg.ResourceIds {
+			if reflect.DeepEqual(candidate, parentSegments) {
+				parentIdType = name
+				break
+			}
+		}
+	}
+
+	if parentIdType != "" {
+		parentName := strings.TrimSuffix(parentIdType, "Id")
+		r.ParentId = &parentIdDefinition{
+			SchemaName:  convertToSnakeCase(parentName) + "_id",
+			ModelField:  parentName + "Id",
+			ParseFunc:   fmt.Sprintf("Parse%sID", parentName),
+			NewFunc:     fmt.Sprintf("New%sID", parentName),
+			ValidateFun: fmt.Sprintf("Validate%sID", parentName),
+			Segments:    parentSegments,
+		}
+		r.IdFields = append(r.IdFields, schemaField{
+			SchemaName: r.ParentId.SchemaName,
+			ModelField: r.ParentId.ModelField,
+			ModelType:  "string",
+			Schema:     fmt.Sprintf("{\nType: pluginsdk.TypeString,\nRequired: true,\nForceNew: true,\nValidateFunc: %s.%s,\n}", r.SdkPackage, r.ParentId.ValidateFun),
+			Required:   true,
+			ForceNew:   true,
+			TestValue:  fmt.Sprintf("azurerm_TODO.test.id # TODO: the %s this %s belongs to", parentName, r.ResourceName),
+		})
+		for _, segment := range parentSegments {
+			r.IdArguments = append(r.IdArguments, fmt.Sprintf("parentId.%s", segment))
+		}
+	} else {
+		for _, segment := range parentSegments {
+			switch segment {
+			case "SubscriptionId":
+				r.IdArguments = append(r.IdArguments, "subscriptionId")
+				continue
+
+			case "ResourceGroupName":
+				r.IdFields = append(r.IdFields, schemaField{
+					SchemaName: "resource_group_name",
+					ModelField: "ResourceGroupName",
+					ModelType:  "string",
+					Schema:     "commonschema.ResourceGroupName()",
+					SdkField:   segment,
+					Required:   true,
+					ForceNew:   true,
+					TestValue:  "azurerm_resource_group.test.name",
+				})
+
+			default:
+				r.IdFields = append(r.IdFields, schemaField{
+					SchemaName: convertToSnakeCase(segment),
+					ModelField: segment,
+					ModelType:  "string",
+					Schema:     "{\nType: pluginsdk.TypeString,\nRequired: true,\nForceNew: true,\nValidateFunc: validation.StringIsNotEmpty,\n}",
+					SdkField:   segment,
+					Required:   true,
+					ForceNew:   true,
+					TestValue:  fmt.Sprintf("%q # TODO: the %s", "TODO", segment),
+				})
+			}
+			r.IdArguments = append(r.IdArguments, fmt.Sprintf("config.%s", segment))
+		}
+	}
+
+	// the name is conventionally the first field in the Schema
+	r.IdFields = append([]schemaField{{
+		SchemaName: "name",
+		ModelField: "Name",
+		ModelType:  "string",
+		Schema:     "{\nType: pluginsdk.TypeString,\nRequired: true,\nForceNew: true,\nValidateFunc: validation.StringIsNotEmpty,\n}",
+		SdkField:   nameSegment,
+		Required:   true,
+		ForceNew:   true,
+		TestValue:  `"acctest-${var.random_integer}"`,
+	}}, r.IdFields...)
+	r.IdArguments = append(r.IdArguments, "config.Name")
+}
+
+// addField adds the SDK Field `field` to the Schema when it's a supported type, `prefix` is the path to the field
+// within the SDK Model (e.g. `Properties.`)
+func (r *resourceDefinition) addField(pkg *sdkPackage, field sdkField, prefix string, attributes, arguments map[string]struct{}, updateFields map[string]string, usedNames map[string]struct{}) {
+	schemaName := convertToSnakeCase(field.Name)
+	if _, exists := usedNames[schemaName]; exists {
+		r.Todos = append(r.Todos, fmt.Sprintf("the field %q conflicts with an existing field in the Schema and must be mapped manually", prefix+field.Name))
+		return
+	}
+
+	isPointer := strings.HasPrefix(field.Type, "*")
+	baseType := strings.TrimPrefix(field.Type, "*")
+	_, isComputed := attributes[field.Name]
+	if _, isArgument := arguments[field.Name]; !isArgument && !isComputed && isReadOnlyField(field.Name) {
+		// fields which are conventionally Read-Only are exposed as Attributes, unless they can be updated
+		_, canBeUpdated := updateFields[prefix+field.Name]
+		isComputed = !canBeUpdated
+	}
+
+	output := schemaField{
+		SchemaName: schemaName,
+		ModelField: field.Name,
+		SdkField:   prefix + field.Name,
+		Required:   !isPointer && !isComputed,
+		Computed:   isComputed,
+	}
+
+	input := fmt.Sprintf("input.%s", field.Name)
+	sdkValue := fmt.Sprintf("input.%s%s", prefix, field.Name)
+	var schemaType, validateFunc, elem string
+	switch baseType {
+	case "string":
+		output.ModelType = "string"
+		schemaType = "pluginsdk.TypeString"
+		validateFunc = "validation.StringIsNotEmpty"
+		output.TestValue = `"example"`
+		output.ExpandCondition = fmt.Sprintf(`input.%s != ""`, field.Name)
+	case "int64":
+		output.ModelType = "int64"
+		schemaType = "pluginsdk.TypeInt"
+		output.TestValue = "1"
+	case "bool":
+		output.ModelType = "bool"
+		schemaType = "pluginsdk.TypeBool"
+		output.TestValue = "true"
+	case "float64":
+		output.ModelType = "float64"
+		schemaType = "pluginsdk.TypeFloat"
+		output.TestValue = "1.0"
+	case "[]string":
+		output.ExpandCondition = fmt.Sprintf("len(input.%s) > 0", field.Name)
+		output.ModelType = "[]string"
+		schemaType = "pluginsdk.TypeList"
+		elem = "&pluginsdk.Schema{\nType: pluginsdk.TypeString,\nValidateFunc: validation.StringIsNotEmpty,\n}"
+		output.TestValue = `["example"]`
+	case "map[string]string":
+		output.ExpandCondition = fmt.Sprintf("len(input.%s) > 0", field.Name)
+		output.ModelType = "map[string]string"
+		schemaType = "pluginsdk.TypeMap"
+		elem = "&pluginsdk.Schema{\nType: pluginsdk.TypeString,\n}"
+		output.TestValue = "{\n    example = \"value\"\n  }"
+	default:
+		values, isConstant := pkg.Constants[baseType]
+		if !isConstant {
+			r.Todos = append(r.Todos, fmt.Sprintf("the field %q of type %q isn't supported and must be mapped manually", prefix+field.Name, field.Type))
+			return
+		}
+		output.ModelType = "string"
+		schemaType = "pluginsdk.TypeString"
+		validateFunc = fmt.Sprintf("validation.StringInSlice(%s.PossibleValuesFor%s(), false)", r.SdkPackage, baseType)
+		output.TestValue = strconv.Quote(values[0])
+		output.ExpandCondition = fmt.Sprintf(`input.%s != ""`, field.Name)
+
+		// constants need converting to/from the SDK type
+		input = fmt.Sprintf("%s.%s(input.%s)", r.SdkPackage, baseType, field.Name)
+		if isPointer {
+			output.Flatten = fmt.Sprintf("string(pointer.From(%s))", sdkValue)
+		} else {
+			output.Flatten = fmt.Sprintf("string(%s)", sdkValue)
+		}
+	}
+
+	if isPointer {
+		// only Optional fields which are specified in the configuration are sent to the API, since a zero-value
+		// can be valid for Booleans and Numbers the raw configuration is checked for these
+		if output.ExpandCondition == "" {
+			output.ExpandCondition = fmt.Sprintf("!metadata.ResourceData.GetRawConfig().AsValueMap()[%q].IsNull()", schemaName)
+		}
+		output.Expand = fmt.Sprintf("pointer.To(%s)", input)
+		if output.Flatten == "" {
+			output.Flatten = fmt.Sprintf("pointer.From(%s)", sdkValue)
+		}
+	} else {
+		// Required fields are always sent
+		output.ExpandCondition = ""
+		output.Expand = input
+		if output.Flatten == "" {
+			output.Flatten = sdkValue
+		}
+	}
+
+	if !isComputed && output.UpdateExpandFor(prefix+field.Name, field.Type, updateFields, r) {
+		output.ForceNew = false
+	} else {
+		// fields which can't be updated force a new resource to be created
+		output.ForceNew = !isComputed && !r.UpdateUsesCreate
+	}
+
+	lines := []string{fmt.Sprintf("Type: %s,", schemaType)}
+	switch {
+	case output.Computed:
+		lines = append(lines, "Computed: true,")
+	case output.Required:
+		lines = append(lines, "Required: true,")
+	default:
+		lines = append(lines, "Optional: true,")
+	}
+	if output.ForceNew {
+		lines = append(lines, "ForceNew: true,")
+	}
+	if validateFunc != "" && !output.Computed {
+		lines = append(lines, fmt.Sprintf("ValidateFunc: %s,", validateFunc))
+	}
+	if elem != "" {
+		lines = append(lines, fmt.Sprintf("Elem: %s,", elem))
+	}
+	output.Schema = fmt.Sprintf("{\n%s\n}", strings.Join(lines, "\n"))
+
+	usedNames[schemaName] = struct{}{}
+	r.Fields = append(r.Fields, output)
+}
+
+// UpdateExpandFor populates the UpdateExpand expression when the field `sdkField` exists (with the same type) within
+// the Update Model, returning whether the field can be updated
+func (f *schemaField) UpdateExpandFor(sdkField, sdkType string, updateFields map[string]string, r *resourceDefinition) bool {
+	if r.UpdateUsesCreate {
+		return true
+	}
+
+	updateType, ok := updateFields[sdkField]
+	if !ok {
+		return false
+	}
+	if strings.TrimPrefix(updateType, "*") != strings.TrimPrefix(sdkType, "*") {
+		r.Todos = append(r.Todos, fmt.Sprintf("the field %q is a %q in the Create Model but a %q in the Update Model and must be updated manually", sdkField, sdkType, updateType))
+		return false
+	}
+
+	// the Update Model commonly uses a pointer where the Create Model doesn't, since all fields are optional
+	expand := strings.Replace(f.Expand, "input.", "config.", 1)
+	switch {
+	case strings.HasPrefix(updateType, "*") && !strings.HasPrefix(sdkType, "*"):
+		expand = fmt.Sprintf("pointer.To(%s)", expand)
+	case !strings.HasPrefix(updateType, "*") && strings.HasPrefix(sdkType, "*"):
+		expand = strings.TrimSuffix(strings.TrimPrefix(expand, "pointer.To("), ")")
+	}
+	f.UpdateExpand = expand
+	return true
+}
+
+// Arguments returns the fields within the Schema which can be specified by users
+func (r resourceDefinition) Arguments() []schemaField {
+	output := make([]schemaField, 0)
+	for _, field := range r.Fields {
+		if !field.Computed {
+			output = append(output, field)
+		}
+	}
+	return output
+}
+
+// Attributes returns the fields within the Schema which are Computed
+func (r resourceDefinition) Attributes() []schemaField {
+	output := make([]schemaField, 0)
+	for _, field := range r.Fields {
+		if field.Computed {
+			output = append(output, field)
+		}
+	}
+	return output
+}
+
+// ModelFields returns all of the fields within the Schema Model, sorted by name
+func (r resourceDefinition) ModelFields() []schemaField {
+	output := make([]schemaField, 0)
+	output = append(output, r.IdFields...)
+	if r.HasLocation {
+		output = append(output, schemaField{SchemaName: "location", ModelField: "Location", ModelType: "string"})
+	}
+	if r.HasTags {
+		output = append(output, schemaField{SchemaName: "tags", ModelField: "Tags", ModelType: "map[string]interface{}"})
+	}
+	if r.Identity != nil {
+		output = append(output, schemaField{SchemaName: "identity", ModelField: "Identity", ModelType: r.Identity.ModelType})
+	}
+	output = append(output, r.Fields...)
+
+	sort.Slice(output, func(i, j int) bool {
+		return output[i].ModelField < output[j].ModelField
+	})
+	return output
+}
+
+// HasUpdatableFields returns whether any fields within the Schema can be updated
+func (r resourceDefinition) HasUpdatableFields() bool {
+	if r.UpdateMethod == "" {
+		return false
+	}
+	if r.UpdateUsesCreate {
+		return true
+	}
+	if r.HasTagsUpdate && r.HasTags {
+		return true
+	}
+	for _, field := range r.Arguments() {
+		if field.UpdateExpand != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Results returns the variables which the results of the SDK method `method` are assigned to, since the Long
+// Running Operation helpers (e.g. `CreateOrUpdateThenPoll`) only return an error
+func (r resourceDefinition) Results(method string) string {
+	if strings.HasSuffix(method, "ThenPoll") {
+		return "err"
+	}
+	return "_, err"
+}
+
+// RequiresPointer returns whether the `pointer` package is used by the generated Resource
+func (r resourceDefinition) RequiresPointer() bool {
+	if r.Identity != nil && r.Identity.FlattenPtr {
+		return true
+	}
+	for _, field := range r.Fields {
+		if strings.Contains(field.Expand, "pointer.") || strings.Contains(field.Flatten, "pointer.") || strings.Contains(field.UpdateExpand, "pointer.") {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiresCommonSchema returns whether the `commonschema` package is used by the generated Resource
+func (r resourceDefinition) RequiresCommonSchema() bool {
+	if r.HasLocation || r.HasTags || r.Identity != nil {
+		return true
+	}
+	for _, field := range r.IdFields {
+		if strings.HasPrefix(field.Schema, "commonschema.") {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiresValidation returns whether the `validation` package is used by the generated Resource
+func (r resourceDefinition) RequiresValidation() bool {
+	for _, field := range append(r.IdFields, r.Fields...) {
+		if strings.Contains(field.Schema, "validation.") {
+			return true
+		}
+	}
+	return false
+}
+
+// TestArguments returns the arguments used in the `basic` (or when `complete` is set, `complete`) acceptance test
+func (r resourceDefinition) TestArguments(complete bool) []testArgument {
+	output := make([]testArgument, 0)
+	for _, field := range r.IdFields {
+		output = append(output, testArgument{Name: field.SchemaName, Value: field.TestValue})
+	}
+	if r.HasLocation {
+		output = append(output, testArgument{Name: "location", Value: "azurerm_resource_group.test.location"})
+	}
+	for _, field := range r.Arguments() {
+		if complete || field.Required {
+			output = append(output, testArgument{Name: field.SchemaName, Value: field.TestValue})
+		}
+	}
+	if complete && r.Identity != nil {
+		output = append(output, testArgument{Name: "identity", Value: "{\n    type = \"SystemAssigned\" # TODO: check the supported Identity types\n  }", Block: true})
+	}
+	if complete && r.HasTags {
+		output = append(output, testArgument{Name: "tags", Value: "{\n    environment = \"terraform-acctests\"\n    some_key    = \"some-value\"\n  }"})
+	}
+
+	return alignTestArguments(output)
+}
+
+// RequiresImportArguments returns the arguments used in the `requiresImport` acceptance test
+func (r resourceDefinition) RequiresImportArguments() []testArgument {
+	output := make([]testArgument, 0)
+	for _, field := range r.TestArguments(false) {
+		output = append(output, testArgument{
+			Name:  field.Name,
+			Value: fmt.Sprintf("%s.test.%s", r.ResourceType, field.Name),
+		})
+	}
+	return alignTestArguments(output)
+}
+
+type testArgument struct {
+	Name    string
+	Value   string
+	Block   bool
+	Padding string
+}
+
+// alignTestArguments aligns the `=` for consecutive single-line arguments, matching `terraform fmt`
+func alignTestArguments(input []testArgument) []testArgument {
+	start := 0
+	for i := 0; i <= len(input); i++ {
+		if i < len(input) && !strings.Contains(input[i].Value, "\n") {
+			continue
+		}
+
+		longest := 0
+		for _, arg := range input[start:i] {
+			longest = max(longest, len(arg.Name))
+		}
+		for j := start; j < i; j++ {
+			input[j].Padding = strings.Repeat(" ", longest-len(input[j].Name))
+		}
+		start = i + 1
+	}
+	return input
+}
+
+// Naming
+
+func convertToPascalCase(input string) string {
+	output := ""
+	for _, segment := range strings.Split(input, "_") {
+		if segment == "" {
+			continue
+		}
+		output += strings.ToUpper(segment[:1]) + segment[1:]
+	}
+	return output
+}
+
+// convertToSnakeCase converts a Go identifier into a Schema field name, treating a sequence of upper-case characters
+// as a single word (e.g. `VMSize` becomes `vm_size`)
+func convertToSnakeCase(input string) string {
+	runes := []rune(input)
+	output := make([]rune, 0, len(runes))
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			previousIsLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			nextIsLower := i > 0 && i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1])
+			if previousIsLower || nextIsLower {
+				output = append(output, '_')
+			}
+			output = append(output, unicode.ToLower(r))
+			continue
+		}
+		output = append(output, r)
+	}
+	return string(output)
+}
+
+func splitList(input string) []string {
+	output := make([]string, 0)
+	for _, item := range strings.Split(input, ",") {
+		if v := strings.TrimSpace(item); v != "" {
+			output = append(output, v)
+		}
+	}
+	return output
+}
+
+// Templates
+
+var resourceTemplate = template.Must(template.New("resource").Parse(`// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package {{ .ServicePackage }}
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+{{ if .RequiresPointer }}	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+{{ end }}	"github.com/hashicorp/go-azure-helpers/lang/response"
+{{ if .RequiresCommonSchema }}	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+{{ end }}{{ if .Identity }}	"github.com/hashicorp/go-azure-helpers/resourcemanager/identity"
+{{ end }}{{ if .HasLocation }}	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+{{ end }}{{ if .HasTags }}	"github.com/hashicorp/go-azure-helpers/resourcemanager/tags"
+{{ end }}	"{{ .SdkImportPath }}"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+{{ if .RequiresValidation }}	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+{{ end }})
+
+var _ sdk.Resource = {{ .ResourceName }}Resource{}
+{{ if .HasUpdatableFields }}var _ sdk.ResourceWithUpdate = {{ .ResourceName }}Resource{}
+{{ end }}
+type {{ .ResourceName }}Resource struct{}
+
+type {{ .ResourceName }}ResourceModel struct {
+{{- range .ModelFields }}
+	{{ .ModelField }} {{ .ModelType }} ` + "`tfschema:\"{{ .SchemaName }}\"`" + `
+{{- end }}
+}
+
+func (r {{ .ResourceName }}Resource) ModelObject() interface{} {
+	return &{{ .ResourceName }}ResourceModel{}
+}
+
+func (r {{ .ResourceName }}Resource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return {{ .SdkPackage }}.{{ .IdValidate }}
+}
+
+func (r {{ .ResourceName }}Resource) ResourceType() string {
+	return "{{ .ResourceType }}"
+}
+
+func (r {{ .ResourceName }}Resource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+{{- range .IdFields }}
+		"{{ .SchemaName }}": {{ .Schema }},
+{{ end }}
+{{- if .HasLocation }}
+		"location": commonschema.Location(),
+{{ end }}
+{{- range .Arguments }}
+		"{{ .SchemaName }}": {{ .Schema }},
+{{ end }}
+{{- if .Identity }}
+		"identity": {{ .Identity.Schema }},
+{{ end }}
+{{- if .HasTags }}
+		"tags": commonschema.Tags(),
+{{- end }}
+	}
+}
+
+func (r {{ .ResourceName }}Resource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+{{- range .Attributes }}
+		"{{ .SchemaName }}": {{ .Schema }},
+{{ end }}
+	}
+}
+
+func (r {{ .ResourceName }}Resource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.{{ .ClientPath }}
+{{- if not .ParentId }}
+			subscriptionId := metadata.Client.Account.SubscriptionId
+{{- end }}
+
+			var config {{ .ResourceName }}ResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+{{ if .ParentId }}
+			parentId, err := {{ .SdkPackage }}.{{ .ParentId.ParseFunc }}(config.{{ .ParentId.ModelField }})
+			if err != nil {
+				return err
+			}
+{{ end }}
+			id := {{ .SdkPackage }}.{{ .IdNewFunc }}({{ range $i, $v := .IdArguments }}{{ if $i }}, {{ end }}{{ $v }}{{ end }})
+
+			existing, err := client.Get(ctx, id)
+			if err != nil {
+				if !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
+				}
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			var payload {{ .SdkPackage }}.{{ .CreateModel }}
+			if err := r.expand{{ .CreateModel }}(metadata, config, &payload); err != nil {
+				return fmt.Errorf("expanding %s: %+v", id, err)
+			}
+
+			if {{ .Results .CreateMethod }} := client.{{ .CreateMethod }}(ctx, id, payload); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r {{ .ResourceName }}Resource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.{{ .ClientPath }}
+
+			id, err := {{ .SdkPackage }}.{{ .IdParseFunc }}(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(*id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := {{ .ResourceName }}ResourceModel{
+{{- if .ParentId }}
+				{{ .ParentId.ModelField }}: {{ .SdkPackage }}.{{ .ParentId.NewFunc }}({{ range $i, $v := .ParentId.Segments }}{{ if $i }}, {{ end }}id.{{ $v }}{{ end }}).ID(),
+{{- end }}
+{{- range .IdFields }}{{ if .SdkField }}
+				{{ .ModelField }}: id.{{ .SdkField }},
+{{- end }}{{ end }}
+			}
+
+			if model := resp.Model; model != nil {
+				if err := r.flatten{{ .CreateModel }}(*model, &state); err != nil {
+					return fmt.Errorf("flattening %s: %+v", *id, err)
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+{{ if .HasUpdatableFields }}
+func (r {{ .ResourceName }}Resource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.{{ .ClientPath }}
+
+			id, err := {{ .SdkPackage }}.{{ .IdParseFunc }}(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config {{ .ResourceName }}ResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+{{ if .UpdateUsesCreate }}
+			existing, err := client.Get(ctx, *id)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+			if existing.Model == nil {
+				return fmt.Errorf("retrieving %s: ` + "`model`" + ` was nil", *id)
+			}
+
+			payload := *existing.Model
+			if err := r.expand{{ .CreateModel }}(metadata, config, &payload); err != nil {
+				return fmt.Errorf("expanding %s: %+v", *id, err)
+			}
+
+			if {{ .Results .CreateMethod }} := client.{{ .CreateMethod }}(ctx, *id, payload); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+{{ else }}
+			var payload {{ .SdkPackage }}.{{ .UpdateModel }}
+{{- if and .HasTags .HasTagsUpdate }}
+			if metadata.ResourceData.HasChange("tags") {
+				payload.Tags = tags.Expand(config.Tags)
+			}
+{{- end }}
+{{- range .Arguments }}{{ if .UpdateExpand }}
+			if metadata.ResourceData.HasChange("{{ .SchemaName }}") {
+				{{- if eq (printf "%.11s" .SdkField) "Properties." }}
+				if payload.Properties == nil {
+					payload.Properties = &{{ $.SdkPackage }}.{{ $.UpdatePropertiesType }}{}
+				}
+				{{- end }}
+				payload.{{ .SdkField }} = {{ .UpdateExpand }}
+			}
+{{- end }}{{ end }}
+
+			if {{ .Results .UpdateMethod }} := client.{{ .UpdateMethod }}(ctx, *id, payload); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+{{ end }}
+			return nil
+		},
+	}
+}
+{{ end }}
+func (r {{ .ResourceName }}Resource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.{{ .ClientPath }}
+
+			id, err := {{ .SdkPackage }}.{{ .IdParseFunc }}(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if {{ .Results .DeleteMethod }} := client.{{ .DeleteMethod }}(ctx, *id); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r {{ .ResourceName }}Resource) expand{{ .CreateModel }}(metadata sdk.ResourceMetaData, input {{ .ResourceName }}ResourceModel, output *{{ .SdkPackage }}.{{ .CreateModel }}) error {
+{{- range .Todos }}
+	// TODO: {{ . }}
+{{- end }}
+{{- if .HasLocation }}
+	output.Location = location.Normalize(input.Location)
+{{- end }}
+{{- if .HasTags }}
+	output.Tags = tags.Expand(input.Tags)
+{{- end }}
+{{- if .Identity }}
+
+	expandedIdentity, err := {{ .Identity.ExpandFunc }}(input.Identity)
+	if err != nil {
+		return fmt.Errorf("expanding ` + "`identity`" + `: %+v", err)
+	}
+	output.Identity = expandedIdentity
+{{- end }}
+{{- if .PropertiesType }}
+
+	if output.Properties == nil {
+		output.Properties = &{{ .SdkPackage }}.{{ .PropertiesType }}{}
+	}
+{{- end }}
+{{- range .Arguments }}
+{{- if .ExpandCondition }}
+	if {{ .ExpandCondition }} {
+		output.{{ .SdkField }} = {{ .Expand }}
+	}
+{{- else }}
+	output.{{ .SdkField }} = {{ .Expand }}
+{{- end }}
+{{- end }}
+
+	return nil
+}
+
+func (r {{ .ResourceName }}Resource) flatten{{ .CreateModel }}(input {{ .SdkPackage }}.{{ .CreateModel }}, output *{{ .ResourceName }}ResourceModel) error {
+{{- if .HasLocation }}
+	output.Location = location.Normalize(input.Location)
+{{- end }}
+{{- if .HasTags }}
+	output.Tags = tags.Flatten(input.Tags)
+{{- end }}
+{{- if .Identity }}
+{{ if .Identity.FlattenError }}
+	flattenedIdentity, err := {{ .Identity.FlattenFunc }}(input.Identity)
+	if err != nil {
+		return fmt.Errorf("flattening ` + "`identity`" + `: %+v", err)
+	}
+	output.Identity = {{ if .Identity.FlattenPtr }}pointer.From(flattenedIdentity){{ else }}flattenedIdentity{{ end }}
+{{- else }}
+	output.Identity = {{ .Identity.FlattenFunc }}(input.Identity)
+{{- end }}
+{{- end }}
+{{- if .PropertiesType }}
+
+	if input.Properties == nil {
+		input.Properties = &{{ .SdkPackage }}.{{ .PropertiesType }}{}
+	}
+{{- end }}
+{{- range .Fields }}
+	output.{{ .ModelField }} = {{ .Flatten }}
+{{- end }}
+
+	return nil
+}
+`))
+
+var resourceTestTemplate = template.Must(template.New("resource_test").Parse(`// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package {{ .ServicePackage }}_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"{{ .SdkImportPath }}"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type {{ .ResourceName }}TestResource struct{}
+
+func TestAcc{{ .ResourceName }}_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "{{ .ResourceType }}", "test")
+	r := {{ .ResourceName }}TestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAcc{{ .ResourceName }}_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "{{ .ResourceType }}", "test")
+	r := {{ .ResourceName }}TestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAcc{{ .ResourceName }}_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "{{ .ResourceType }}", "test")
+	r := {{ .ResourceName }}TestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+{{ if .HasUpdatableFields }}
+func TestAcc{{ .ResourceName }}_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "{{ .ResourceType }}", "test")
+	r := {{ .ResourceName }}TestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+{{ end }}
+func (r {{ .ResourceName }}TestResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := {{ .SdkPackage }}.{{ .IdParseFunc }}(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.{{ .ClientPath }}.Get(ctx, *id)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r {{ .ResourceName }}TestResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(` + "`" + `
+%s
+
+resource "{{ .ResourceType }}" "test" {
+{{- range .TestArguments false }}
+  {{ .Name }}{{ .Padding }}{{ if not .Block }} ={{ end }} {{ .Value }}
+{{- end }}
+}
+` + "`" + `, r.template(data))
+}
+
+func (r {{ .ResourceName }}TestResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(` + "`" + `
+%s
+
+resource "{{ .ResourceType }}" "import" {
+{{- range .RequiresImportArguments }}
+  {{ .Name }}{{ .Padding }} = {{ .Value }}
+{{- end }}
+}
+` + "`" + `, r.basic(data))
+}
+
+func (r {{ .ResourceName }}TestResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(` + "`" + `
+%s
+
+resource "{{ .ResourceType }}" "test" {
+{{- range .TestArguments true }}
+  {{ .Name }}{{ .Padding }}{{ if not .Block }} ={{ end }} {{ .Value }}
+{{- end }}
+}
+` + "`" + `, r.template(data))
+}
+
+func (r {{ .ResourceName }}TestResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(` + "`" + `
+provider "azurerm" {
+  features {}
+}
+
+variable "random_integer" {
+  default = %d
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestrg-${var.random_integer}"
+  location = %q
+}
+` + "`" + `, data.RandomInteger, data.Locations.Primary)
+}
+`))
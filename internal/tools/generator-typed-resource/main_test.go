@@ -0,0 +1,209 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const devCenterSdkPath = "github.com/hashicorp/go-azure-sdk/resource-manager/devcenter/2023-04-01"
+
+func TestConvertToSnakeCase(t *testing.T) {
+	cases := []struct {
+		in  string
+		out string
+	}{
+		{
+			"Description",
+			"description",
+		},
+		{
+			"DevCenterUri",
+			"dev_center_uri",
+		},
+		{
+			"VMSize",
+			"vm_size",
+		},
+		{
+			"PublicIPAddressId",
+			"public_ip_address_id",
+		},
+		{
+			"Sku2Name",
+			"sku2_name",
+		},
+	}
+
+	for idx, c := range cases {
+		out := convertToSnakeCase(c.in)
+		if c.out != out {
+			t.Fatalf("%d. %q (expect) != %q (actual)", idx, c.out, out)
+		}
+	}
+}
+
+func TestGenerateResourceWithSeparateUpdate(t *testing.T) {
+	input := generatorInput{
+		ResourceType:   "azurerm_dev_center_project",
+		SdkImportPath:  devCenterSdkPath + "/projects",
+		ClientPath:     "DevCenter.V20230401.Projects",
+		ServicePackage: "devcenter",
+	}
+	resource, test := generate(t, input)
+
+	expectedResource := []string{
+		"type DevCenterProjectResource struct{}",
+		"var _ sdk.ResourceWithUpdate = DevCenterProjectResource{}",
+		"return projects.ValidateProjectID",
+		"id := projects.NewProjectID(subscriptionId, config.ResourceGroupName, config.Name)",
+		"client.CreateOrUpdateThenPoll(ctx, id, payload)",
+		"client.UpdateThenPoll(ctx, *id, payload)",
+		"client.DeleteThenPoll(ctx, *id)",
+		"Name:              id.ProjectName,",
+		`"resource_group_name": commonschema.ResourceGroupName(),`,
+		"if !metadata.ResourceData.GetRawConfig().AsValueMap()[\"max_dev_boxes_per_user\"].IsNull() {\n\t\toutput.Properties.MaxDevBoxesPerUser = pointer.To(input.MaxDevBoxesPerUser)\n\t}",
+		"if input.Description != \"\" {\n\t\toutput.Properties.Description = pointer.To(input.Description)\n\t}",
+		"output.Properties.DevCenterId = input.DevCenterId",
+		"output.DevCenterUri = pointer.From(input.Properties.DevCenterUri)",
+		"payload.Properties = &projects.ProjectUpdateProperties{}",
+		"payload.Properties.MaxDevBoxesPerUser = pointer.To(config.MaxDevBoxesPerUser)",
+		"payload.Properties.DevCenterId = pointer.To(config.DevCenterId)",
+		"payload.Tags = tags.Expand(config.Tags)",
+	}
+	for _, v := range expectedResource {
+		if !strings.Contains(resource, v) {
+			t.Fatalf("expected the generated Resource to contain %q:\n\n%s", v, resource)
+		}
+	}
+
+	// the DevCenterUri is conventionally Read-Only so is an Attribute by default, whereas the remaining fields can be updated
+	expectedSchema := map[string]string{
+		"description":            "Type:         pluginsdk.TypeString,\n\t\t\tOptional:     true,\n\t\t\tValidateFunc: validation.StringIsNotEmpty,",
+		"dev_center_id":          "Type:         pluginsdk.TypeString,\n\t\t\tRequired:     true,\n\t\t\tValidateFunc: validation.StringIsNotEmpty,",
+		"dev_center_uri":         "Type:     pluginsdk.TypeString,\n\t\t\tComputed: true,",
+		"max_dev_boxes_per_user": "Type:     pluginsdk.TypeInt,\n\t\t\tOptional: true,\n\t\t},",
+	}
+	for field, v := range expectedSchema {
+		if !strings.Contains(resource, v) {
+			t.Fatalf("expected the Schema for %q to contain %q:\n\n%s", field, v, resource)
+		}
+	}
+
+	expectedTest := []string{
+		"func TestAccDevCenterProject_basic(t *testing.T) {",
+		"func TestAccDevCenterProject_update(t *testing.T) {",
+		"id, err := projects.ParseProjectID(state.ID)",
+		"clients.DevCenter.V20230401.Projects.Get(ctx, *id)",
+		`resource "azurerm_dev_center_project" "import" {`,
+		"  max_dev_boxes_per_user = 1",
+	}
+	for _, v := range expectedTest {
+		if !strings.Contains(test, v) {
+			t.Fatalf("expected the generated Test to contain %q:\n\n%s", v, test)
+		}
+	}
+}
+
+func TestGenerateResourceWithIdentity(t *testing.T) {
+	input := generatorInput{
+		ResourceType:   "azurerm_dev_center",
+		SdkImportPath:  devCenterSdkPath + "/devcenters",
+		ClientPath:     "DevCenter.V20230401.DevCenters",
+		ServicePackage: "devcenter",
+	}
+	resource, _ := generate(t, input)
+
+	expected := []string{
+		`"identity": commonschema.SystemAssignedUserAssignedIdentityOptional(),`,
+		"Identity          []identity.ModelSystemAssignedUserAssigned `tfschema:\"identity\"`",
+		"expandedIdentity, err := identity.ExpandSystemAndUserAssignedMapFromModel(input.Identity)",
+		"output.Identity = pointer.From(flattenedIdentity)",
+		"output.Location = location.Normalize(input.Location)",
+	}
+	for _, v := range expected {
+		if !strings.Contains(resource, v) {
+			t.Fatalf("expected the generated Resource to contain %q:\n\n%s", v, resource)
+		}
+	}
+}
+
+func TestGenerateResourceWithParentId(t *testing.T) {
+	input := generatorInput{
+		ResourceType:   "azurerm_dev_center_catalog",
+		SdkImportPath:  devCenterSdkPath + "/catalogs",
+		ClientPath:     "DevCenter.V20230401.Catalogs",
+		ServicePackage: "devcenter",
+		Arguments:      []string{"SyncState"},
+	}
+	resource, _ := generate(t, input)
+
+	expected := []string{
+		`"dev_center_id": {`,
+		"ValidateFunc: catalogs.ValidateDevCenterID,",
+		"parentId, err := catalogs.ParseDevCenterID(config.DevCenterId)",
+		"id := catalogs.NewCatalogID(parentId.SubscriptionId, parentId.ResourceGroupName, parentId.DevCenterName, config.Name)",
+		"DevCenterId: catalogs.NewDevCenterID(id.SubscriptionId, id.ResourceGroupName, id.DevCenterName).ID(),",
+		`// TODO: the field "Properties.GitHub" of type "*GitCatalog" isn't supported and must be mapped manually`,
+		"if input.SyncState != \"\" {\n\t\toutput.Properties.SyncState = pointer.To(catalogs.CatalogSyncState(input.SyncState))\n\t}",
+		"output.SyncState = string(pointer.From(input.Properties.SyncState))",
+
+		// the LastSyncTime is conventionally Read-Only
+		"\"last_sync_time\": {\n\t\t\tType:     pluginsdk.TypeString,\n\t\t\tComputed: true,",
+
+		// the SyncState is specified as an Argument, but none of the supported fields can be updated so should be ForceNew
+		"\"sync_state\": {\n\t\t\tType:         pluginsdk.TypeString,\n\t\t\tOptional:     true,\n\t\t\tForceNew:     true,\n\t\t\tValidateFunc: validation.StringInSlice(catalogs.PossibleValuesForCatalogSyncState(), false),",
+	}
+	if strings.Contains(resource, "func (r DevCenterCatalogResource) Update()") {
+		t.Fatalf("expected the generated Resource not to be updatable:\n\n%s", resource)
+	}
+	for _, v := range expected {
+		if !strings.Contains(resource, v) {
+			t.Fatalf("expected the generated Resource to contain %q:\n\n%s", v, resource)
+		}
+	}
+}
+
+func TestGenerateResourceAlreadyExists(t *testing.T) {
+	input := generatorInput{
+		ResourceType:   "azurerm_dev_center_project",
+		SdkImportPath:  devCenterSdkPath + "/projects",
+		ClientPath:     "DevCenter.V20230401.Projects",
+		ServicePackage: "devcenter",
+	}
+	outputPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outputPath, "dev_center_project_resource_gen.go"), []byte("package devcenter"), 0644); err != nil {
+		t.Fatalf("writing the existing file: %+v", err)
+	}
+
+	if err := run(input, sdkPath(input), outputPath); err == nil {
+		t.Fatalf("expected an error when the Resource already exists but didn't get one")
+	}
+}
+
+func generate(t *testing.T, input generatorInput) (resource string, test string) {
+	outputPath := t.TempDir()
+	if err := run(input, sdkPath(input), outputPath); err != nil {
+		t.Fatalf("generating %q: %+v", input.ResourceType, err)
+	}
+
+	fileName := strings.TrimPrefix(input.ResourceType, "azurerm_")
+	resourceContents, err := os.ReadFile(filepath.Join(outputPath, fileName+"_resource_gen.go"))
+	if err != nil {
+		t.Fatalf("reading the generated Resource: %+v", err)
+	}
+	testContents, err := os.ReadFile(filepath.Join(outputPath, fileName+"_resource_gen_test.go"))
+	if err != nil {
+		t.Fatalf("reading the generated Test: %+v", err)
+	}
+
+	return string(resourceContents), string(testContents)
+}
+
+func sdkPath(input generatorInput) string {
+	return filepath.Join("..", "..", "..", "vendor", filepath.FromSlash(input.SdkImportPath))
+}
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package possiblevalues
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+
+	gomonkey "github.com/agiledragon/gomonkey/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Patch replaces `StringInSlice` so that the returned ValidateFunc hands back the valid values as warnings,
+// which allows the possible values to be read from the schema using `FromValidateFunc`.
+//
+// This must be called before the Provider's schema is built, and only by tooling which never validates user input.
+func Patch() {
+	gomonkey.ApplyFunc(validation.StringInSlice,
+		func(valid []string, ignoreCase bool) schema.SchemaValidateFunc { //nolint:staticcheck
+			return func(i interface{}, k string) (warnings []string, errors []error) {
+				var res []string // must have a copy
+				res = append(res, valid...)
+				return res, nil
+			}
+		})
+}
+
+// FromValidateFunc returns the possible values for the ValidateFunc when it's a (patched) `StringInSlice`
+func FromValidateFunc(input schema.SchemaValidateFunc) []string { //nolint:staticcheck
+	if input == nil {
+		return nil
+	}
+
+	pc := reflect.ValueOf(input).Pointer()
+	fn := runtime.FuncForPC(pc).Name()
+	// ValidateFunc may directly use the sdk v2 StringInSlice, in which case the function name will be that of Patch
+	if !strings.Contains(fn, "possiblevalues.Patch") && !strings.Contains(fn, "StringInSlice") {
+		return nil
+	}
+
+	values, _ := input(nil, "")
+	return values
+}
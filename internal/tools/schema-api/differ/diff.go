@@ -5,114 +5,154 @@ package differ
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
 	schema_rules "github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/schema-rules"
 )
 
+// removedResourceRule is reported when a Resource or Data Source is removed without first being deprecated,
+// this can't be expressed as a BreakingChangeRule since it applies to the whole resource rather than a property
+const removedResourceRule = "removed_resource"
+
 type Differ struct {
 	base    *providerjson.ProviderWrapper
 	current *providerjson.ProviderWrapper
 }
 
-func (d *Differ) Diff(fileName string, providerName string) []string {
+// Violation is a single breaking change between the base and current schema, intended to be consumed by PR tooling
+type Violation struct {
+	Resource   string                `json:"resource"`
+	DataSource bool                  `json:"dataSource,omitempty"`
+	Path       string                `json:"path,omitempty"`
+	Rule       string                `json:"rule"`
+	Severity   schema_rules.Severity `json:"severity"`
+	Message    string                `json:"message"`
+}
+
+func (v Violation) String() string {
+	kind := "resource"
+	if v.DataSource {
+		kind = "data source"
+	}
+	return fmt.Sprintf("[%s] %s %q: %s", v.Severity, kind, v.Resource, v.Message)
+}
+
+func (d *Differ) Diff(fileName string, providerName string) ([]Violation, error) {
 	if err := d.loadFromProvider(providerjson.LoadData(), providerName); err != nil {
-		return []string{err.Error()}
+		return nil, err
 	}
 
 	if err := d.loadFromFile(fileName); err != nil {
-		return []string{err.Error()}
+		return nil, err
 	}
 
 	if d.base.ProviderName != d.current.ProviderName {
-		return []string{fmt.Sprintf("provider name mismatch, expected %q, got %q", d.base.ProviderName, d.current.ProviderName)}
+		return nil, fmt.Errorf("provider name mismatch, expected %q, got %q", d.base.ProviderName, d.current.ProviderName)
 	}
 
-	violations := make([]string, 0)
+	return d.compare(), nil
+}
 
-	for resource, rs := range d.current.ProviderSchema.ResourcesMap {
-		_, ok := d.base.ProviderSchema.ResourcesMap[resource]
-		if !ok {
-			// New resource, no breaking changes to worry about
-			continue
+func (d *Differ) compare() []Violation {
+	violations := make([]Violation, 0)
+	violations = append(violations, compareResources(d.base.ProviderSchema.ResourcesMap, d.current.ProviderSchema.ResourcesMap, false, schema_rules.BreakingChangeRules)...)
+	violations = append(violations, compareResources(d.base.ProviderSchema.DataSourcesMap, d.current.ProviderSchema.DataSourcesMap, true, schema_rules.BreakingChangeRulesDataSource)...)
+
+	sort.Slice(violations, func(i, j int) bool {
+		a, b := violations[i], violations[j]
+		if a.DataSource != b.DataSource {
+			return !a.DataSource
 		}
-		for propertyName, propertySchema := range rs.Schema {
-			// Get the same from the base (released) json
-			baseItem, ok := d.base.ProviderSchema.ResourcesMap[resource].Schema[propertyName]
-			if !ok {
-				// New property, could be breaking - Required etc
-				baseItem = providerjson.SchemaJSON{}
-			}
-			if errs := compareNodeResource(baseItem, propertySchema, propertyName); errs != nil {
-				violations = append(violations, errs...)
-			}
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
 		}
-	}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Rule < b.Rule
+	})
+
+	return violations
+}
+
+// compareResources compares each Resource (or Data Source) in the base (released) schema against the current schema.
+// New Resources and Data Sources are not in the base (released) schema, so have no breaking changes to worry about
+func compareResources(base map[string]providerjson.ResourceJSON, current map[string]providerjson.ResourceJSON, dataSource bool, rules []schema_rules.BreakingChangeRule) []Violation {
+	violations := make([]Violation, 0)
 
-	for dataSource, ds := range d.current.ProviderSchema.DataSourcesMap {
-		_, ok := d.base.ProviderSchema.DataSourcesMap[dataSource]
+	for name, baseResource := range base {
+		currentResource, ok := current[name]
 		if !ok {
-			// New data source, no breaking changes to worry about
-			continue
-		}
-		for propertyName, propertySchema := range ds.Schema {
-			// Get the same from the base (released) json
-			baseItem, ok := d.base.ProviderSchema.DataSourcesMap[dataSource].Schema[propertyName]
-			if !ok {
-				// New property, could be breaking - Required etc
-				baseItem = providerjson.SchemaJSON{}
-			}
-			if errs := compareNodeDataSource(baseItem, propertySchema, propertyName); errs != nil {
-				violations = append(violations, errs...)
+			// a removed Resource or Data Source is only a breaking change when it wasn't Deprecated first
+			if baseResource.DeprecationMessage == "" {
+				violations = append(violations, Violation{
+					Resource:   name,
+					DataSource: dataSource,
+					Rule:       removedResourceRule,
+					Severity:   schema_rules.SeverityError,
+					Message:    fmt.Sprintf("%q has been removed without first being Deprecated", name),
+				})
 			}
+			continue
 		}
+
+		violations = append(violations, compareSchemas(name, dataSource, "", baseResource.Schema, currentResource.Schema, rules)...)
 	}
 
 	return violations
 }
 
-func compareNodeResource(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string) (errs []string) {
-	if nodeIsBlock(base) {
-		newBaseRaw := base.Elem.(providerjson.ResourceJSON).Schema
-		newCurrent := current.Elem.(*providerjson.ResourceJSON).Schema
-		for k, newBase := range newBaseRaw {
-			errs = append(errs, compareNodeResource(newBase, newCurrent[k], k)...)
-		}
-	}
+func compareSchemas(resource string, dataSource bool, parentPath string, base map[string]providerjson.SchemaJSON, current map[string]providerjson.SchemaJSON, rules []schema_rules.BreakingChangeRule) []Violation {
+	violations := make([]Violation, 0)
 
-	for _, v := range schema_rules.BreakingChangeRules {
-		if err := v.Check(base, current, nodeName); err != nil {
-			errs = append(errs, *err)
+	for _, propertyName := range propertyNames(base, current) {
+		path := propertyName
+		if parentPath != "" {
+			path = fmt.Sprintf("%s.%s", parentPath, propertyName)
 		}
-	}
-
-	return
-}
 
-func compareNodeDataSource(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string) (errs []string) {
-	if nodeIsBlock(base) {
-		newBaseRaw := base.Elem.(providerjson.ResourceJSON).Schema
-		newCurrent := current.Elem.(*providerjson.ResourceJSON).Schema
-		for k, newBase := range newBaseRaw {
-			errs = append(errs, compareNodeDataSource(newBase, newCurrent[k], k)...)
+		// a property missing from either side is compared against an empty schema, so that the rules can
+		// detect new (e.g. Required) and removed properties
+		baseItem := base[propertyName]
+		currentItem := current[propertyName]
+
+		// changes within a block are only relevant when the block exists in both schemas, new properties in
+		// a new block (or removed properties in a removed block) are covered by the rules for the block itself
+		baseElem, baseIsBlock := baseItem.ElemSchema()
+		currentElem, currentIsBlock := currentItem.ElemSchema()
+		if baseIsBlock && currentIsBlock {
+			violations = append(violations, compareSchemas(resource, dataSource, path, baseElem, currentElem, rules)...)
 		}
-	}
 
-	for _, v := range schema_rules.BreakingChangeRulesDataSource {
-		if err := v.Check(base, current, nodeName); err != nil {
-			errs = append(errs, *err)
+		for _, rule := range rules {
+			if message := rule.Check(baseItem, currentItem, path); message != nil {
+				violations = append(violations, Violation{
+					Resource:   resource,
+					DataSource: dataSource,
+					Path:       path,
+					Rule:       rule.Name(),
+					Severity:   rule.Severity(),
+					Message:    *message,
+				})
+			}
 		}
 	}
 
-	return
+	return violations
 }
 
-func nodeIsBlock(input providerjson.SchemaJSON) bool {
-	if input.Type == providerjson.SchemaTypeList || input.Type == providerjson.SchemaTypeSet {
-		if _, ok := input.Elem.(providerjson.ResourceJSON); ok {
-			return true
+func propertyNames(base map[string]providerjson.SchemaJSON, current map[string]providerjson.SchemaJSON) []string {
+	names := make([]string, 0, len(current))
+	for k := range current {
+		names = append(names, k)
+	}
+	for k := range base {
+		if _, ok := current[k]; !ok {
+			names = append(names, k)
 		}
 	}
+	sort.Strings(names)
 
-	return false
+	return names
 }
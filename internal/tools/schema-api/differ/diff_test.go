@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func TestDiffer_compare(t *testing.T) {
+	d := Differ{
+		base: &providerjson.ProviderWrapper{
+			ProviderSchema: &providerjson.ProviderSchemaJSON{
+				ResourcesMap: map[string]providerjson.ResourceJSON{
+					"azurerm_example": {
+						Schema: map[string]providerjson.SchemaJSON{
+							"name":    {Type: "TypeString", Required: true, ForceNew: true},
+							"removed": {Type: "TypeString", Optional: true},
+							"network_rules": {
+								Type:     providerjson.SchemaTypeList,
+								Optional: true,
+								Elem: providerjson.ResourceJSON{
+									Schema: map[string]providerjson.SchemaJSON{
+										"bypass": {Type: "TypeString", Optional: true},
+									},
+								},
+							},
+						},
+					},
+					"azurerm_removed": {
+						Schema: map[string]providerjson.SchemaJSON{},
+					},
+					"azurerm_deprecated": {
+						Schema:             map[string]providerjson.SchemaJSON{},
+						DeprecationMessage: "deprecated",
+					},
+				},
+			},
+		},
+		current: &providerjson.ProviderWrapper{
+			ProviderSchema: &providerjson.ProviderSchemaJSON{
+				ResourcesMap: map[string]providerjson.ResourceJSON{
+					"azurerm_example": {
+						Schema: map[string]providerjson.SchemaJSON{
+							"name": {Type: "TypeString", Required: true, ForceNew: true},
+							"network_rules": {
+								Type:     providerjson.SchemaTypeList,
+								Optional: true,
+								Elem: &providerjson.ResourceJSON{
+									Schema: map[string]providerjson.SchemaJSON{
+										"bypass":   {Type: "TypeString", Optional: true, ForceNew: true},
+										"ip_rules": {Type: "TypeString", Required: true},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expected := []Violation{
+		{Resource: "azurerm_example", Path: "network_rules.bypass", Rule: "new_force_new_property"},
+		{Resource: "azurerm_example", Path: "network_rules.ip_rules", Rule: "new_required_property"},
+		{Resource: "azurerm_example", Path: "removed", Rule: "removed_property"},
+		{Resource: "azurerm_removed", Rule: "removed_resource"},
+	}
+
+	actual := d.compare()
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d violations, got %d: %+v", len(expected), len(actual), actual)
+	}
+
+	for i, v := range expected {
+		if actual[i].Resource != v.Resource || actual[i].Path != v.Path || actual[i].Rule != v.Rule {
+			t.Errorf("expected violation %d to be %s/%s/%s, got %s/%s/%s", i, v.Resource, v.Path, v.Rule, actual[i].Resource, actual[i].Path, actual[i].Rule)
+		}
+	}
+}
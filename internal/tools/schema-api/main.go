@@ -4,6 +4,7 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
@@ -13,8 +14,11 @@ import (
 	"syscall"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/possiblevalues"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/differ"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+	schema_rules "github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/schema-rules"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/upgradeguide"
 )
 
 func main() {
@@ -25,13 +29,19 @@ func main() {
 	providerName := f.String("provider-name", "azurerm", "set the provider name, defaults to `azurerm`")
 	exportSchema := f.String("export", "", "export the schema to the given path/filename. Intended for use in the release process")
 	detectBreakingChanges := f.String("detect", "", "compare current schema to named dump.")
-	errorOnBreakingChange := f.Bool("error-on-violation", false, "should the detect mode exit with a non-zero error code. Defaults to `false`")
+	errorOnBreakingChange := f.Bool("error-on-violation", false, "should the detect mode exit with a non-zero error code when a violation with the severity `error` is found. Defaults to `false`")
+	upgradeGuideFrom := f.String("upgrade-guide-from", "", "generate an upgrade guide from the named dump to the dump named in `upgrade-guide-to`")
+	upgradeGuideTo := f.String("upgrade-guide-to", "", "the named dump to generate the upgrade guide to")
+	outputFormat := f.String("output", "text", "the format used to output the results of the detect and upgrade guide modes, either `text` (Markdown for the upgrade guide) or `json`")
 
 	if err := f.Parse(os.Args[1:]); err != nil {
 		fmt.Printf("error parsing args: %+v", err)
 		os.Exit(1)
 	}
 
+	// the possible values are read from the patched `StringInSlice`, so this needs patching before the schema is built
+	possiblevalues.Patch()
+
 	data := providerjson.LoadData()
 
 	switch {
@@ -53,13 +63,60 @@ func main() {
 	case pointer.From(detectBreakingChanges) != "":
 		{
 			d := differ.Differ{}
-			if violations := d.Diff(*detectBreakingChanges, *providerName); violations != nil {
+			violations, err := d.Diff(*detectBreakingChanges, *providerName)
+			if err != nil {
+				log.Fatalf("error detecting breaking changes: %+v", err)
+			}
+
+			switch pointer.From(outputFormat) {
+			case "json":
+				if err := json.NewEncoder(os.Stdout).Encode(violations); err != nil {
+					log.Fatalf("error encoding violations: %+v", err)
+				}
+			default:
 				for _, v := range violations {
 					log.Println(v)
 				}
-				if pointer.From(errorOnBreakingChange) {
-					os.Exit(1)
+			}
+
+			if pointer.From(errorOnBreakingChange) {
+				for _, v := range violations {
+					if v.Severity == schema_rules.SeverityError {
+						os.Exit(1)
+					}
+				}
+			}
+
+			os.Exit(0)
+		}
+
+	case pointer.From(upgradeGuideFrom) != "" || pointer.From(upgradeGuideTo) != "":
+		{
+			if pointer.From(upgradeGuideFrom) == "" || pointer.From(upgradeGuideTo) == "" {
+				log.Fatal("both `upgrade-guide-from` and `upgrade-guide-to` must be specified")
+			}
+
+			base, err := providerjson.ReadFromFile(*upgradeGuideFrom)
+			if err != nil {
+				log.Fatalf("error loading %q: %+v", *upgradeGuideFrom, err)
+			}
+			target, err := providerjson.ReadFromFile(*upgradeGuideTo)
+			if err != nil {
+				log.Fatalf("error loading %q: %+v", *upgradeGuideTo, err)
+			}
+
+			report, err := upgradeguide.Generate(base, target)
+			if err != nil {
+				log.Fatalf("error generating upgrade guide: %+v", err)
+			}
+
+			switch pointer.From(outputFormat) {
+			case "json":
+				if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
+					log.Fatalf("error encoding upgrade guide: %+v", err)
 				}
+			default:
+				fmt.Println(report.Markdown())
 			}
 
 			os.Exit(0)
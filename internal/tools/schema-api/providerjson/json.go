@@ -33,6 +33,10 @@ type SchemaJSON struct {
 	Elem        interface{} `json:"elem,omitempty"`
 	MaxItems    int         `json:"maxItems,omitempty"`
 	MinItems    int         `json:"minItems,omitempty"`
+	Deprecated  string      `json:"deprecated,omitempty"`
+
+	// PossibleValues is populated for properties validated by `StringInSlice`
+	PossibleValues []string `json:"possibleValues,omitempty"`
 }
 
 func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
@@ -48,11 +52,19 @@ func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
 	b.Description, _ = m["description"].(string)
 	b.Computed, _ = m["computed"].(bool)
 	b.ForceNew, _ = m["forceNew"].(bool)
+	b.Deprecated, _ = m["deprecated"].(string)
 	if max, ok := m["maxItems"].(float64); ok {
 		b.MaxItems = int(max)
 	}
 	if min, ok := m["minItems"].(float64); ok {
-		b.MaxItems = int(min)
+		b.MinItems = int(min)
+	}
+	if values, ok := m["possibleValues"].([]interface{}); ok {
+		for _, v := range values {
+			if value, ok := v.(string); ok {
+				b.PossibleValues = append(b.PossibleValues, value)
+			}
+		}
 	}
 
 	if def, ok := m["default"]; ok && def != nil {
@@ -83,9 +95,41 @@ func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
 	return nil
 }
 
+// ElemSchema returns the nested schema when the property is a block, regardless of whether it was
+// loaded from the provider (a pointer) or from a JSON dump (a value)
+func (b SchemaJSON) ElemSchema() (map[string]SchemaJSON, bool) {
+	switch e := b.Elem.(type) {
+	case ResourceJSON:
+		return e.Schema, true
+	case *ResourceJSON:
+		if e != nil {
+			return e.Schema, true
+		}
+	}
+
+	return nil, false
+}
+
+// ElemType returns the type of the elements for a List, Set or Map of primitive values
+func (b SchemaJSON) ElemType() string {
+	switch e := b.Elem.(type) {
+	case string:
+		return e
+	case SchemaJSON:
+		return e.Type
+	case *SchemaJSON:
+		if e != nil {
+			return e.Type
+		}
+	}
+
+	return ""
+}
+
 type ResourceJSON struct {
-	Schema   map[string]SchemaJSON `json:"schema"`
-	Timeouts *ResourceTimeoutJSON  `json:"timeouts,omitempty"`
+	Schema             map[string]SchemaJSON `json:"schema"`
+	Timeouts           *ResourceTimeoutJSON  `json:"timeouts,omitempty"`
+	DeprecationMessage string                `json:"deprecationMessage,omitempty"`
 }
 
 type ResourceTimeoutJSON struct {
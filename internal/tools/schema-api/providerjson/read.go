@@ -0,0 +1,25 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerjson
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// ReadFromFile loads a ProviderWrapper previously written using WriteWithWrapper
+func ReadFromFile(fileName string) (*ProviderWrapper, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	buf := &ProviderWrapper{}
+	// TODO - Custom marshalling to fix the type assertions later? meh, works for now...
+	if err := json.NewDecoder(f).Decode(buf); err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
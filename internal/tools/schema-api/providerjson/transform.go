@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/possiblevalues"
 )
 
 func resourceFromRaw(input *schema.Resource) (*ResourceJSON, error) {
@@ -21,6 +22,7 @@ func resourceFromRaw(input *schema.Resource) (*ResourceJSON, error) {
 		translatedSchema[k] = schemaFromRaw(s)
 	}
 	result.Schema = translatedSchema
+	result.DeprecationMessage = input.DeprecationMessage
 
 	if input.Timeouts != nil {
 		timeouts := &ResourceTimeoutJSON{}
@@ -57,6 +59,9 @@ func schemaFromRaw(input *schema.Schema) SchemaJSON {
 		Elem:        decodeElem(input.Elem),
 		MaxItems:    input.MaxItems,
 		MinItems:    input.MinItems,
+		Deprecated:  input.Deprecated,
+
+		PossibleValues: possiblevalues.FromValidateFunc(input.ValidateFunc),
 	}
 }
 
@@ -94,8 +99,14 @@ func SchemaFromMap(input map[string]interface{}) SchemaJSON {
 		result.ForceNew = t.(bool)
 	}
 
-	if t, ok := input["forceNew"]; ok {
-		result.ForceNew = t.(bool)
+	if t, ok := input["deprecated"]; ok {
+		result.Deprecated = t.(string)
+	}
+
+	if t, ok := input["possibleValues"]; ok {
+		for _, v := range t.([]interface{}) {
+			result.PossibleValues = append(result.PossibleValues, v.(string))
+		}
 	}
 
 	if t, ok := input["elem"]; ok {
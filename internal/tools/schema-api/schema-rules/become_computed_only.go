@@ -23,3 +23,11 @@ func (o becomeComputedOnly) Check(base providerjson.SchemaJSON, current provider
 
 	return nil
 }
+
+func (becomeComputedOnly) Name() string {
+	return "become_computed_only"
+}
+
+func (becomeComputedOnly) Severity() Severity {
+	return SeverityError
+}
@@ -23,3 +23,11 @@ func (o defaultValueChange) Check(base providerjson.SchemaJSON, current provider
 
 	return nil
 }
+
+func (defaultValueChange) Name() string {
+	return "default_value_change"
+}
+
+func (defaultValueChange) Severity() Severity {
+	return SeverityError
+}
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRule = maxItemsDecreased{}
+
+type maxItemsDecreased struct{}
+
+// Check - Checks that the MaxItems of a List or Set has not been lowered (or introduced), as existing configurations may exceed it
+func (maxItemsDecreased) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type == "" || current.Type == "" || current.MaxItems == 0 {
+		return nil
+	}
+
+	if base.MaxItems == 0 || current.MaxItems < base.MaxItems {
+		baseMaxItems := "unlimited"
+		if base.MaxItems != 0 {
+			baseMaxItems = strconv.Itoa(base.MaxItems)
+		}
+		return pointer.To(fmt.Sprintf("MaxItems for %q has been reduced from %s to %d", propertyName, baseMaxItems, current.MaxItems))
+	}
+
+	return nil
+}
+
+func (maxItemsDecreased) Name() string {
+	return "max_items_decreased"
+}
+
+func (maxItemsDecreased) Severity() Severity {
+	return SeverityError
+}
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var maxItemsDecreasedBaseNode = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeList,
+	Optional: true,
+	MaxItems: 5,
+}
+
+var maxItemsDecreasedUnlimitedBaseNode = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeList,
+	Optional: true,
+	MaxItems: 0,
+}
+
+var maxItemsDecreasedPasses = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeList,
+	Optional: true,
+	MaxItems: 10,
+}
+
+var maxItemsDecreasedViolates = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeList,
+	Optional: true,
+	MaxItems: 1, // violation
+}
+
+func TestMaxItemsDecreased_Check(t *testing.T) {
+	data := maxItemsDecreased{}
+	if res := data.Check(maxItemsDecreasedBaseNode, maxItemsDecreasedPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+
+	if res := data.Check(maxItemsDecreasedBaseNode, maxItemsDecreasedUnlimitedBaseNode, ""); res != nil {
+		t.Errorf("expected no violation when removing MaxItems, got %+v", res)
+	}
+
+	if res := data.Check(maxItemsDecreasedBaseNode, maxItemsDecreasedViolates, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+
+	if res := data.Check(maxItemsDecreasedUnlimitedBaseNode, maxItemsDecreasedPasses, ""); res == nil {
+		t.Errorf("expected violation when introducing MaxItems, but didn't get one")
+	}
+}
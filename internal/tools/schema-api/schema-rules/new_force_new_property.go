@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRule = newForceNewProperty{}
+
+type newForceNewProperty struct{}
+
+// Check - Checks that an existing property has not become ForceNew, since updates which were previously applied
+// in-place will now recreate the resource
+func (newForceNewProperty) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type != "" && current.Type != "" && !base.ForceNew && current.ForceNew {
+		return pointer.To(fmt.Sprintf("existing property %q has been changed to ForceNew", propertyName))
+	}
+
+	return nil
+}
+
+func (newForceNewProperty) Name() string {
+	return "new_force_new_property"
+}
+
+func (newForceNewProperty) Severity() Severity {
+	return SeverityWarning
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var newForceNewPropertyBaseNode = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeString,
+	Optional: true,
+	ForceNew: false,
+}
+
+var newForceNewPropertyPasses = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeString,
+	Optional: true,
+	ForceNew: false,
+}
+
+var newForceNewPropertyViolates = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeString,
+	Optional: true,
+	ForceNew: true, // violation
+}
+
+func TestNewForceNewProperty_Check(t *testing.T) {
+	data := newForceNewProperty{}
+	if res := data.Check(newForceNewPropertyBaseNode, newForceNewPropertyPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+
+	if res := data.Check(providerjson.SchemaJSON{}, newForceNewPropertyViolates, ""); res != nil {
+		t.Errorf("expected no violation for a new property, got %+v", res)
+	}
+
+	if res := data.Check(newForceNewPropertyBaseNode, newForceNewPropertyViolates, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+}
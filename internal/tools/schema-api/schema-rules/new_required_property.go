@@ -24,3 +24,11 @@ func (newRequiredPropertyExistingResource) Check(base providerjson.SchemaJSON, c
 
 	return nil
 }
+
+func (newRequiredPropertyExistingResource) Name() string {
+	return "new_required_property"
+}
+
+func (newRequiredPropertyExistingResource) Severity() Severity {
+	return SeverityError
+}
@@ -23,3 +23,11 @@ func (optionalRemoveComputed) Check(base providerjson.SchemaJSON, current provid
 
 	return nil
 }
+
+func (optionalRemoveComputed) Name() string {
+	return "optional_remove_computed"
+}
+
+func (optionalRemoveComputed) Severity() Severity {
+	return SeverityError
+}
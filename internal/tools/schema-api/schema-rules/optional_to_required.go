@@ -23,3 +23,11 @@ func (o optionalToRequired) Check(base providerjson.SchemaJSON, current provider
 
 	return nil
 }
+
+func (optionalToRequired) Name() string {
+	return "optional_to_required"
+}
+
+func (optionalToRequired) Severity() Severity {
+	return SeverityError
+}
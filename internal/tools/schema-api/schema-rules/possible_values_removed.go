@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRule = possibleValuesRemoved{}
+
+type possibleValuesRemoved struct{}
+
+// Check - Checks that no values have been removed from the list of possible values for a property. Removing the
+// validation entirely is not a breaking change, so this only applies when both schemas define possible values.
+func (possibleValuesRemoved) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if len(base.PossibleValues) == 0 || len(current.PossibleValues) == 0 {
+		return nil
+	}
+
+	currentValues := make(map[string]struct{}, len(current.PossibleValues))
+	for _, v := range current.PossibleValues {
+		currentValues[v] = struct{}{}
+	}
+
+	removed := make([]string, 0)
+	for _, v := range base.PossibleValues {
+		if _, ok := currentValues[v]; !ok {
+			removed = append(removed, v)
+		}
+	}
+
+	if len(removed) > 0 {
+		return pointer.To(fmt.Sprintf("possible values %q have been removed from property %q", removed, propertyName))
+	}
+
+	return nil
+}
+
+func (possibleValuesRemoved) Name() string {
+	return "possible_values_removed"
+}
+
+func (possibleValuesRemoved) Severity() Severity {
+	return SeverityError
+}
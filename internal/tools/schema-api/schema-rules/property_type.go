@@ -17,10 +17,23 @@ var _ BreakingChangeRule = propertyType{}
 type propertyType struct{}
 
 // Check - Checks for invalid type changes. At the time of writing the only allowed change is a Set to a List
+// The element type of a List, Set or Map of primitive values must also remain the same
 func (propertyType) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
 	if (base.Type != "" && current.Type != "" && base.Type != providerjson.SchemaTypeSet) && base.Type != current.Type {
 		return pointer.To(fmt.Sprintf("schema type has changed for %q (%+v to %+v)", propertyName, base.Type, current.Type))
 	}
 
+	if baseElem, currentElem := base.ElemType(), current.ElemType(); baseElem != "" && currentElem != "" && baseElem != currentElem {
+		return pointer.To(fmt.Sprintf("element type has changed for %q (%+v to %+v)", propertyName, baseElem, currentElem))
+	}
+
 	return nil
 }
+
+func (propertyType) Name() string {
+	return "property_type"
+}
+
+func (propertyType) Severity() Severity {
+	return SeverityError
+}
@@ -79,6 +79,18 @@ var propertyTypeSet = providerjson.SchemaJSON{
 	MinItems:    0,
 }
 
+var propertyTypeListOfStrings = providerjson.SchemaJSON{
+	Type: providerjson.SchemaTypeList,
+	Elem: "TypeString",
+}
+
+var propertyTypeListOfInts = providerjson.SchemaJSON{
+	Type: providerjson.SchemaTypeList,
+	Elem: &providerjson.SchemaJSON{
+		Type: "TypeInt",
+	},
+}
+
 func TestPropertyTypeMatches_Check(t *testing.T) {
 	data := propertyType{}
 	if res := data.Check(propertyTypeBaseNode, propertyTypePasses, ""); res != nil {
@@ -96,4 +108,8 @@ func TestPropertyTypeMatches_Check(t *testing.T) {
 	if res := data.Check(propertyTypeSet, propertyTypeList, ""); res != nil {
 		t.Errorf("expected no violation, got %+v", res)
 	}
+
+	if res := data.Check(propertyTypeListOfStrings, propertyTypeListOfInts, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
 }
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRule = removedProperty{}
+
+type removedProperty struct{}
+
+// Check - Checks that a property is not removed unless it was Deprecated in the base schema
+func (removedProperty) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type != "" && current.Type == "" && base.Deprecated == "" {
+		return pointer.To(fmt.Sprintf("property %q has been removed without first being Deprecated", propertyName))
+	}
+
+	return nil
+}
+
+func (removedProperty) Name() string {
+	return "removed_property"
+}
+
+func (removedProperty) Severity() Severity {
+	return SeverityError
+}
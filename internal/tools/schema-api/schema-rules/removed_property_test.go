@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var removedPropertyBaseNode = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeString,
+	Optional: true,
+}
+
+var removedPropertyDeprecatedBaseNode = providerjson.SchemaJSON{
+	Type:       providerjson.SchemaTypeString,
+	Optional:   true,
+	Deprecated: "`foo` has been deprecated in favour of `bar`",
+}
+
+var removedPropertyPasses = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeString,
+	Optional: true,
+}
+
+var removedPropertyViolates = providerjson.SchemaJSON{}
+
+func TestRemovedProperty_Check(t *testing.T) {
+	data := removedProperty{}
+	if res := data.Check(removedPropertyBaseNode, removedPropertyPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+
+	if res := data.Check(removedPropertyDeprecatedBaseNode, removedPropertyViolates, ""); res != nil {
+		t.Errorf("expected no violation for a deprecated property, got %+v", res)
+	}
+
+	if res := data.Check(removedPropertyBaseNode, removedPropertyViolates, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+}
@@ -5,18 +5,36 @@ package schema_rules
 
 import "github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
 
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
 type BreakingChangeRule interface {
 	Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string
+
+	// Name returns the machine-readable identifier for this rule
+	Name() string
+
+	// Severity returns how serious a violation of this rule is
+	Severity() Severity
 }
 
 var BreakingChangeRules = []BreakingChangeRule{
 	becomeComputedOnly{},
+	maxItemsDecreased{},
+	newForceNewProperty{},
 	newRequiredPropertyExistingResource{},
 	optionalRemoveComputed{},
 	optionalToRequired{},
+	possibleValuesRemoved{},
 	propertyType{},
+	removedProperty{},
 }
 
 var BreakingChangeRulesDataSource = []BreakingChangeRule{
 	propertyType{},
+	removedProperty{},
 }
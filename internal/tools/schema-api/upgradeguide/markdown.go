@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package upgradeguide
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Markdown renders the Report using the same layout as the upgrade guides within `website/docs/guides`
+func (r Report) Markdown() string {
+	var sb strings.Builder
+
+	sb.WriteString("## Data Sources\n\n")
+	if len(r.DataSources) == 0 {
+		sb.WriteString("No changes to existing Data Sources.\n\n")
+	}
+	for _, ds := range r.DataSources {
+		writeResource(&sb, ds, "Data Source", "data source")
+	}
+
+	sb.WriteString("## Resources\n\n")
+	if len(r.Resources) == 0 {
+		sb.WriteString("No changes to existing Resources.\n\n")
+	}
+	for _, rs := range r.Resources {
+		writeResource(&sb, rs, "Resource", "resource")
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func writeResource(sb *strings.Builder, r ResourceReport, heading string, kind string) {
+	sb.WriteString(fmt.Sprintf("### %s: `%s`\n\n", heading, r.Name))
+
+	switch {
+	case r.Removed && r.ReplacedBy != "":
+		sb.WriteString(fmt.Sprintf("The `%s` %s has been removed in favour of the `%s` %[2]s.\n\n", r.Name, kind, r.ReplacedBy))
+	case r.Removed:
+		sb.WriteString(fmt.Sprintf("The `%s` %s has been removed.\n\n", r.Name, kind))
+	case r.ReplacedBy != "":
+		sb.WriteString(fmt.Sprintf("The `%s` %s has been deprecated in favour of the `%s` %[2]s.\n\n", r.Name, kind, r.ReplacedBy))
+	case r.DeprecationMessage != "":
+		sb.WriteString(fmt.Sprintf("The `%s` %s has been deprecated: %s\n\n", r.Name, kind, strings.TrimSpace(r.DeprecationMessage)))
+	}
+
+	lines := make([]string, 0)
+	for _, v := range r.RenamedAttributes {
+		lines = append(lines, fmt.Sprintf("* The field `%s` has been renamed to `%s`.", v.From, v.To))
+	}
+	for _, v := range r.RemovedAttributes {
+		lines = append(lines, fmt.Sprintf("* The field `%s` has been removed.", v))
+	}
+	for _, v := range r.NewRequiredAttributes {
+		lines = append(lines, fmt.Sprintf("* The new field `%s` is Required.", v))
+	}
+	for _, v := range r.ChangedDefaultValues {
+		lines = append(lines, fmt.Sprintf("* The default value for the field `%s` has changed from %s to %s.", v.Path, formatDefault(v.From), formatDefault(v.To)))
+	}
+	for _, v := range r.DeprecatedAttributes {
+		lines = append(lines, fmt.Sprintf("* The field `%s` has been deprecated.", v))
+	}
+
+	if len(lines) > 0 {
+		sb.WriteString(strings.Join(lines, "\n"))
+		sb.WriteString("\n\n")
+	}
+}
+
+func formatDefault(input interface{}) string {
+	if input == nil {
+		return "no default"
+	}
+	return fmt.Sprintf("`%v`", input)
+}
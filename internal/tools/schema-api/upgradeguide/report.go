@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package upgradeguide
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+// Report describes the changes a user needs to be aware of when upgrading between two versions of the provider
+type Report struct {
+	Resources   []ResourceReport `json:"resources,omitempty"`
+	DataSources []ResourceReport `json:"dataSources,omitempty"`
+}
+
+// ResourceReport contains the changes for a single Resource or Data Source
+type ResourceReport struct {
+	Name string `json:"name"`
+
+	// Removed is true when the Resource or Data Source exists in the base schema but not in the target schema
+	Removed bool `json:"removed,omitempty"`
+
+	// DeprecationMessage is the deprecation message from the target schema when the Resource or Data Source has been
+	// deprecated since the base schema, or the deprecation message from the base schema if it has been removed
+	DeprecationMessage string `json:"deprecationMessage,omitempty"`
+
+	// ReplacedBy is the name of the Resource or Data Source which this has been deprecated in favour of, when known
+	ReplacedBy string `json:"replacedBy,omitempty"`
+
+	RenamedAttributes     []RenamedAttribute `json:"renamedAttributes,omitempty"`
+	RemovedAttributes     []string           `json:"removedAttributes,omitempty"`
+	NewRequiredAttributes []string           `json:"newRequiredAttributes,omitempty"`
+	ChangedDefaultValues  []ChangedDefault   `json:"changedDefaultValues,omitempty"`
+	DeprecatedAttributes  []string           `json:"deprecatedAttributes,omitempty"`
+}
+
+type RenamedAttribute struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type ChangedDefault struct {
+	Path string      `json:"path"`
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+func (r ResourceReport) hasChanges() bool {
+	return r.Removed || r.DeprecationMessage != "" || len(r.RenamedAttributes) > 0 || len(r.RemovedAttributes) > 0 ||
+		len(r.NewRequiredAttributes) > 0 || len(r.ChangedDefaultValues) > 0 || len(r.DeprecatedAttributes) > 0
+}
+
+// Generate compares the base (currently used) schema against the target schema and returns the changes, grouped by Resource
+func Generate(base *providerjson.ProviderWrapper, target *providerjson.ProviderWrapper) (*Report, error) {
+	if base == nil || base.ProviderSchema == nil {
+		return nil, fmt.Errorf("base schema was nil")
+	}
+	if target == nil || target.ProviderSchema == nil {
+		return nil, fmt.Errorf("target schema was nil")
+	}
+	if base.ProviderName != target.ProviderName {
+		return nil, fmt.Errorf("provider name mismatch, expected %q, got %q", base.ProviderName, target.ProviderName)
+	}
+
+	return &Report{
+		Resources:   compareResources(base.ProviderSchema.ResourcesMap, target.ProviderSchema.ResourcesMap),
+		DataSources: compareResources(base.ProviderSchema.DataSourcesMap, target.ProviderSchema.DataSourcesMap),
+	}, nil
+}
+
+func compareResources(base map[string]providerjson.ResourceJSON, target map[string]providerjson.ResourceJSON) []ResourceReport {
+	names := make([]string, 0, len(base))
+	for k := range base {
+		names = append(names, k)
+	}
+	for k, v := range target {
+		// new Resources only need to be listed when they're already deprecated
+		if _, ok := base[k]; !ok && v.DeprecationMessage != "" {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+
+	result := make([]ResourceReport, 0)
+	for _, name := range names {
+		report := ResourceReport{
+			Name: name,
+		}
+
+		targetResource, ok := target[name]
+		if !ok {
+			report.Removed = true
+			report.DeprecationMessage = base[name].DeprecationMessage
+		} else {
+			// Resources which were already deprecated in the base schema will have been listed in a previous upgrade guide
+			if base[name].DeprecationMessage == "" {
+				report.DeprecationMessage = targetResource.DeprecationMessage
+			}
+			compareSchemas(&report, "", base[name].Schema, targetResource.Schema)
+		}
+		report.ReplacedBy = replacementFromDeprecationMessage(name, report.DeprecationMessage)
+
+		if report.hasChanges() {
+			result = append(result, report)
+		}
+	}
+
+	return result
+}
+
+func compareSchemas(report *ResourceReport, parentPath string, base map[string]providerjson.SchemaJSON, target map[string]providerjson.SchemaJSON) {
+	names := make([]string, 0)
+	for k := range base {
+		names = append(names, k)
+	}
+	for k := range target {
+		if _, ok := base[k]; !ok {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := name
+		if parentPath != "" {
+			path = fmt.Sprintf("%s.%s", parentPath, name)
+		}
+
+		baseItem, inBase := base[name]
+		targetItem, inTarget := target[name]
+
+		switch {
+		case inBase && !inTarget:
+			if renamedTo := renamedAttribute(name, baseItem.Deprecated, target); renamedTo != "" {
+				to := renamedTo
+				if parentPath != "" {
+					to = fmt.Sprintf("%s.%s", parentPath, renamedTo)
+				}
+				report.RenamedAttributes = append(report.RenamedAttributes, RenamedAttribute{
+					From: path,
+					To:   to,
+				})
+				continue
+			}
+			report.RemovedAttributes = append(report.RemovedAttributes, path)
+
+		case !inBase && inTarget:
+			if targetItem.Required {
+				report.NewRequiredAttributes = append(report.NewRequiredAttributes, path)
+			}
+
+		default:
+			if fmt.Sprintf("%v", baseItem.Default) != fmt.Sprintf("%v", targetItem.Default) {
+				report.ChangedDefaultValues = append(report.ChangedDefaultValues, ChangedDefault{
+					Path: path,
+					From: baseItem.Default,
+					To:   targetItem.Default,
+				})
+			}
+			if baseItem.Deprecated == "" && targetItem.Deprecated != "" {
+				report.DeprecatedAttributes = append(report.DeprecatedAttributes, path)
+			}
+
+			baseElem, baseIsBlock := baseItem.ElemSchema()
+			targetElem, targetIsBlock := targetItem.ElemSchema()
+			if baseIsBlock && targetIsBlock {
+				compareSchemas(report, path, baseElem, targetElem)
+			}
+		}
+	}
+}
+
+var quotedNameRegex = regexp.MustCompile("[`'\"]([a-z0-9_.]+)[`'\"]")
+
+// renamedAttribute returns the name of the attribute which replaces the removed attribute `name`, found by looking
+// for an attribute referenced in the deprecation message which exists at the same level of the target schema. The
+// replacement is typically added alongside the deprecated attribute, so may also exist in the base schema
+func renamedAttribute(name string, deprecationMessage string, target map[string]providerjson.SchemaJSON) string {
+	if deprecationMessage == "" {
+		return ""
+	}
+
+	for _, match := range quotedNameRegex.FindAllStringSubmatch(deprecationMessage, -1) {
+		candidate := match[1]
+		if idx := strings.LastIndex(candidate, "."); idx >= 0 {
+			candidate = candidate[idx+1:]
+		}
+		if candidate == name {
+			continue
+		}
+		if _, inTarget := target[candidate]; inTarget {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+var resourceNameRegex = regexp.MustCompile(`azurerm_[a-z0-9_]+`)
+
+// replacementFromDeprecationMessage returns the Resource named in the deprecation message, this is generated by the
+// `DeprecatedInFavourOfResource` / `DeprecatedInFavourOfDataSource` functions for Typed Resources and is commonly
+// written by hand for Untyped Resources
+func replacementFromDeprecationMessage(name string, deprecationMessage string) string {
+	for _, match := range resourceNameRegex.FindAllString(deprecationMessage, -1) {
+		if match != name {
+			return match
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package upgradeguide
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func TestGenerate(t *testing.T) {
+	base := &providerjson.ProviderWrapper{
+		ProviderName: "azurerm",
+		ProviderSchema: &providerjson.ProviderSchemaJSON{
+			ResourcesMap: map[string]providerjson.ResourceJSON{
+				"azurerm_example": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name":        {Type: "TypeString", Required: true},
+						"sku":         {Type: "TypeString", Optional: true, Default: "Basic"},
+						"old_setting": {Type: "TypeBool", Optional: true, Deprecated: "`old_setting` has been deprecated in favour of `new_setting`"},
+						"legacy":      {Type: "TypeString", Optional: true},
+						"network": {
+							Type:     providerjson.SchemaTypeList,
+							Optional: true,
+							Elem: providerjson.ResourceJSON{
+								Schema: map[string]providerjson.SchemaJSON{
+									"subnet_id": {Type: "TypeString", Optional: true},
+								},
+							},
+						},
+					},
+				},
+				"azurerm_old": {
+					Schema:             map[string]providerjson.SchemaJSON{},
+					DeprecationMessage: `The "azurerm_old" resource has been deprecated and replaced by the "azurerm_new" resource.`,
+				},
+				"azurerm_unchanged": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name": {Type: "TypeString", Required: true},
+					},
+				},
+			},
+		},
+	}
+	target := &providerjson.ProviderWrapper{
+		ProviderName: "azurerm",
+		ProviderSchema: &providerjson.ProviderSchemaJSON{
+			ResourcesMap: map[string]providerjson.ResourceJSON{
+				"azurerm_example": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name":        {Type: "TypeString", Required: true},
+						"sku":         {Type: "TypeString", Optional: true, Default: "Standard"},
+						"new_setting": {Type: "TypeBool", Optional: true},
+						"network": {
+							Type:     providerjson.SchemaTypeList,
+							Optional: true,
+							Elem: providerjson.ResourceJSON{
+								Schema: map[string]providerjson.SchemaJSON{
+									"subnet_id": {Type: "TypeString", Optional: true},
+									"zone":      {Type: "TypeString", Required: true},
+								},
+							},
+						},
+					},
+				},
+				"azurerm_unchanged": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name": {Type: "TypeString", Required: true},
+					},
+				},
+			},
+		},
+	}
+
+	actual, err := Generate(base, target)
+	if err != nil {
+		t.Fatalf("generating report: %+v", err)
+	}
+
+	expected := []ResourceReport{
+		{
+			Name:                  "azurerm_example",
+			RenamedAttributes:     []RenamedAttribute{{From: "old_setting", To: "new_setting"}},
+			RemovedAttributes:     []string{"legacy"},
+			NewRequiredAttributes: []string{"network.zone"},
+			ChangedDefaultValues:  []ChangedDefault{{Path: "sku", From: "Basic", To: "Standard"}},
+		},
+		{
+			Name:               "azurerm_old",
+			Removed:            true,
+			DeprecationMessage: `The "azurerm_old" resource has been deprecated and replaced by the "azurerm_new" resource.`,
+			ReplacedBy:         "azurerm_new",
+		},
+	}
+	if !reflect.DeepEqual(actual.Resources, expected) {
+		t.Fatalf("expected %+v\n\ngot %+v", expected, actual.Resources)
+	}
+
+	markdown := actual.Markdown()
+	for _, v := range []string{
+		"### Resource: `azurerm_example`",
+		"* The field `old_setting` has been renamed to `new_setting`.",
+		"* The default value for the field `sku` has changed from `Basic` to `Standard`.",
+		"The `azurerm_old` resource has been removed in favour of the `azurerm_new` resource.",
+	} {
+		if !strings.Contains(markdown, v) {
+			t.Errorf("expected the markdown to contain %q, got:\n%s", v, markdown)
+		}
+	}
+}
+
+func TestGenerate_deprecatedInPreviousVersion(t *testing.T) {
+	// the replacement attribute is added alongside the deprecated attribute, so exists in both schemas
+	base := &providerjson.ProviderWrapper{
+		ProviderName: "azurerm",
+		ProviderSchema: &providerjson.ProviderSchemaJSON{
+			ResourcesMap: map[string]providerjson.ResourceJSON{
+				"azurerm_example": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name":        {Type: "TypeString", Required: true},
+						"old_setting": {Type: "TypeBool", Optional: true, Deprecated: "`old_setting` has been deprecated in favour of `new_setting`"},
+						"new_setting": {Type: "TypeBool", Optional: true},
+					},
+				},
+				"azurerm_deprecated": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name": {Type: "TypeString", Required: true},
+					},
+					DeprecationMessage: `The "azurerm_deprecated" resource has been deprecated and will be removed in a future version.`,
+				},
+			},
+		},
+	}
+	target := &providerjson.ProviderWrapper{
+		ProviderName: "azurerm",
+		ProviderSchema: &providerjson.ProviderSchemaJSON{
+			ResourcesMap: map[string]providerjson.ResourceJSON{
+				"azurerm_example": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name":        {Type: "TypeString", Required: true},
+						"new_setting": {Type: "TypeBool", Optional: true},
+					},
+				},
+				"azurerm_deprecated": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name": {Type: "TypeString", Required: true},
+					},
+					DeprecationMessage: `The "azurerm_deprecated" resource has been deprecated and will be removed in a future version.`,
+				},
+			},
+		},
+	}
+
+	actual, err := Generate(base, target)
+	if err != nil {
+		t.Fatalf("generating report: %+v", err)
+	}
+
+	expected := []ResourceReport{
+		{
+			Name:              "azurerm_example",
+			RenamedAttributes: []RenamedAttribute{{From: "old_setting", To: "new_setting"}},
+		},
+	}
+	if !reflect.DeepEqual(actual.Resources, expected) {
+		t.Fatalf("expected %+v\n\ngot %+v", expected, actual.Resources)
+	}
+}
@@ -6,10 +6,16 @@ package main
 import (
 	"flag"
 	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
 	"log"
 	"math"
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
@@ -18,6 +24,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/magodo/terraform-provider-azurerm-example-gen/examplegen"
 )
 
@@ -28,7 +35,7 @@ func main() {
 
 	resourceName := f.String("name", "", "The name of the Data Source/Resource which should be generated")
 	brandName := f.String("brand-name", "", "The friendly/brand name of this Data Source/Resource (e.g. Resource Group)")
-	resourceId := f.String("resource-id", "", "An Azure Resource ID showing an example of how to Import this Resource")
+	resourceId := f.String("resource-id", "", "An Azure Resource ID showing an example of how to Import this Resource (defaults to an example built from the Segments of the Resource ID used by a Typed Resource)")
 	resourceType := f.String("type", "", "Whether this is a Data Source (data) or a Resource (resource)")
 	websitePath := f.String("website-path", "", "The relative path to the website folder")
 
@@ -71,10 +78,6 @@ func main() {
 	}
 
 	isResource := *resourceType == "resource"
-	if isResource && (resourceId == nil || *resourceId == "") {
-		quitWithError("An example of an Azure Resource ID must be specified via `-resource-id` when scaffolding for a Resource")
-		return
-	}
 
 	var expsrc *examplegen.ExampleSource
 	if *genExample {
@@ -119,6 +122,7 @@ func getContent(resourceName, brandName string, resourceId *string, isResource b
 					}
 
 					generator.resource = dsWrapper
+					generator.model = ds.ModelObject()
 					generator.websiteCategories = service.WebsiteCategories()
 					break
 				}
@@ -148,6 +152,8 @@ func getContent(resourceName, brandName string, resourceId *string, isResource b
 					}
 
 					generator.resource = rsWrapper
+					generator.model = rs.ModelObject()
+					generator.idValidationFunc = rs.IDValidationFunc()
 					generator.websiteCategories = service.WebsiteCategories()
 					break
 				}
@@ -166,6 +172,22 @@ func getContent(resourceName, brandName string, resourceId *string, isResource b
 		if generator.resource == nil {
 			return nil, fmt.Errorf("Resource %q was not registered!", resourceName)
 		}
+
+		if resourceId == nil || *resourceId == "" {
+			if generator.idValidationFunc == nil {
+				return nil, fmt.Errorf("an example of an Azure Resource ID must be specified via `-resource-id` when scaffolding for an Untyped Resource")
+			}
+
+			example, err := exampleResourceIdFromValidationFunc(generator.idValidationFunc)
+			if err != nil {
+				return nil, fmt.Errorf("building an example Resource ID for %q (specify one via `-resource-id` instead): %+v", resourceName, err)
+			}
+			generator.resourceId = example
+		}
+	}
+
+	for _, field := range generator.fieldsMissingFromModel() {
+		log.Printf("[WARN] %q is defined in the Schema but has no matching `tfschema` field in the Model %T", field, generator.model)
 	}
 
 	docs := generator.generate()
@@ -215,9 +237,16 @@ type documentationGenerator struct {
 	// isDataSource defines if this is a Data Source (if not it's a Resource)
 	isDataSource bool
 
-	// resourceId is an example of the ID used by this Resource
+	// resourceId is an example of the ID used by this Resource, when not specified an example is built
+	// from the segments of the Resource ID used by this Resource
 	resourceId *string
 
+	// idValidationFunc is the function used to validate the Resource ID for Typed Resources (and nil for Untyped)
+	idValidationFunc pluginsdk.SchemaValidateFunc
+
+	// model is the `tfschema` Model Object for Typed Data Sources/Resources (and nil for Untyped)
+	model interface{}
+
 	// websiteCategories is the list of categories available by this service definition
 	websiteCategories []string
 
@@ -564,6 +593,9 @@ func (gen documentationGenerator) buildIndentForExample(level int) string {
 }
 
 func (gen documentationGenerator) buildDescriptionForArgument(name string, field *schema.Schema, blockName string) string {
+	if field.Description != "" {
+		return gen.descriptionFromSchema(field)
+	}
 	if name == "name" {
 		if blockName == "" {
 			if gen.isDataSource {
@@ -632,6 +664,9 @@ func (gen documentationGenerator) buildDescriptionForArgument(name string, field
 }
 
 func (gen documentationGenerator) buildDescriptionForAttribute(name string, field *schema.Schema, blockName string) string {
+	if field.Description != "" {
+		return gen.descriptionFromSchema(field)
+	}
 	if name == "name" {
 		if blockName == "" {
 			return fmt.Sprintf("The name of this %s.", gen.brandName)
@@ -835,17 +870,7 @@ func (gen documentationGenerator) uniqueBlockNamesForArgument(fields map[string]
 			continue
 		}
 
-		if field.Type != schema.TypeList && field.Type != schema.TypeSet {
-			continue
-		}
-
-		if field.Elem == nil {
-			continue
-		}
-		v, ok := field.Elem.(*schema.Resource)
-		if !ok {
-			continue
-		}
+		v := gen.nestedBlock(field)
 		if v == nil {
 			continue
 		}
@@ -854,30 +879,11 @@ func (gen documentationGenerator) uniqueBlockNamesForArgument(fields map[string]
 		blockNames = append(blockNames, fieldName)
 		blocks[fieldName] = v.Schema
 
-		// at this point we want to iterate over all the fields to determine which ones are nested blocks, then iterate over/aggregate those
-		for _, innerElem := range v.Schema {
-			if innerElem.Type != schema.TypeList && innerElem.Type != schema.TypeSet {
-				continue
-			}
-			if field.Elem == nil {
-				continue
-			}
-
-			innerV, ok := field.Elem.(*schema.Resource)
-			if !ok {
-				continue
-			}
-			if innerV == nil {
-				continue
-			}
-
-			innerBlockNames, innerBlocks := gen.uniqueBlockNamesForArgument(innerV.Schema)
-			for _, innerBlockName := range innerBlockNames {
-				innerBlock := innerBlocks[innerBlockName]
-
-				blockNames = append(blockNames, innerBlockName)
-				blocks[innerBlockName] = innerBlock
-			}
+		// then any blocks nested within this block, however deep they go
+		innerBlockNames, innerBlocks := gen.uniqueBlockNamesForArgument(v.Schema)
+		for _, innerBlockName := range innerBlockNames {
+			blockNames = append(blockNames, innerBlockName)
+			blocks[innerBlockName] = innerBlocks[innerBlockName]
 		}
 	}
 
@@ -904,54 +910,214 @@ func (gen documentationGenerator) uniqueBlockNamesForAttribute(fields map[string
 			continue
 		}
 
-		if field.Type != schema.TypeList && field.Type != schema.TypeSet {
+		v := gen.nestedBlock(field)
+		if v == nil {
 			continue
 		}
 
-		if field.Elem == nil {
-			continue
-		}
-		v, ok := field.Elem.(*schema.Resource)
-		if !ok {
-			continue
+		// add this block
+		blockNames = append(blockNames, fieldName)
+		blocks[fieldName] = v.Schema
+
+		// everything within a computed-only block is computed too, so any nested blocks need documenting
+		innerBlockNames, innerBlocks := gen.uniqueBlockNamesForNestedAttribute(v.Schema)
+		for _, innerBlockName := range innerBlockNames {
+			blockNames = append(blockNames, innerBlockName)
+			blocks[innerBlockName] = innerBlocks[innerBlockName]
 		}
+	}
+
+	blockNames = gen.distinctBlockNames(blockNames)
+	sort.Strings(blockNames)
+
+	return blockNames, blocks
+}
+
+func (gen documentationGenerator) uniqueBlockNamesForNestedAttribute(fields map[string]*schema.Schema) ([]string, map[string]map[string]*schema.Schema) {
+	blockNames := make([]string, 0)
+	blocks := make(map[string]map[string]*schema.Schema)
+
+	for _, fieldName := range gen.sortFields(fields) {
+		v := gen.nestedBlock(fields[fieldName])
 		if v == nil {
 			continue
 		}
 
-		// add this block
 		blockNames = append(blockNames, fieldName)
 		blocks[fieldName] = v.Schema
 
-		// at this point we want to iterate over all the fields to determine which ones are nested blocks, then iterate over/aggregate those
-		for _, innerElem := range v.Schema {
-			if innerElem.Type != schema.TypeList && innerElem.Type != schema.TypeSet {
-				continue
-			}
-			if field.Elem == nil {
+		innerBlockNames, innerBlocks := gen.uniqueBlockNamesForNestedAttribute(v.Schema)
+		for _, innerBlockName := range innerBlockNames {
+			blockNames = append(blockNames, innerBlockName)
+			blocks[innerBlockName] = innerBlocks[innerBlockName]
+		}
+	}
+
+	return blockNames, blocks
+}
+
+// nestedBlock returns the Resource nested within this field, or nil when this field isn't a block
+func (gen documentationGenerator) nestedBlock(field *schema.Schema) *schema.Resource {
+	if field.Type != schema.TypeList && field.Type != schema.TypeSet {
+		return nil
+	}
+
+	v, ok := field.Elem.(*schema.Resource)
+	if !ok || v == nil {
+		return nil
+	}
+
+	return v
+}
+
+// descriptionFromSchema returns the Description defined in the Schema, which Typed Data Sources/Resources
+// define for their Arguments and Attributes - ensuring it's terminated like the rest of the documentation.
+func (gen documentationGenerator) descriptionFromSchema(field *schema.Schema) string {
+	description := strings.TrimSpace(field.Description)
+	if !strings.HasSuffix(description, ".") && !strings.HasSuffix(description, "?") {
+		description += "."
+	}
+	return description
+}
+
+// exampleResourceIdFromValidationFunc builds an example of the Resource ID validated by `validateFunc` from the
+// example values of its Segments. Since the type of the Resource ID can't be determined from the function at
+// runtime, this locates the source of the package containing the function and reads the example values from the
+// `Segments()` method of the Resource ID defined alongside it, as generated for the Resource IDs within
+// `go-azure-sdk` and `commonids`.
+func exampleResourceIdFromValidationFunc(validateFunc pluginsdk.SchemaValidateFunc) (*string, error) {
+	fn := runtime.FuncForPC(reflect.ValueOf(validateFunc).Pointer())
+	if fn == nil {
+		return nil, fmt.Errorf("unable to determine the name of the ID Validation Function")
+	}
+
+	// e.g. `github.com/hashicorp/go-azure-helpers/resourcemanager/commonids.ValidateAppServicePlanID`
+	fullName := fn.Name()
+	lastSlash := strings.LastIndex(fullName, "/")
+	dot := strings.Index(fullName[lastSlash+1:], ".")
+	if dot == -1 {
+		return nil, fmt.Errorf("unable to determine the package of the ID Validation Function %q", fullName)
+	}
+	packagePath := fullName[:lastSlash+1+dot]
+	funcName := fullName[lastSlash+1+dot+1:]
+	if !strings.HasPrefix(funcName, "Validate") || strings.Contains(funcName, ".") {
+		return nil, fmt.Errorf("expected the ID Validation Function to be a generated `Validate...ID` function but got %q", fullName)
+	}
+
+	pkg, err := build.Import(packagePath, ".", build.FindOnly)
+	if err != nil {
+		return nil, fmt.Errorf("locating the package %q: %+v", packagePath, err)
+	}
+
+	fileSet := token.NewFileSet()
+	packages, err := parser.ParseDir(fileSet, pkg.Dir, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the package %q: %+v", packagePath, err)
+	}
+
+	for _, p := range packages {
+		for _, file := range p.Files {
+			if !fileDeclaresFunc(file, funcName) {
 				continue
 			}
 
-			innerV, ok := field.Elem.(*schema.Resource)
-			if !ok {
-				continue
+			segments := segmentsFromFile(file)
+			if len(segments) == 0 {
+				return nil, fmt.Errorf("the `Segments()` of the Resource ID validated by %q weren't found in %q", funcName, packagePath)
 			}
-			if innerV == nil {
-				continue
+
+			components := make([]string, 0)
+			for _, segment := range segments {
+				components = append(components, strings.TrimPrefix(segment, "/"))
 			}
+			example := fmt.Sprintf("/%s", strings.Join(components, "/"))
+			return &example, nil
+		}
+	}
+
+	return nil, fmt.Errorf("the ID Validation Function %q wasn't found in %q", funcName, pkg.Dir)
+}
 
-			innerBlockNames, innerBlocks := gen.uniqueBlockNamesForAttribute(innerV.Schema)
-			for _, innerBlockName := range innerBlockNames {
-				innerBlock := innerBlocks[innerBlockName]
+func fileDeclaresFunc(file *ast.File, name string) bool {
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == name {
+			return true
+		}
+	}
+	return false
+}
 
-				blockNames = append(blockNames, innerBlockName)
-				blocks[innerBlockName] = innerBlock
+// segmentsFromFile returns the example values of the Segments returned from the `Segments()` method within
+// the file, which is the last (string) argument passed to each of the `resourceids.*Segment` functions
+func segmentsFromFile(file *ast.File) []string {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || fn.Name.Name != "Segments" || fn.Body == nil {
+			continue
+		}
+
+		output := make([]string, 0)
+		ast.Inspect(fn.Body, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			if literal, ok := call.Args[len(call.Args)-1].(*ast.BasicLit); ok && literal.Kind == token.STRING {
+				if v, err := strconv.Unquote(literal.Value); err == nil {
+					output = append(output, v)
+				}
 			}
+			return false
+		})
+		return output
+	}
+
+	return nil
+}
+
+// fieldsMissingFromModel returns the paths of any fields within the Schema which don't have a matching `tfschema`
+// field in the Model for Typed Data Sources/Resources, since these won't be set and shouldn't be documented as-is.
+func (gen documentationGenerator) fieldsMissingFromModel() []string {
+	if gen.model == nil || gen.resource == nil {
+		return nil
+	}
+
+	return gen.compareSchemaToModel(gen.resource.Schema, reflect.TypeOf(gen.model), "")
+}
+
+func (gen documentationGenerator) compareSchemaToModel(fields map[string]*schema.Schema, modelType reflect.Type, path string) []string {
+	for modelType.Kind() == reflect.Ptr || modelType.Kind() == reflect.Slice {
+		modelType = modelType.Elem()
+	}
+	if modelType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	modelFields := make(map[string]reflect.Type)
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		if tag, ok := field.Tag.Lookup("tfschema"); ok {
+			modelFields[strings.Split(tag, ",")[0]] = field.Type
 		}
 	}
 
-	blockNames = gen.distinctBlockNames(blockNames)
-	sort.Strings(blockNames)
+	output := make([]string, 0)
+	for _, fieldName := range gen.sortFields(fields) {
+		fieldPath := fieldName
+		if path != "" {
+			fieldPath = fmt.Sprintf("%s.%s", path, fieldName)
+		}
 
-	return blockNames, blocks
+		modelField, ok := modelFields[fieldName]
+		if !ok {
+			output = append(output, fieldPath)
+			continue
+		}
+
+		if v := gen.nestedBlock(fields[fieldName]); v != nil {
+			output = append(output, gen.compareSchemaToModel(v.Schema, modelField, fieldPath)...)
+		}
+	}
+
+	return output
 }
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/localusers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/sergi/go-diff/diffmatchpatch"
 )
@@ -162,6 +164,176 @@ A 'block3' block supports the following:
 	runTest(t, expectedOut, actualOut)
 }
 
+func TestResourceArgumentBlockFromTypedSchema(t *testing.T) {
+	expectedOut := strings.ReplaceAll(`## Arguments Reference
+
+The following arguments are supported:
+
+* 'name' - (Required) The name of the Foobar. Changing this forces a new Foobar to be created.
+
+---
+
+* 'outer' - (Optional) An 'outer' block as defined below.
+
+---
+
+A 'deepest' block supports the following:
+
+* 'value' - (Required) The value of the deepest block.
+
+---
+
+A 'inner' block supports the following:
+
+* 'deepest' - (Optional) A 'deepest' block as defined above.
+
+---
+
+A 'outer' block supports the following:
+
+* 'inner' - (Optional) An 'inner' block as defined above.`, "'", "`")
+
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Foobar",
+			},
+			"outer": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "An `outer` block as defined below.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"inner": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "An `inner` block as defined above.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"deepest": {
+										Type:     schema.TypeList,
+										Optional: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"value": {
+													Type:        schema.TypeString,
+													Required:    true,
+													Description: "The value of the deepest block.",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	gen := setupDocGen(false, resource)
+	actualOut := gen.argumentsBlock()
+
+	runTest(t, expectedOut, actualOut)
+}
+
+func TestExampleResourceIdFromValidationFunc(t *testing.T) {
+	testData := []struct {
+		name         string
+		validateFunc schema.SchemaValidateFunc
+		expected     string
+		error        bool
+	}{
+		{
+			name:         "go-azure-sdk",
+			validateFunc: localusers.ValidateLocalUserID,
+			expected:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group/providers/Microsoft.Storage/storageAccounts/storageAccountValue/localUsers/localUserValue",
+		},
+		{
+			name:         "commonids",
+			validateFunc: commonids.ValidateResourceGroupID,
+			expected:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group",
+		},
+		{
+			name: "closure",
+			validateFunc: func(i interface{}, k string) ([]string, []error) {
+				return nil, nil
+			},
+			error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		actual, err := exampleResourceIdFromValidationFunc(v.validateFunc)
+		if err != nil {
+			if v.error {
+				continue
+			}
+			t.Fatalf("unexpected error for %q: %+v", v.name, err)
+		}
+		if v.error {
+			t.Fatalf("expected an error for %q but got %q", v.name, *actual)
+		}
+		if *actual != v.expected {
+			t.Fatalf("expected %q but got %q for %q", v.expected, *actual, v.name)
+		}
+	}
+}
+
+func TestFieldsMissingFromModel(t *testing.T) {
+	type nestedModel struct {
+		Value string `tfschema:"value"`
+	}
+	type model struct {
+		Name   string        `tfschema:"name"`
+		Nested []nestedModel `tfschema:"nested"`
+	}
+
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"missing": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"nested": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"value": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"other": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+	gen := setupDocGen(false, resource)
+	gen.model = &model{}
+
+	actual := strings.Join(gen.fieldsMissingFromModel(), ",")
+	if expected := "missing,nested.other"; actual != expected {
+		t.Fatalf("expected the fields missing from the model to be %q but got %q", expected, actual)
+	}
+}
+
 func runTest(t *testing.T, expected, actual string) {
 	dmp := diffmatchpatch.New()
 	diffs := dmp.DiffMain(actual, expected, true)